@@ -5,6 +5,7 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
@@ -25,12 +26,24 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error
+	UntrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error
+	ReloadChainConfig(ctx context.Context, chain string, options ...rpc.Option) (*ReloadChainConfigReply, error)
+	DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanIPRange(ctx context.Context, ipRange string, duration time.Duration, options ...rpc.Option) error
+	UnbanIPRange(ctx context.Context, ipRange string, options ...rpc.Option) error
+	ListBans(ctx context.Context, options ...rpc.Option) ([]Ban, error)
+	PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	BackupDB(ctx context.Context, name string, options ...rpc.Option) (*BackupDBReply, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -83,6 +96,77 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.trackSubnet", &TrackSubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UntrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.untrackSubnet", &TrackSubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ReloadChainConfig(ctx context.Context, chain string, options ...rpc.Option) (*ReloadChainConfigReply, error) {
+	res := &ReloadChainConfigReply{}
+	err := c.requester.SendRequest(ctx, "admin.reloadChainConfig", &ReloadChainConfigArgs{
+		Chain: chain,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.disconnectPeer", &PeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banNode", &BanNodeArgs{
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanNode(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanNode", &PeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanIPRange(ctx context.Context, ipRange string, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banIPRange", &BanIPRangeArgs{
+		IPRange:  ipRange,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanIPRange(ctx context.Context, ipRange string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanIPRange", &IPRangeArgs{
+		IPRange: ipRange,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ListBans(ctx context.Context, options ...rpc.Option) ([]Ban, error) {
+	res := &ListBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.listBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) PinPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.pinPeer", &PinPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnpinPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unpinPeer", &PeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -163,3 +247,11 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+func (c *client) BackupDB(ctx context.Context, name string, options ...rpc.Option) (*BackupDBReply, error) {
+	res := &BackupDBReply{}
+	err := c.requester.SendRequest(ctx, "admin.backupDB", &BackupDBArgs{
+		Name: name,
+	}, res, options...)
+	return res, err
+}
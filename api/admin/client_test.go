@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -62,6 +63,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
+	case *ReloadChainConfigReply:
+		response := mc.response.(*ReloadChainConfigReply)
+		*p = *response
+	case *ListBansReply:
+		response := mc.response.(*ListBansReply)
+		*p = *response
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
@@ -155,6 +162,113 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestTrackSubnet(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.TrackSubnet(context.Background(), ids.GenerateTestID())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestUntrackSubnet(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.UntrackSubnet(context.Background(), ids.GenerateTestID())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestReloadChainConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &ReloadChainConfigReply{
+			Changed:   true,
+			Restarted: true,
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.ReloadChainConfig(context.Background(), "chain")
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ReloadChainConfigReply{}, errTest)}
+		_, err := mockClient.ReloadChainConfig(context.Background(), "chain")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestPeerManagement(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	tests := map[string]func(client) error{
+		"DisconnectPeer": func(c client) error {
+			return c.DisconnectPeer(context.Background(), nodeID)
+		},
+		"BanNode": func(c client) error {
+			return c.BanNode(context.Background(), nodeID, time.Hour)
+		},
+		"UnbanNode": func(c client) error {
+			return c.UnbanNode(context.Background(), nodeID)
+		},
+		"BanIPRange": func(c client) error {
+			return c.BanIPRange(context.Background(), "10.0.0.0/8", time.Hour)
+		},
+		"UnbanIPRange": func(c client) error {
+			return c.UnbanIPRange(context.Background(), "10.0.0.0/8")
+		},
+		"PinPeer": func(c client) error {
+			return c.PinPeer(context.Background(), nodeID, "1.2.3.4:9651")
+		},
+		"UnpinPeer": func(c client) error {
+			return c.UnpinPeer(context.Background(), nodeID)
+		},
+	}
+	for method, call := range tests {
+		for _, test := range SuccessResponseTests {
+			t.Run(method+" "+test.name, func(t *testing.T) {
+				mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+				err := call(mockClient)
+				require.ErrorIs(t, err, test.expectedErr)
+			})
+		}
+	}
+}
+
+func TestListBans(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		nodeID := ids.GenerateTestNodeID()
+		expectedBans := []Ban{
+			{
+				NodeID: &nodeID,
+				Expiry: time.Unix(1_000, 0),
+			},
+			{
+				IPRange: "10.0.0.0/8",
+				Expiry:  time.Unix(2_000, 0),
+			},
+		}
+		mockClient := client{requester: NewMockClient(&ListBansReply{Bans: expectedBans}, nil)}
+
+		bans, err := mockClient.ListBans(context.Background())
+		require.NoError(err)
+		require.Equal(expectedBans, bans)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListBansReply{}, errTest)}
+		_, err := mockClient.ListBans(context.Background())
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestStacktrace(t *testing.T) {
 	for _, test := range SuccessResponseTests {
 		t.Run(test.name, func(t *testing.T) {
@@ -5,9 +5,13 @@ package admin
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"path"
+	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -16,11 +20,14 @@ import (
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/database/rpcdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
@@ -39,8 +46,10 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong      = errors.New("alias length is too long")
+	errNoLogLevel        = errors.New("need to specify either displayLevel or logLevel")
+	errInvalidBackupName = errors.New("backup name must be a non-empty file name")
+	errInvalidIPRange    = errors.New("invalid IP range")
 )
 
 type Config struct {
@@ -53,6 +62,13 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	NetworkID    uint32
+	// DBName is the type of [DB]. e.g. leveldb or pebble.
+	DBName string
+	// DBBackupDir is the directory that database checkpoints are written to.
+	DBBackupDir string
+	// Network is used to manage the connections to individual peers.
+	Network network.Network
 }
 
 // Admin is the API service for node admin management
@@ -60,6 +76,11 @@ type Admin struct {
 	Config
 	lock     sync.RWMutex
 	profiler profiler.Profiler
+
+	// backupLock prevents concurrent database checkpoints. It is separate
+	// from [lock] so that a long running checkpoint doesn't block the other
+	// admin methods.
+	backupLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
@@ -214,6 +235,303 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// TrackSubnetArgs are the arguments for calling TrackSubnet and UntrackSubnet
+type TrackSubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// TrackSubnet starts validating the subnet by creating its chains. The subnet
+// remains tracked after the node restarts.
+func (a *Admin) TrackSubnet(_ *http.Request, args *TrackSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "trackSubnet"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.ChainManager.TrackSubnet(args.SubnetID)
+}
+
+// UntrackSubnet stops validating the subnet by shutting down its chains. The
+// subnet remains untracked after the node restarts.
+func (a *Admin) UntrackSubnet(r *http.Request, args *TrackSubnetArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "untrackSubnet"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.ChainManager.UntrackSubnet(r.Context(), args.SubnetID)
+}
+
+// ReloadChainConfigArgs are the arguments for calling ReloadChainConfig
+type ReloadChainConfigArgs struct {
+	Chain string `json:"chain"`
+}
+
+// ReloadChainConfigReply describes how the configs were applied to the chain
+type ReloadChainConfigReply struct {
+	// True if the chain's config or its subnet's config changed
+	Changed bool `json:"changed"`
+	// True if the chain was restarted because its VM can't reload its config
+	Restarted bool `json:"restarted"`
+}
+
+// ReloadChainConfig reads the chain and subnet configs again and applies them
+// to the chain. The P-chain, X-chain, and C-chain can't be restarted, so the
+// node must be restarted to apply changes to their configs.
+func (a *Admin) ReloadChainConfig(r *http.Request, args *ReloadChainConfigArgs, reply *ReloadChainConfigReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadChainConfig"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	result, err := a.ChainManager.ReloadChainConfig(r.Context(), chainID)
+	reply.Changed = result.Changed
+	reply.Restarted = result.Restarted
+	return err
+}
+
+// PeerArgs are the arguments for calling the peer management methods that
+// only require a NodeID
+type PeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// DisconnectPeer closes the connection to a peer. The peer may reconnect unless
+// it is banned.
+func (a *Admin) DisconnectPeer(_ *http.Request, args *PeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "disconnectPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.Disconnect(args.NodeID)
+}
+
+// BanNodeArgs are the arguments for calling BanNode
+type BanNodeArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration of the ban, such as "24h"
+	Duration string `json:"duration"`
+}
+
+// BanNode disconnects from a peer and refuses all connections with it for the
+// provided duration. Bans are persisted across restarts.
+func (a *Admin) BanNode(_ *http.Request, args *BanNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banNode"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("duration", args.Duration),
+	)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.BanNode(args.NodeID, duration)
+}
+
+// UnbanNode removes the ban of a peer
+func (a *Admin) UnbanNode(_ *http.Request, args *PeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbanNode"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.UnbanNode(args.NodeID)
+}
+
+// IPRangeArgs are the arguments for calling UnbanIPRange
+type IPRangeArgs struct {
+	// IPRange is either a CIDR, such as "10.0.0.0/8", or a single IP
+	IPRange string `json:"ipRange"`
+}
+
+// BanIPRangeArgs are the arguments for calling BanIPRange
+type BanIPRangeArgs struct {
+	// IPRange is either a CIDR, such as "10.0.0.0/8", or a single IP
+	IPRange string `json:"ipRange"`
+	// Duration of the ban, such as "24h"
+	Duration string `json:"duration"`
+}
+
+// BanIPRange disconnects from the peers in an IP range and refuses all
+// connections with IPs in the range for the provided duration. Bans are
+// persisted across restarts.
+func (a *Admin) BanIPRange(_ *http.Request, args *BanIPRangeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banIPRange"),
+		logging.UserString("ipRange", args.IPRange),
+		logging.UserString("duration", args.Duration),
+	)
+
+	ipRange, err := parseIPRange(args.IPRange)
+	if err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.BanIPRange(ipRange, duration)
+}
+
+// UnbanIPRange removes the ban of an IP range
+func (a *Admin) UnbanIPRange(_ *http.Request, args *IPRangeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbanIPRange"),
+		logging.UserString("ipRange", args.IPRange),
+	)
+
+	ipRange, err := parseIPRange(args.IPRange)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.UnbanIPRange(ipRange)
+}
+
+// Ban is a NodeID, or an IP range, that is banned until Expiry
+type Ban struct {
+	NodeID  *ids.NodeID `json:"nodeID,omitempty"`
+	IPRange string      `json:"ipRange,omitempty"`
+	Expiry  time.Time   `json:"expiry"`
+}
+
+// ListBansReply are the results from calling ListBans
+type ListBansReply struct {
+	Bans []Ban `json:"bans"`
+}
+
+// ListBans returns the bans that haven't expired yet
+func (a *Admin) ListBans(_ *http.Request, _ *struct{}, reply *ListBansReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "listBans"),
+	)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	bans, err := a.Network.Bans()
+	if err != nil {
+		return err
+	}
+
+	reply.Bans = make([]Ban, len(bans))
+	for i, ban := range bans {
+		reply.Bans[i].Expiry = ban.Expiry
+		if ban.IPRange != nil {
+			reply.Bans[i].IPRange = ban.IPRange.String()
+		} else {
+			nodeID := ban.NodeID
+			reply.Bans[i].NodeID = &nodeID
+		}
+	}
+	return nil
+}
+
+// PinPeerArgs are the arguments for calling PinPeer
+type PinPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// IP of the peer, such as "1.2.3.4:9651"
+	IP string `json:"ip"`
+}
+
+// PinPeer makes the node always attempt to be connected to a peer, as if it
+// were a validator. Pins are persisted across restarts.
+func (a *Admin) PinPeer(_ *http.Request, args *PinPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "pinPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.PinPeer(args.NodeID, ip)
+}
+
+// UnpinPeer removes the pin of a peer
+func (a *Admin) UnpinPeer(_ *http.Request, args *PeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unpinPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.Network.UnpinPeer(args.NodeID)
+}
+
+// parseIPRange parses either a CIDR or a single IP, which is treated as a range
+// that only contains that IP.
+func parseIPRange(ipRange string) (*net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(ipRange); err == nil {
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(ipRange)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %q", errInvalidIPRange, ipRange)
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		ip = ipv4
+	}
+	bits := len(ip) * 8
+	return &net.IPNet{
+		IP:   ip,
+		Mask: net.CIDRMask(bits, bits),
+	}, nil
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
@@ -413,3 +731,58 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+type BackupDBArgs struct {
+	// Name of the checkpoint directory to create in the node's database backup
+	// directory
+	Name string `json:"name"`
+}
+
+type BackupDBReply struct {
+	// Path is the directory the checkpoint was written to
+	Path            string      `json:"path"`
+	DatabaseVersion string      `json:"databaseVersion"`
+	NodeVersion     string      `json:"nodeVersion"`
+	NetworkID       json.Uint32 `json:"networkID"`
+	DatabaseName    string      `json:"databaseName"`
+	Timestamp       time.Time   `json:"timestamp"`
+	NumKeys         json.Uint64 `json:"numKeys"`
+}
+
+// BackupDB writes a consistent checkpoint of the node's database to the
+// database backup directory while the node keeps running. The checkpoint can
+// be restored by starting the node with the db-restore-dir flag.
+func (a *Admin) BackupDB(_ *http.Request, args *BackupDBArgs, reply *BackupDBReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "backupDB"),
+		logging.UserString("name", args.Name),
+	)
+
+	if args.Name == "" || args.Name == "." || args.Name == ".." || filepath.Base(args.Name) != args.Name {
+		return errInvalidBackupName
+	}
+
+	a.backupLock.Lock()
+	defer a.backupLock.Unlock()
+
+	dir := filepath.Join(a.DBBackupDir, args.Name)
+	metadata, err := backup.Checkpoint(a.Log, a.DB, a.DBName, a.NetworkID, dir)
+	if err != nil {
+		return err
+	}
+
+	a.Log.Info("created database checkpoint",
+		zap.String("path", dir),
+		zap.Uint64("numKeys", metadata.NumKeys),
+	)
+
+	reply.Path = dir
+	reply.DatabaseVersion = metadata.DatabaseVersion
+	reply.NodeVersion = metadata.NodeVersion
+	reply.NetworkID = json.Uint32(metadata.NetworkID)
+	reply.DatabaseName = metadata.DatabaseName
+	reply.Timestamp = metadata.Timestamp
+	reply.NumKeys = json.Uint64(metadata.NumKeys)
+	return nil
+}
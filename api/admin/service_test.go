@@ -4,15 +4,28 @@
 package admin
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebble"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
@@ -167,3 +180,342 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupDB(t *testing.T) {
+	a := &Admin{Config: Config{
+		Log:         logging.NoLog{},
+		DB:          memdb.New(),
+		NetworkID:   constants.UnitTestID,
+		DBName:      pebble.Name,
+		DBBackupDir: t.TempDir(),
+	}}
+	require.NoError(t, a.DB.Put([]byte("hello"), []byte("world")))
+
+	tests := []struct {
+		name        string
+		backupName  string
+		expectedErr error
+	}{
+		{
+			name:       "valid",
+			backupName: "backup",
+		},
+		{
+			name:        "already exists",
+			backupName:  "backup",
+			expectedErr: backup.ErrCheckpointExists,
+		},
+		{
+			name:        "empty name",
+			backupName:  "",
+			expectedErr: errInvalidBackupName,
+		},
+		{
+			name:        "parent directory",
+			backupName:  "..",
+			expectedErr: errInvalidBackupName,
+		},
+		{
+			name:        "nested path",
+			backupName:  filepath.Join("..", "backup"),
+			expectedErr: errInvalidBackupName,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			reply := &BackupDBReply{}
+			err := a.BackupDB(
+				nil,
+				&BackupDBArgs{
+					Name: test.backupName,
+				},
+				reply,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			require.Equal(filepath.Join(a.DBBackupDir, test.backupName), reply.Path)
+			require.Equal(json.Uint64(1), reply.NumKeys)
+			require.Equal(json.Uint32(constants.UnitTestID), reply.NetworkID)
+
+			metadata, err := backup.ReadMetadata(reply.Path)
+			require.NoError(err)
+			require.Equal(pebble.Name, metadata.DatabaseName)
+		})
+	}
+}
+
+type trackingChainManager struct {
+	chains.Manager
+
+	trackedSubnetID   ids.ID
+	untrackedSubnetID ids.ID
+	reloadedChainID   ids.ID
+	reloadResult      chains.ReloadResult
+	err               error
+}
+
+func (m *trackingChainManager) TrackSubnet(subnetID ids.ID) error {
+	m.trackedSubnetID = subnetID
+	return m.err
+}
+
+func (m *trackingChainManager) UntrackSubnet(_ context.Context, subnetID ids.ID) error {
+	m.untrackedSubnetID = subnetID
+	return m.err
+}
+
+func (m *trackingChainManager) ReloadChainConfig(_ context.Context, chainID ids.ID) (chains.ReloadResult, error) {
+	m.reloadedChainID = chainID
+	return m.reloadResult, m.err
+}
+
+func TestServiceTrackSubnet(t *testing.T) {
+	require := require.New(t)
+
+	chainManager := &trackingChainManager{
+		Manager: chains.TestManager,
+	}
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	subnetID := ids.GenerateTestID()
+	require.NoError(a.TrackSubnet(nil, &TrackSubnetArgs{SubnetID: subnetID}, &api.EmptyReply{}))
+	require.Equal(subnetID, chainManager.trackedSubnetID)
+
+	chainManager.err = errTest
+	err := a.TrackSubnet(nil, &TrackSubnetArgs{SubnetID: subnetID}, &api.EmptyReply{})
+	require.ErrorIs(err, errTest)
+}
+
+func TestServiceUntrackSubnet(t *testing.T) {
+	require := require.New(t)
+
+	chainManager := &trackingChainManager{
+		Manager: chains.TestManager,
+	}
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	subnetID := ids.GenerateTestID()
+	req := httptest.NewRequest(http.MethodPost, "/ext/admin", nil)
+	require.NoError(a.UntrackSubnet(req, &TrackSubnetArgs{SubnetID: subnetID}, &api.EmptyReply{}))
+	require.Equal(subnetID, chainManager.untrackedSubnetID)
+
+	chainManager.err = errTest
+	err := a.UntrackSubnet(req, &TrackSubnetArgs{SubnetID: subnetID}, &api.EmptyReply{})
+	require.ErrorIs(err, errTest)
+}
+
+func TestServiceReloadChainConfig(t *testing.T) {
+	require := require.New(t)
+
+	chainManager := &trackingChainManager{
+		Manager: chains.TestManager,
+		reloadResult: chains.ReloadResult{
+			Changed:   true,
+			Restarted: true,
+		},
+	}
+	a := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	chainID := ids.GenerateTestID()
+	req := httptest.NewRequest(http.MethodPost, "/ext/admin", nil)
+	reply := &ReloadChainConfigReply{}
+	require.NoError(a.ReloadChainConfig(req, &ReloadChainConfigArgs{Chain: chainID.String()}, reply))
+	require.Equal(chainID, chainManager.reloadedChainID)
+	require.Equal(&ReloadChainConfigReply{
+		Changed:   true,
+		Restarted: true,
+	}, reply)
+
+	chainManager.err = errTest
+	err := a.ReloadChainConfig(req, &ReloadChainConfigArgs{Chain: chainID.String()}, &ReloadChainConfigReply{})
+	require.ErrorIs(err, errTest)
+}
+
+type managingNetwork struct {
+	network.Network
+
+	bans           []network.Ban
+	bannedNodeID   ids.NodeID
+	bannedIPRange  *net.IPNet
+	banDuration    time.Duration
+	pinnedNodeID   ids.NodeID
+	pinnedIP       ips.IPPort
+	unpinnedNodeID ids.NodeID
+	err            error
+}
+
+func (n *managingNetwork) BanNode(nodeID ids.NodeID, duration time.Duration) error {
+	n.bannedNodeID = nodeID
+	n.banDuration = duration
+	return n.err
+}
+
+func (n *managingNetwork) BanIPRange(ipRange *net.IPNet, duration time.Duration) error {
+	n.bannedIPRange = ipRange
+	n.banDuration = duration
+	return n.err
+}
+
+func (n *managingNetwork) Bans() ([]network.Ban, error) {
+	return n.bans, n.err
+}
+
+func (n *managingNetwork) PinPeer(nodeID ids.NodeID, ip ips.IPPort) error {
+	n.pinnedNodeID = nodeID
+	n.pinnedIP = ip
+	return n.err
+}
+
+func (n *managingNetwork) UnpinPeer(nodeID ids.NodeID) error {
+	n.unpinnedNodeID = nodeID
+	return n.err
+}
+
+func TestServiceBanNode(t *testing.T) {
+	require := require.New(t)
+
+	n := &managingNetwork{}
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: n,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(a.BanNode(nil, &BanNodeArgs{NodeID: nodeID, Duration: "1h30m"}, &api.EmptyReply{}))
+	require.Equal(nodeID, n.bannedNodeID)
+	require.Equal(90*time.Minute, n.banDuration)
+
+	n.err = errTest
+	err := a.BanNode(nil, &BanNodeArgs{NodeID: nodeID, Duration: "1h"}, &api.EmptyReply{})
+	require.ErrorIs(err, errTest)
+}
+
+func TestServiceBanIPRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		ipRange         string
+		expectedIPRange string
+		expectedErr     error
+	}{
+		{
+			name:            "ipv4 cidr",
+			ipRange:         "10.1.0.0/16",
+			expectedIPRange: "10.1.0.0/16",
+		},
+		{
+			name:            "ipv4 cidr with host bits",
+			ipRange:         "10.1.2.3/16",
+			expectedIPRange: "10.1.0.0/16",
+		},
+		{
+			name:            "single ipv4",
+			ipRange:         "10.1.2.3",
+			expectedIPRange: "10.1.2.3/32",
+		},
+		{
+			name:            "single ipv6",
+			ipRange:         "2001:db8::1",
+			expectedIPRange: "2001:db8::1/128",
+		},
+		{
+			name:        "invalid",
+			ipRange:     "not an ip",
+			expectedErr: errInvalidIPRange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			n := &managingNetwork{}
+			a := &Admin{Config: Config{
+				Log:     logging.NoLog{},
+				Network: n,
+			}}
+
+			err := a.BanIPRange(nil, &BanIPRangeArgs{IPRange: test.ipRange, Duration: "1h"}, &api.EmptyReply{})
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedIPRange, n.bannedIPRange.String())
+			require.Equal(time.Hour, n.banDuration)
+		})
+	}
+}
+
+func TestServiceListBans(t *testing.T) {
+	require := require.New(t)
+
+	_, ipRange, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(err)
+
+	var (
+		nodeID = ids.GenerateTestNodeID()
+		expiry = time.Unix(1_000, 0)
+	)
+	n := &managingNetwork{
+		bans: []network.Ban{
+			{
+				NodeID: nodeID,
+				Expiry: expiry,
+			},
+			{
+				IPRange: ipRange,
+				Expiry:  expiry,
+			},
+		},
+	}
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: n,
+	}}
+
+	reply := &ListBansReply{}
+	require.NoError(a.ListBans(nil, nil, reply))
+	require.Equal(
+		[]Ban{
+			{
+				NodeID: &nodeID,
+				Expiry: expiry,
+			},
+			{
+				IPRange: "10.0.0.0/8",
+				Expiry:  expiry,
+			},
+		},
+		reply.Bans,
+	)
+}
+
+func TestServicePinPeer(t *testing.T) {
+	require := require.New(t)
+
+	n := &managingNetwork{}
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: n,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(a.PinPeer(nil, &PinPeerArgs{NodeID: nodeID, IP: "1.2.3.4:9651"}, &api.EmptyReply{}))
+	require.Equal(nodeID, n.pinnedNodeID)
+	require.Equal("1.2.3.4:9651", n.pinnedIP.String())
+
+	require.NoError(a.UnpinPeer(nil, &PeerArgs{NodeID: nodeID}, &api.EmptyReply{}))
+	require.Equal(nodeID, n.unpinnedNodeID)
+}
@@ -14,14 +14,19 @@ import (
 )
 
 type mockClient struct {
-	reply  APIReply
-	err    error
-	onCall func()
+	reply        APIReply
+	historyReply APIHistoryReply
+	err          error
+	onCall       func()
 }
 
 func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, replyIntf interface{}, _ ...rpc.Option) error {
-	reply := replyIntf.(*APIReply)
-	*reply = mc.reply
+	switch reply := replyIntf.(type) {
+	case *APIReply:
+		*reply = mc.reply
+	case *APIHistoryReply:
+		*reply = mc.historyReply
+	}
 	mc.onCall()
 	return mc.err
 }
@@ -40,6 +45,13 @@ func TestClient(t *testing.T) {
 		reply: APIReply{
 			Healthy: true,
 		},
+		historyReply: APIHistoryReply{
+			Checks: map[string]map[string]History{
+				healthNamespace: {
+					"check": {Flapping: true},
+				},
+			},
+		},
 		err:    nil,
 		onCall: func() {},
 	}
@@ -65,6 +77,12 @@ func TestClient(t *testing.T) {
 		require.True(liveness.Healthy)
 	}
 
+	{
+		history, err := c.History(context.Background(), nil)
+		require.NoError(err)
+		require.True(history.Checks[healthNamespace]["check"].Flapping)
+	}
+
 	{
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		healthy, err := AwaitHealthy(ctx, c, time.Second, nil)
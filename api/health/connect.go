@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/proto/pb/health/healthconnect"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+var _ healthconnect.HealthHandler = (*connectService)(nil)
+
+// NewConnectHandler returns the path and the handler that serve the health
+// checks of [reporter] over gRPC, gRPC-Web and Connect.
+func NewConnectHandler(log logging.Logger, reporter Reporter) (string, http.Handler) {
+	return healthconnect.NewHealthHandler(&connectService{
+		log:    log,
+		health: reporter,
+	})
+}
+
+type connectService struct {
+	log    logging.Logger
+	health Reporter
+}
+
+func (s *connectService) Readiness(
+	_ context.Context,
+	req *connect.Request[healthpb.ReadinessRequest],
+) (*connect.Response[healthpb.ReadinessResponse], error) {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "readiness"),
+		zap.Strings("tags", req.Msg.Tags),
+	)
+
+	report, err := newReport(s.health.Readiness(req.Msg.Tags...))
+	return connect.NewResponse(&healthpb.ReadinessResponse{
+		Report: report,
+	}), err
+}
+
+func (s *connectService) Health(
+	_ context.Context,
+	req *connect.Request[healthpb.HealthRequest],
+) (*connect.Response[healthpb.HealthResponse], error) {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "health"),
+		zap.Strings("tags", req.Msg.Tags),
+	)
+
+	report, err := newReport(s.health.Health(req.Msg.Tags...))
+	return connect.NewResponse(&healthpb.HealthResponse{
+		Report: report,
+	}), err
+}
+
+func (s *connectService) Liveness(
+	_ context.Context,
+	req *connect.Request[healthpb.LivenessRequest],
+) (*connect.Response[healthpb.LivenessResponse], error) {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "liveness"),
+		zap.Strings("tags", req.Msg.Tags),
+	)
+
+	report, err := newReport(s.health.Liveness(req.Msg.Tags...))
+	return connect.NewResponse(&healthpb.LivenessResponse{
+		Report: report,
+	}), err
+}
+
+func (s *connectService) StreamHealth(
+	ctx context.Context,
+	req *connect.Request[healthpb.StreamHealthRequest],
+	stream *connect.ServerStream[healthpb.StreamHealthResponse],
+) error {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "streamHealth"),
+		zap.Strings("tags", req.Msg.Tags),
+	)
+
+	var lastResults map[string]Result
+	for {
+		// The channel must be fetched before the results so that no change is
+		// missed.
+		changed := s.health.HealthChanged()
+		results, healthy := s.health.Health(req.Msg.Tags...)
+		if lastResults == nil || !sameStates(lastResults, results) {
+			lastResults = results
+			report, err := newReport(results, healthy)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(&healthpb.StreamHealthResponse{
+				Report: report,
+			}); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// sameStates returns true if [a] and [b] contain the same checks and each check
+// either passes in both or fails with the same error in both.
+func sameStates(a, b map[string]Result) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, resultA := range a {
+		resultB, ok := b[name]
+		if !ok {
+			return false
+		}
+		switch {
+		case resultA.Error == nil && resultB.Error == nil:
+		case resultA.Error == nil || resultB.Error == nil:
+			return false
+		case *resultA.Error != *resultB.Error:
+			return false
+		}
+	}
+	return true
+}
+
+func newReport(results map[string]Result, healthy bool) (*healthpb.Report, error) {
+	report := &healthpb.Report{
+		Checks:  make(map[string]*healthpb.Result, len(results)),
+		Healthy: healthy,
+	}
+	for name, result := range results {
+		details, err := json.Marshal(result.Details)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		pbResult := &healthpb.Result{
+			Details:            details,
+			Duration:           durationpb.New(result.Duration),
+			ContiguousFailures: result.ContiguousFailures,
+		}
+		if result.Error != nil {
+			pbResult.Error = *result.Error
+		}
+		if !result.Timestamp.IsZero() {
+			pbResult.Timestamp = timestamppb.New(result.Timestamp)
+		}
+		if result.TimeOfFirstFailure != nil {
+			pbResult.TimeOfFirstFailure = timestamppb.New(*result.TimeOfFirstFailure)
+		}
+		report.Checks[name] = pbResult
+	}
+	return report, nil
+}
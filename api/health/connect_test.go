@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/proto/pb/health/healthconnect"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	healthpb "github.com/ava-labs/avalanchego/proto/pb/health"
+)
+
+func newConnectClient(t *testing.T, reporter Reporter) healthconnect.HealthClient {
+	path, handler := NewConnectHandler(logging.NoLog{}, reporter)
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return healthconnect.NewHealthClient(server.Client(), server.URL)
+}
+
+func TestConnectHealth(t *testing.T) {
+	require := require.New(t)
+
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		return map[string]int{"value": 1}, errUnhealthy
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
+	require.NoError(err)
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	require.Eventually(func() bool {
+		results, _ := h.Health()
+		return results["check"].ContiguousFailures > 1
+	}, awaitTimeout, awaitFreq)
+
+	client := newConnectClient(t, h)
+	response, err := client.Health(context.Background(), connect.NewRequest(&healthpb.HealthRequest{}))
+	require.NoError(err)
+
+	report := response.Msg.Report
+	require.False(report.Healthy)
+	require.Contains(report.Checks, "check")
+
+	result := report.Checks["check"]
+	require.JSONEq(`{"value":1}`, string(result.Details))
+	require.Equal(errUnhealthy.Error(), result.Error)
+	require.NotNil(result.TimeOfFirstFailure)
+	require.Positive(result.ContiguousFailures)
+}
+
+func TestConnectStreamHealth(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if shouldCheckErr.Get() {
+			return nil, errUnhealthy
+		}
+		return nil, nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
+	require.NoError(err)
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	awaitHealthy(t, h, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client := newConnectClient(t, h)
+	stream, err := client.StreamHealth(ctx, connect.NewRequest(&healthpb.StreamHealthRequest{}))
+	require.NoError(err)
+	defer stream.Close()
+	// The stream must be canceled before it is closed, as closing it waits
+	// for the server to end the stream.
+	defer cancel()
+
+	// The current state is sent as soon as the stream is opened.
+	require.True(stream.Receive())
+	require.True(stream.Msg().Report.Healthy)
+
+	// Checks that keep passing aren't reported again, so the next report is
+	// sent once the check fails.
+	shouldCheckErr.Set(true)
+	require.True(stream.Receive())
+	report := stream.Msg().Report
+	require.False(report.Healthy)
+	require.Equal(errUnhealthy.Error(), report.Checks["check"].Error)
+
+	shouldCheckErr.Set(false)
+	require.True(stream.Receive())
+	require.True(stream.Msg().Report.Healthy)
+}
@@ -21,6 +21,10 @@ const (
 	// Registering a health check with this tag will ensure that it is always
 	// included in all health query results.
 	ApplicationTag = "application"
+
+	readinessNamespace = "readiness"
+	healthNamespace    = "health"
+	livenessNamespace  = "liveness"
 )
 
 var _ Health = (*health)(nil)
@@ -52,6 +56,14 @@ type Reporter interface {
 	Readiness(tags ...string) (map[string]Result, bool)
 	Health(tags ...string) (map[string]Result, bool)
 	Liveness(tags ...string) (map[string]Result, bool)
+
+	// History returns the recent state transitions of the checks, keyed by
+	// the namespace of the checks and then by the name of the checks.
+	History(tags ...string) map[string]map[string]History
+
+	// HealthChanged returns a channel that is closed the next time a health
+	// check is registered or starts or stops passing.
+	HealthChanged() <-chan struct{}
 }
 
 type health struct {
@@ -61,18 +73,22 @@ type health struct {
 	liveness  *worker
 }
 
-func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
-	readinessWorker, err := newWorker(log, "readiness", registerer)
+func New(log logging.Logger, registerer prometheus.Registerer, config Config) (Health, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	readinessWorker, err := newWorker(log, readinessNamespace, registerer, config)
 	if err != nil {
 		return nil, err
 	}
 
-	healthWorker, err := newWorker(log, "health", registerer)
+	healthWorker, err := newWorker(log, healthNamespace, registerer, config)
 	if err != nil {
 		return nil, err
 	}
 
-	livenessWorker, err := newWorker(log, "liveness", registerer)
+	livenessWorker, err := newWorker(log, livenessNamespace, registerer, config)
 	return &health{
 		log:       log,
 		readiness: readinessWorker,
@@ -97,7 +113,7 @@ func (h *health) Readiness(tags ...string) (map[string]Result, bool) {
 	results, healthy := h.readiness.Results(tags...)
 	if !healthy {
 		h.log.Warn("failing check",
-			zap.String("namespace", "readiness"),
+			zap.String("namespace", readinessNamespace),
 			zap.Reflect("reason", results),
 		)
 	}
@@ -108,7 +124,7 @@ func (h *health) Health(tags ...string) (map[string]Result, bool) {
 	results, healthy := h.health.Results(tags...)
 	if !healthy {
 		h.log.Warn("failing check",
-			zap.String("namespace", "health"),
+			zap.String("namespace", healthNamespace),
 			zap.Reflect("reason", results),
 		)
 	}
@@ -119,13 +135,25 @@ func (h *health) Liveness(tags ...string) (map[string]Result, bool) {
 	results, healthy := h.liveness.Results(tags...)
 	if !healthy {
 		h.log.Warn("failing check",
-			zap.String("namespace", "liveness"),
+			zap.String("namespace", livenessNamespace),
 			zap.Reflect("reason", results),
 		)
 	}
 	return results, healthy
 }
 
+func (h *health) History(tags ...string) map[string]map[string]History {
+	return map[string]map[string]History{
+		readinessNamespace: h.readiness.History(tags...),
+		healthNamespace:    h.health.History(tags...),
+		livenessNamespace:  h.liveness.History(tags...),
+	}
+}
+
+func (h *health) HealthChanged() <-chan struct{} {
+	return h.health.Changed()
+}
+
 func (h *health) Start(ctx context.Context, freq time.Duration) {
 	h.readiness.Start(ctx, freq)
 	h.health.Start(ctx, freq)
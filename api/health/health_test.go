@@ -54,7 +54,7 @@ func TestDuplicatedRegistations(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -77,7 +77,7 @@ func TestDefaultFailing(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	{
@@ -118,7 +118,7 @@ func TestPassingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -182,7 +182,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -229,7 +229,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 func TestDeadlockRegression(t *testing.T) {
 	require := require.New(t)
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	var lock sync.Mutex
@@ -259,7 +259,7 @@ func TestTags(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 	require.NoError(h.RegisterHealthCheck("check1", check))
 	require.NoError(h.RegisterHealthCheck("check2", check, "tag1"))
@@ -390,3 +390,162 @@ func TestTags(t *testing.T) {
 		require.False(health)
 	}
 }
+
+func TestHistory(t *testing.T) {
+	require := require.New(t)
+
+	var healthy utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		if healthy.Get() {
+			return "", nil
+		}
+		return "", errUnhealthy
+	})
+
+	config := Config{
+		HistorySize:       3,
+		FlappingThreshold: 2,
+		FlappingWindow:    time.Hour,
+	}
+	hIntf, err := New(logging.NoLog{}, prometheus.NewRegistry(), config)
+	require.NoError(err)
+	h := hIntf.(*health)
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	history := h.History()
+	require.Len(history, 3)
+	require.Empty(history[readinessNamespace])
+	require.Empty(history[livenessNamespace])
+	require.Equal(History{}, history[healthNamespace]["check"])
+
+	// A check that keeps failing doesn't transition.
+	h.health.runChecks(context.Background())
+	require.Equal(History{}, h.History()[healthNamespace]["check"])
+
+	healthy.Set(true)
+	h.health.runChecks(context.Background())
+	checkHistory := h.History()[healthNamespace]["check"]
+	require.Len(checkHistory.Transitions, 1)
+	require.True(checkHistory.Transitions[0].Healthy)
+	require.Nil(checkHistory.Transitions[0].Error)
+	require.False(checkHistory.Flapping)
+
+	healthy.Set(false)
+	h.health.runChecks(context.Background())
+	checkHistory = h.History()[healthNamespace]["check"]
+	require.Len(checkHistory.Transitions, 2)
+	require.False(checkHistory.Transitions[1].Healthy)
+	require.Equal(errUnhealthy.Error(), *checkHistory.Transitions[1].Error)
+	require.False(checkHistory.Flapping)
+
+	// Exceeding the threshold marks the check as flapping.
+	healthy.Set(true)
+	h.health.runChecks(context.Background())
+	checkHistory = h.History()[healthNamespace]["check"]
+	require.Len(checkHistory.Transitions, 3)
+	require.True(checkHistory.Flapping)
+
+	// Only the most recent transitions are kept.
+	healthy.Set(false)
+	h.health.runChecks(context.Background())
+	checkHistory = h.History()[healthNamespace]["check"]
+	require.Len(checkHistory.Transitions, 3)
+	require.False(checkHistory.Transitions[0].Healthy)
+	require.True(checkHistory.Transitions[1].Healthy)
+	require.False(checkHistory.Transitions[2].Healthy)
+	require.True(checkHistory.Flapping)
+}
+
+func TestIsFlapping(t *testing.T) {
+	now := time.Now()
+	transitions := []Transition{
+		{Timestamp: now.Add(-3 * time.Minute)},
+		{Timestamp: now.Add(-2 * time.Minute)},
+		{Timestamp: now.Add(-time.Minute)},
+	}
+	tests := []struct {
+		name      string
+		threshold int
+		since     time.Time
+		expected  bool
+	}{
+		{
+			name:      "disabled",
+			threshold: 0,
+			since:     now.Add(-time.Hour),
+			expected:  false,
+		},
+		{
+			name:      "below threshold",
+			threshold: 3,
+			since:     now.Add(-time.Hour),
+			expected:  false,
+		},
+		{
+			name:      "above threshold",
+			threshold: 2,
+			since:     now.Add(-time.Hour),
+			expected:  true,
+		},
+		{
+			name:      "above threshold outside of window",
+			threshold: 2,
+			since:     now.Add(-2 * time.Minute),
+			expected:  false,
+		},
+		{
+			name:      "above threshold inside of window",
+			threshold: 1,
+			since:     now.Add(-2 * time.Minute),
+			expected:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, isFlapping(transitions, test.threshold, test.since))
+		})
+	}
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:        "default",
+			config:      DefaultConfig,
+			expectedErr: nil,
+		},
+		{
+			name: "invalid history size",
+			config: Config{
+				FlappingWindow: time.Minute,
+			},
+			expectedErr: errInvalidHistorySize,
+		},
+		{
+			name: "invalid flapping window",
+			config: Config{
+				HistorySize: 1,
+			},
+			expectedErr: errInvalidFlappingWindow,
+		},
+		{
+			name: "flapping threshold too big",
+			config: Config{
+				HistorySize:       2,
+				FlappingThreshold: 2,
+				FlappingWindow:    time.Minute,
+			},
+			expectedErr: errFlappingThresholdTooBig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	DefaultConfig = Config{
+		HistorySize:       32,
+		FlappingThreshold: 4,
+		FlappingWindow:    10 * time.Minute,
+	}
+
+	errInvalidHistorySize      = errors.New("history size must be positive")
+	errInvalidFlappingWindow   = errors.New("flapping window must be positive")
+	errFlappingThresholdTooBig = errors.New("flapping threshold must be less than the history size")
+)
+
+type Config struct {
+	// HistorySize is the maximum number of state transitions that are kept
+	// for each check.
+	HistorySize int `json:"historySize"`
+
+	// FlappingThreshold is the number of state transitions within
+	// [FlappingWindow] that a check can make before it is reported as
+	// flapping. If 0, checks are never reported as flapping.
+	FlappingThreshold int `json:"flappingThreshold"`
+
+	// FlappingWindow is the period of time over which the state transitions of
+	// a check are counted.
+	FlappingWindow time.Duration `json:"flappingWindow"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.HistorySize <= 0:
+		return errInvalidHistorySize
+	case c.FlappingWindow <= 0:
+		return errInvalidFlappingWindow
+	case c.FlappingThreshold >= c.HistorySize:
+		return fmt.Errorf("%w: %d >= %d", errFlappingThresholdTooBig, c.FlappingThreshold, c.HistorySize)
+	default:
+		return nil
+	}
+}
+
+// Transition is a change of a check from passing to failing or from failing to
+// passing.
+type Transition struct {
+	// Healthy is true if the check started passing.
+	Healthy bool `json:"healthy"`
+
+	// Error is the string representation of the error returned by the check
+	// when it started failing. The value is nil if the check started passing.
+	Error *string `json:"error,omitempty"`
+
+	// Timestamp of the HealthCheck that caused the transition.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type History struct {
+	// Transitions of the check, from oldest to newest. Only the most recent
+	// transitions are kept.
+	Transitions []Transition `json:"transitions"`
+
+	// Flapping is true if the check transitioned more than the flapping
+	// threshold within the flapping window.
+	Flapping bool `json:"flapping"`
+}
+
+// isFlapping returns true if more than [threshold] of [transitions] happened
+// after [since].
+func isFlapping(transitions []Transition, threshold int, since time.Time) bool {
+	if threshold <= 0 || len(transitions) <= threshold {
+		return false
+	}
+	// Transitions are sorted by their timestamps, so only the oldest
+	// transition that would exceed the threshold needs to be checked.
+	return !transitions[len(transitions)-threshold-1].Timestamp.Before(since)
+}
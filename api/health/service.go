@@ -60,3 +60,21 @@ func (s *Service) Liveness(_ *http.Request, args *APIArgs, reply *APIReply) erro
 	reply.Checks, reply.Healthy = s.health.Liveness(args.Tags...)
 	return nil
 }
+
+// APIHistoryReply is the response for History.
+type APIHistoryReply struct {
+	// Checks maps the namespace of the checks, such as "health", to the
+	// history of each check in the namespace.
+	Checks map[string]map[string]History `json:"checks"`
+}
+
+// History returns the recent state transitions of the checks of the node
+func (s *Service) History(_ *http.Request, args *APIArgs, reply *APIHistoryReply) error {
+	s.log.Debug("API called",
+		zap.String("service", "health"),
+		zap.String("method", "history"),
+		zap.Strings("tags", args.Tags),
+	)
+	reply.Checks = s.health.History(args.Tags...)
+	return nil
+}
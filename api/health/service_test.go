@@ -22,7 +22,7 @@ func TestServiceResponses(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	s := &Service{
@@ -103,6 +103,21 @@ func TestServiceResponses(t *testing.T) {
 		require.Zero(result.ContiguousFailures)
 		require.True(reply.Healthy)
 	}
+
+	{
+		reply := APIHistoryReply{}
+		require.NoError(s.History(nil, &APIArgs{}, &reply))
+
+		require.Len(reply.Checks, 3)
+		for _, namespace := range []string{readinessNamespace, healthNamespace, livenessNamespace} {
+			checks := reply.Checks[namespace]
+			require.Len(checks, 1, namespace)
+			history := checks["check"]
+			require.Len(history.Transitions, 1, namespace)
+			require.True(history.Transitions[0].Healthy, namespace)
+			require.False(history.Flapping, namespace)
+		}
+	}
 }
 
 func TestServiceTagResponse(t *testing.T) {
@@ -158,7 +173,7 @@ func TestServiceTagResponse(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			require := require.New(t)
 
-			h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+			h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 			require.NoError(err)
 			require.NoError(test.register(h, "check1", check))
 			require.NoError(test.register(h, "check2", check, subnetID1.String()))
@@ -30,14 +30,19 @@ var (
 type worker struct {
 	log        logging.Logger
 	namespace  string
+	config     Config
 	metrics    *metrics
 	checksLock sync.RWMutex
 	checks     map[string]*taggedChecker
 
 	resultsLock                 sync.RWMutex
 	results                     map[string]Result
+	transitions                 map[string][]Transition
 	numFailingApplicationChecks int
 	tags                        map[string]set.Set[string] // tag -> set of check names
+	// changed is closed, and replaced, whenever a check is registered or
+	// starts or stops passing.
+	changed chan struct{}
 
 	startOnce sync.Once
 	closeOnce sync.Once
@@ -55,16 +60,20 @@ func newWorker(
 	log logging.Logger,
 	namespace string,
 	registerer prometheus.Registerer,
+	config Config,
 ) (*worker, error) {
 	metrics, err := newMetrics(namespace, registerer)
 	return &worker{
-		log:       log,
-		namespace: namespace,
-		metrics:   metrics,
-		checks:    make(map[string]*taggedChecker),
-		results:   make(map[string]Result),
-		closer:    make(chan struct{}),
-		tags:      make(map[string]set.Set[string]),
+		log:         log,
+		namespace:   namespace,
+		config:      config,
+		metrics:     metrics,
+		checks:      make(map[string]*taggedChecker),
+		results:     make(map[string]Result),
+		transitions: make(map[string][]Transition),
+		changed:     make(chan struct{}),
+		closer:      make(chan struct{}),
+		tags:        make(map[string]set.Set[string]),
 	}, err
 }
 
@@ -115,6 +124,7 @@ func (w *worker) RegisterCheck(name string, check Checker, tags ...string) error
 	// If this is a new application-wide check, then all of the registered tags
 	// now have one additional failing check.
 	w.updateMetrics(tc, false /*=healthy*/, true /*=register*/)
+	w.notifyChanged()
 	return nil
 }
 
@@ -138,6 +148,58 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 	w.resultsLock.RLock()
 	defer w.resultsLock.RUnlock()
 
+	names := w.names(tags)
+	results := make(map[string]Result, names.Len())
+	healthy := true
+	for name := range names {
+		if result, ok := w.results[name]; ok {
+			results[name] = result
+			healthy = healthy && result.Error == nil
+		}
+	}
+	return results, healthy
+}
+
+func (w *worker) History(tags ...string) map[string]History {
+	w.resultsLock.RLock()
+	defer w.resultsLock.RUnlock()
+
+	var (
+		names     = w.names(tags)
+		since     = time.Now().Add(-w.config.FlappingWindow)
+		histories = make(map[string]History, names.Len())
+	)
+	for name := range names {
+		if _, ok := w.results[name]; !ok {
+			continue
+		}
+		transitions := w.transitions[name]
+		histories[name] = History{
+			Transitions: slices.Clone(transitions),
+			Flapping:    isFlapping(transitions, w.config.FlappingThreshold, since),
+		}
+	}
+	return histories
+}
+
+// Changed returns a channel that is closed the next time a check is registered
+// or starts or stops passing.
+func (w *worker) Changed() <-chan struct{} {
+	w.resultsLock.RLock()
+	defer w.resultsLock.RUnlock()
+
+	return w.changed
+}
+
+// Assumes [w.resultsLock] is held.
+func (w *worker) notifyChanged() {
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+// names returns the names of the checks that are tagged with any of [tags].
+// Assumes [w.resultsLock] is held.
+func (w *worker) names(tags []string) set.Set[string] {
 	// if no tags are specified, return all checks
 	if len(tags) == 0 {
 		tags = allTags
@@ -151,16 +213,7 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 			names.Union(set)
 		}
 	}
-
-	results := make(map[string]Result, names.Len())
-	healthy := true
-	for name := range names {
-		if result, ok := w.results[name]; ok {
-			results[name] = result
-			healthy = healthy && result.Error == nil
-		}
-	}
-	return results, healthy
+	return names
 }
 
 func (w *worker) Start(ctx context.Context, freq time.Duration) {
@@ -250,6 +303,7 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 				zap.Error(err),
 			)
 			w.updateMetrics(check, false /*=healthy*/, false /*=register*/)
+			w.addTransition(name, check, result)
 		}
 	} else if prevResult.Error != nil {
 		w.log.Info("check started passing",
@@ -258,10 +312,42 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 			zap.Strings("tags", check.tags),
 		)
 		w.updateMetrics(check, true /*=healthy*/, false /*=register*/)
+		w.addTransition(name, check, result)
 	}
 	w.results[name] = result
 }
 
+// addTransition records that the check changed state to [result]. Only the
+// most recent [w.config.HistorySize] transitions are kept.
+// Assumes [w.resultsLock] is held.
+func (w *worker) addTransition(name string, check *taggedChecker, result Result) {
+	var (
+		transitions = w.transitions[name]
+		since       = result.Timestamp.Add(-w.config.FlappingWindow)
+		wasFlapping = isFlapping(transitions, w.config.FlappingThreshold, since)
+	)
+	if len(transitions) >= w.config.HistorySize {
+		transitions = slices.Delete(transitions, 0, len(transitions)-w.config.HistorySize+1)
+	}
+	transitions = append(transitions, Transition{
+		Healthy:   result.Error == nil,
+		Error:     result.Error,
+		Timestamp: result.Timestamp,
+	})
+	w.transitions[name] = transitions
+	w.notifyChanged()
+
+	if !wasFlapping && isFlapping(transitions, w.config.FlappingThreshold, since) {
+		w.log.Warn("check started flapping",
+			zap.String("namespace", w.namespace),
+			zap.String("name", name),
+			zap.Strings("tags", check.tags),
+			zap.Int("threshold", w.config.FlappingThreshold),
+			zap.Duration("window", w.config.FlappingWindow),
+		)
+	}
+}
+
 // updateMetrics updates the metrics for the given check. If [healthy] is true,
 // then the check is considered healthy and the metrics are decremented.
 // Otherwise, the check is considered unhealthy and the metrics are incremented.
@@ -28,6 +28,7 @@ type Client interface {
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ids.ID, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	GetMetrics(context.Context, ...rpc.Option) (*GetMetricsReply, error)
 }
 
 // Client implementation for an Info API Client
@@ -114,6 +115,12 @@ func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID]
 	return res.VMs, err
 }
 
+func (c *client) GetMetrics(ctx context.Context, options ...rpc.Option) (*GetMetricsReply, error) {
+	res := &GetMetricsReply{}
+	err := c.requester.SendRequest(ctx, "info.getMetrics", struct{}{}, res, options...)
+	return res, err
+}
+
 // AwaitBootstrapped polls the node every [freq] to check if [chainID] has
 // finished bootstrapping. Returns true once [chainID] reports that it has
 // finished bootstrapping.
@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/info/infoconnect"
+
+	infopb "github.com/ava-labs/avalanchego/proto/pb/info"
+)
+
+var _ infoconnect.InfoHandler = (*connectService)(nil)
+
+// connectService serves the info API over gRPC, gRPC-Web and Connect by
+// delegating to the JSON-RPC service.
+type connectService struct {
+	info *Info
+}
+
+func (s *connectService) GetNodeVersion(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[infopb.GetNodeVersionResponse], error) {
+	var reply GetNodeVersionReply
+	if err := s.info.GetNodeVersion(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&infopb.GetNodeVersionResponse{
+		Version:            reply.Version,
+		DatabaseVersion:    reply.DatabaseVersion,
+		RpcProtocolVersion: uint32(reply.RPCProtocolVersion),
+		GitCommit:          reply.GitCommit,
+		VmVersions:         reply.VMVersions,
+	}), nil
+}
+
+func (s *connectService) GetNodeID(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[infopb.GetNodeIDResponse], error) {
+	var reply GetNodeIDReply
+	if err := s.info.GetNodeID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	response := &infopb.GetNodeIDResponse{
+		NodeId: reply.NodeID.Bytes(),
+	}
+	if reply.NodePOP != nil {
+		response.PublicKey = reply.NodePOP.PublicKey[:]
+		response.ProofOfPossession = reply.NodePOP.ProofOfPossession[:]
+	}
+	return connect.NewResponse(response), nil
+}
+
+func (s *connectService) GetNodeIP(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[infopb.GetNodeIPResponse], error) {
+	var reply GetNodeIPReply
+	if err := s.info.GetNodeIP(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&infopb.GetNodeIPResponse{
+		Ip: reply.IP,
+	}), nil
+}
+
+func (s *connectService) GetNetworkID(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[infopb.GetNetworkIDResponse], error) {
+	var reply GetNetworkIDReply
+	if err := s.info.GetNetworkID(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&infopb.GetNetworkIDResponse{
+		NetworkId: uint32(reply.NetworkID),
+	}), nil
+}
+
+func (s *connectService) GetNetworkName(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[infopb.GetNetworkNameResponse], error) {
+	var reply GetNetworkNameReply
+	if err := s.info.GetNetworkName(nil, nil, &reply); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&infopb.GetNetworkNameResponse{
+		NetworkName: reply.NetworkName,
+	}), nil
+}
+
+func (s *connectService) GetBlockchainID(
+	_ context.Context,
+	req *connect.Request[infopb.GetBlockchainIDRequest],
+) (*connect.Response[infopb.GetBlockchainIDResponse], error) {
+	var reply GetBlockchainIDReply
+	err := s.info.GetBlockchainID(
+		nil,
+		&GetBlockchainIDArgs{
+			Alias: req.Msg.Alias,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&infopb.GetBlockchainIDResponse{
+		BlockchainId: reply.BlockchainID[:],
+	}), nil
+}
+
+func (s *connectService) IsBootstrapped(
+	_ context.Context,
+	req *connect.Request[infopb.IsBootstrappedRequest],
+) (*connect.Response[infopb.IsBootstrappedResponse], error) {
+	var reply IsBootstrappedResponse
+	err := s.info.IsBootstrapped(
+		nil,
+		&IsBootstrappedArgs{
+			Chain: req.Msg.Chain,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&infopb.IsBootstrappedResponse{
+		IsBootstrapped: reply.IsBootstrapped,
+	}), nil
+}
+
+func (s *connectService) Uptime(
+	_ context.Context,
+	req *connect.Request[infopb.UptimeRequest],
+) (*connect.Response[infopb.UptimeResponse], error) {
+	var subnetID ids.ID
+	if len(req.Msg.SubnetId) != 0 {
+		var err error
+		subnetID, err = ids.ToID(req.Msg.SubnetId)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	var reply UptimeResponse
+	err := s.info.Uptime(
+		nil,
+		&UptimeRequest{
+			SubnetID: subnetID,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&infopb.UptimeResponse{
+		RewardingStakePercentage:  float64(reply.RewardingStakePercentage),
+		WeightedAveragePercentage: float64(reply.WeightedAveragePercentage),
+	}), nil
+}
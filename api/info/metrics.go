@@ -0,0 +1,260 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/metric"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// The names of the metrics that the snapshot is computed from. Node level
+// metrics are registered under [constants.PlatformName] and chain level
+// metrics are registered under the chain's metrics namespace.
+const (
+	networkNamespace = "network"
+	dbNamespace      = "db"
+
+	peersMetric       = "peers"
+	subnetPeersMetric = "peers_subnet"
+	subnetIDLabel     = "subnetID"
+
+	inboundRemainingAtLargeBytesMetric    = "byte_throttler_inbound_remaining_at_large_bytes"
+	inboundRemainingValidatorBytesMetric  = "byte_throttler_inbound_remaining_validator_bytes"
+	inboundAwaitingAcquireMetric          = "byte_throttler_inbound_awaiting_acquire"
+	outboundRemainingAtLargeBytesMetric   = "throttler_outbound_remaining_at_large_bytes"
+	outboundRemainingValidatorBytesMetric = "throttler_outbound_remaining_validator_bytes"
+	outboundAcquireFailuresMetric         = "throttler_outbound_acquire_failures"
+
+	levelDBSizeMetric  = "db_internal_size"
+	dbReadBytesMetric  = "read_size_sum"
+	dbWriteBytesMetric = "write_size_sum"
+
+	bootstrapFetchedMetric  = "bs_fetched"
+	bootstrapAcceptedMetric = "bs_accepted"
+	bootstrapETAMetric      = "bs_eta_fetching_complete"
+
+	lastAcceptedHeightMetric = "last_accepted_height"
+	processingBlocksMetric   = "blks_processing"
+	acceptedLatencySumMetric = "blks_accepted_sum"
+	acceptedCountMetric      = "blks_accepted_count"
+)
+
+// GetMetricsReply is a snapshot of the node's metrics
+type GetMetricsReply struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Network   NetworkMetrics  `json:"network"`
+	Database  DatabaseMetrics `json:"database"`
+	// Subnets are keyed by subnet ID and include the primary network
+	Subnets map[ids.ID]*SubnetMetrics `json:"subnets"`
+}
+
+// NetworkMetrics describes the node's p2p network
+type NetworkMetrics struct {
+	// Number of connected peers
+	Peers     json.Uint64      `json:"peers"`
+	Throttler ThrottlerMetrics `json:"throttler"`
+}
+
+// ThrottlerMetrics describes the usage of the message throttlers
+type ThrottlerMetrics struct {
+	InboundRemainingAtLargeBytes    json.Uint64 `json:"inboundRemainingAtLargeBytes"`
+	InboundRemainingValidatorBytes  json.Uint64 `json:"inboundRemainingValidatorBytes"`
+	InboundAwaitingAcquire          json.Uint64 `json:"inboundAwaitingAcquire"`
+	OutboundRemainingAtLargeBytes   json.Uint64 `json:"outboundRemainingAtLargeBytes"`
+	OutboundRemainingValidatorBytes json.Uint64 `json:"outboundRemainingValidatorBytes"`
+	OutboundAcquireFailures         json.Uint64 `json:"outboundAcquireFailures"`
+}
+
+// DatabaseMetrics describes the usage of a database
+type DatabaseMetrics struct {
+	// Bytes allocated on disk. Only reported by the node's leveldb database.
+	Size       json.Uint64 `json:"size,omitempty"`
+	ReadBytes  json.Uint64 `json:"readBytes"`
+	WriteBytes json.Uint64 `json:"writeBytes"`
+}
+
+// SubnetMetrics describes a subnet and its chains
+type SubnetMetrics struct {
+	// Number of connected peers that are validating the subnet
+	Peers json.Uint64 `json:"peers"`
+	// Chains are keyed by chain ID
+	Chains map[ids.ID]*ChainMetrics `json:"chains"`
+}
+
+// ChainMetrics describes a chain
+type ChainMetrics struct {
+	Name         string           `json:"name"`
+	Bootstrapped bool             `json:"bootstrapped"`
+	Bootstrap    BootstrapMetrics `json:"bootstrap"`
+	// Height of the last accepted block
+	AcceptedHeight   json.Uint64 `json:"acceptedHeight"`
+	ProcessingBlocks json.Uint64 `json:"processingBlocks"`
+	// Average time from the issuance of a block to its acceptance
+	AverageAcceptLatency time.Duration   `json:"averageAcceptLatency"`
+	Database             DatabaseMetrics `json:"database"`
+}
+
+// BootstrapMetrics describes the progress of bootstrapping
+type BootstrapMetrics struct {
+	Fetched  json.Uint64 `json:"fetched"`
+	Accepted json.Uint64 `json:"accepted"`
+	// Estimated time until all the blocks are fetched
+	FetchETA time.Duration `json:"fetchETA"`
+}
+
+// GetMetrics returns a snapshot of the node's metrics, broken down by subnet
+// and chain
+func (i *Info) GetMetrics(_ *http.Request, _ *struct{}, reply *GetMetricsReply) error {
+	i.log.Debug("API called",
+		zap.String("service", "info"),
+		zap.String("method", "getMetrics"),
+	)
+
+	families, err := i.metrics.Gather()
+	if err != nil {
+		return err
+	}
+	metrics := newMetricsIndex(families)
+
+	var (
+		network = metric.AppendNamespace(constants.PlatformName, networkNamespace)
+		db      = metric.AppendNamespace(constants.PlatformName, dbNamespace)
+	)
+	reply.Timestamp = time.Now()
+	reply.Network = NetworkMetrics{
+		Peers: metrics.uint64(network, peersMetric),
+		Throttler: ThrottlerMetrics{
+			InboundRemainingAtLargeBytes:    metrics.uint64(network, inboundRemainingAtLargeBytesMetric),
+			InboundRemainingValidatorBytes:  metrics.uint64(network, inboundRemainingValidatorBytesMetric),
+			InboundAwaitingAcquire:          metrics.uint64(network, inboundAwaitingAcquireMetric),
+			OutboundRemainingAtLargeBytes:   metrics.uint64(network, outboundRemainingAtLargeBytesMetric),
+			OutboundRemainingValidatorBytes: metrics.uint64(network, outboundRemainingValidatorBytesMetric),
+			OutboundAcquireFailures:         metrics.uint64(network, outboundAcquireFailuresMetric),
+		},
+	}
+	reply.Database = DatabaseMetrics{
+		Size:       metrics.uint64(constants.PlatformName, levelDBSizeMetric),
+		ReadBytes:  metrics.uint64(db, dbReadBytesMetric),
+		WriteBytes: metrics.uint64(db, dbWriteBytesMetric),
+	}
+
+	reply.Subnets = make(map[ids.ID]*SubnetMetrics)
+	for _, chain := range i.chainManager.Chains() {
+		subnet, ok := reply.Subnets[chain.SubnetID]
+		if !ok {
+			subnet = &SubnetMetrics{
+				Peers:  metrics.subnetPeers(network, chain.SubnetID),
+				Chains: make(map[ids.ID]*ChainMetrics),
+			}
+			reply.Subnets[chain.SubnetID] = subnet
+		}
+
+		var (
+			namespace = chain.MetricsNamespace
+			chainDB   = metric.AppendNamespace(namespace, dbNamespace)
+		)
+		subnet.Chains[chain.ID] = &ChainMetrics{
+			Name:         chain.Name,
+			Bootstrapped: i.chainManager.IsBootstrapped(chain.ID),
+			Bootstrap: BootstrapMetrics{
+				Fetched:  metrics.uint64(namespace, bootstrapFetchedMetric),
+				Accepted: metrics.uint64(namespace, bootstrapAcceptedMetric),
+				FetchETA: time.Duration(metrics.value(namespace, bootstrapETAMetric)),
+			},
+			AcceptedHeight:       metrics.uint64(namespace, lastAcceptedHeightMetric),
+			ProcessingBlocks:     metrics.uint64(namespace, processingBlocksMetric),
+			AverageAcceptLatency: metrics.average(namespace, acceptedLatencySumMetric, acceptedCountMetric),
+			Database: DatabaseMetrics{
+				ReadBytes:  metrics.uint64(chainDB, dbReadBytesMetric),
+				WriteBytes: metrics.uint64(chainDB, dbWriteBytesMetric),
+			},
+		}
+	}
+	return nil
+}
+
+// metricsIndex looks up gathered metrics by their name.
+type metricsIndex map[string]*dto.MetricFamily
+
+func newMetricsIndex(families []*dto.MetricFamily) metricsIndex {
+	index := make(metricsIndex, len(families))
+	for _, family := range families {
+		index[family.GetName()] = family
+	}
+	return index
+}
+
+// value returns the sum of the values of the metric [name] in [namespace]
+// across all of its labels. Returns 0 if the metric isn't registered.
+func (m metricsIndex) value(namespace, name string) float64 {
+	family, ok := m[metric.AppendNamespace(namespace, name)]
+	if !ok {
+		return 0
+	}
+
+	var sum float64
+	for _, sample := range family.Metric {
+		sum += metricValue(sample)
+	}
+	return sum
+}
+
+func (m metricsIndex) uint64(namespace, name string) json.Uint64 {
+	return json.Uint64(max(m.value(namespace, name), 0))
+}
+
+// average returns the average of the observations of an averager whose sum
+// and count are reported by [sumName] and [countName].
+func (m metricsIndex) average(namespace, sumName, countName string) time.Duration {
+	count := m.value(namespace, countName)
+	if count == 0 {
+		return 0
+	}
+	return time.Duration(m.value(namespace, sumName) / count)
+}
+
+// subnetPeers returns the number of connected peers that are validating
+// [subnetID].
+func (m metricsIndex) subnetPeers(namespace string, subnetID ids.ID) json.Uint64 {
+	if subnetID == constants.PrimaryNetworkID {
+		return m.uint64(namespace, peersMetric)
+	}
+
+	family, ok := m[metric.AppendNamespace(namespace, subnetPeersMetric)]
+	if !ok {
+		return 0
+	}
+
+	subnetIDStr := subnetID.String()
+	for _, sample := range family.Metric {
+		for _, label := range sample.Label {
+			if label.GetName() == subnetIDLabel && label.GetValue() == subnetIDStr {
+				return json.Uint64(max(metricValue(sample), 0))
+			}
+		}
+	}
+	return 0
+}
+
+func metricValue(sample *dto.Metric) float64 {
+	switch {
+	case sample.Gauge != nil:
+		return sample.Gauge.GetValue()
+	case sample.Counter != nil:
+		return sample.Counter.GetValue()
+	case sample.Untyped != nil:
+		return sample.Untyped.GetValue()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testChainManager struct {
+	chains.Manager
+
+	chains       []chains.ChainInfo
+	bootstrapped ids.ID
+}
+
+func (m *testChainManager) Chains() []chains.ChainInfo {
+	return m.chains
+}
+
+func (m *testChainManager) IsBootstrapped(chainID ids.ID) bool {
+	return chainID == m.bootstrapped
+}
+
+func newTestGauge(t *testing.T, registerer prometheus.Registerer, name string, value float64) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: name,
+	})
+	gauge.Set(value)
+	require.NoError(t, registerer.Register(gauge))
+}
+
+func TestGetMetrics(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID      = ids.GenerateTestID()
+		subnetChainID = ids.GenerateTestID()
+		gatherer      = metrics.NewMultiGatherer()
+	)
+
+	nodeRegistry := prometheus.NewRegistry()
+	newTestGauge(t, nodeRegistry, "network_peers", 10)
+	newTestGauge(t, nodeRegistry, "network_throttler_outbound_remaining_at_large_bytes", 1024)
+	newTestGauge(t, nodeRegistry, "db_internal_size", 4096)
+	newTestGauge(t, nodeRegistry, "db_write_size_sum", 2048)
+	subnetPeers := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "network_peers_subnet",
+		},
+		[]string{"subnetID"},
+	)
+	subnetPeers.WithLabelValues(subnetID.String()).Set(3)
+	subnetPeers.WithLabelValues(ids.GenerateTestID().String()).Set(5)
+	require.NoError(nodeRegistry.Register(subnetPeers))
+	require.NoError(gatherer.Register(constants.PlatformName, nodeRegistry))
+
+	pChainRegistry := prometheus.NewRegistry()
+	newTestGauge(t, pChainRegistry, "last_accepted_height", 100)
+	newTestGauge(t, pChainRegistry, "blks_processing", 2)
+	newTestGauge(t, pChainRegistry, "blks_accepted_sum", float64(6*time.Second))
+	newTestGauge(t, pChainRegistry, "blks_accepted_count", 3)
+	newTestGauge(t, pChainRegistry, "db_read_size_sum", 512)
+	require.NoError(gatherer.Register("avalanche_P", pChainRegistry))
+
+	subnetChainRegistry := prometheus.NewRegistry()
+	newTestGauge(t, subnetChainRegistry, "bs_fetched", 50)
+	newTestGauge(t, subnetChainRegistry, "bs_accepted", 20)
+	newTestGauge(t, subnetChainRegistry, "bs_eta_fetching_complete", float64(time.Minute))
+	require.NoError(gatherer.Register("avalanche_subnet", subnetChainRegistry))
+
+	info := &Info{
+		log: logging.NoLog{},
+		chainManager: &testChainManager{
+			Manager: chains.TestManager,
+			chains: []chains.ChainInfo{
+				{
+					ID:               constants.PlatformChainID,
+					SubnetID:         constants.PrimaryNetworkID,
+					Name:             "P",
+					MetricsNamespace: "avalanche_P",
+				},
+				{
+					ID:               subnetChainID,
+					SubnetID:         subnetID,
+					Name:             "subnet",
+					MetricsNamespace: "avalanche_subnet",
+				},
+			},
+			bootstrapped: constants.PlatformChainID,
+		},
+		metrics: gatherer,
+	}
+
+	reply := &GetMetricsReply{}
+	require.NoError(info.GetMetrics(nil, nil, reply))
+
+	require.Equal(
+		NetworkMetrics{
+			Peers: 10,
+			Throttler: ThrottlerMetrics{
+				OutboundRemainingAtLargeBytes: 1024,
+			},
+		},
+		reply.Network,
+	)
+	require.Equal(
+		DatabaseMetrics{
+			Size:       4096,
+			WriteBytes: 2048,
+		},
+		reply.Database,
+	)
+	require.Equal(
+		map[ids.ID]*SubnetMetrics{
+			constants.PrimaryNetworkID: {
+				Peers: 10,
+				Chains: map[ids.ID]*ChainMetrics{
+					constants.PlatformChainID: {
+						Name:                 "P",
+						Bootstrapped:         true,
+						AcceptedHeight:       100,
+						ProcessingBlocks:     2,
+						AverageAcceptLatency: 2 * time.Second,
+						Database: DatabaseMetrics{
+							ReadBytes: 512,
+						},
+					},
+				},
+			},
+			subnetID: {
+				Peers: 3,
+				Chains: map[ids.ID]*ChainMetrics{
+					subnetChainID: {
+						Name: "subnet",
+						Bootstrap: BootstrapMetrics{
+							Fetched:  50,
+							Accepted: 20,
+							FetchETA: time.Minute,
+						},
+					},
+				},
+			},
+		},
+		reply.Subnets,
+	)
+}
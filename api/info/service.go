@@ -9,12 +9,14 @@ import (
 	"net/http"
 
 	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/proto/pb/info/infoconnect"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -42,6 +44,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	metrics      prometheus.Gatherer
 }
 
 type Parameters struct {
@@ -61,6 +64,10 @@ type Parameters struct {
 	VMManager                     vms.Manager
 }
 
+// NewService returns the handlers of the info API, keyed by the extension of
+// the endpoint they are served under. The JSON-RPC handler is served under the
+// base endpoint and the gRPC, gRPC-Web and Connect handler is served under the
+// path of the Connect service.
 func NewService(
 	parameters Parameters,
 	log logging.Logger,
@@ -70,24 +77,35 @@ func NewService(
 	myIP ips.DynamicIPPort,
 	network network.Network,
 	benchlist benchlist.Manager,
-) (http.Handler, error) {
+	metrics prometheus.Gatherer,
+) (map[string]http.Handler, error) {
+	info := &Info{
+		Parameters:   parameters,
+		log:          log,
+		validators:   validators,
+		chainManager: chainManager,
+		vmManager:    vmManager,
+		myIP:         myIP,
+		networking:   network,
+		benchlist:    benchlist,
+		metrics:      metrics,
+	}
+
 	server := rpc.NewServer()
 	codec := json.NewCodec()
 	server.RegisterCodec(codec, "application/json")
 	server.RegisterCodec(codec, "application/json;charset=UTF-8")
-	return server, server.RegisterService(
-		&Info{
-			Parameters:   parameters,
-			log:          log,
-			validators:   validators,
-			chainManager: chainManager,
-			vmManager:    vmManager,
-			myIP:         myIP,
-			networking:   network,
-			benchlist:    benchlist,
-		},
-		"info",
-	)
+	if err := server.RegisterService(info, "info"); err != nil {
+		return nil, err
+	}
+
+	connectPath, connectHandler := infoconnect.NewInfoHandler(&connectService{
+		info: info,
+	})
+	return map[string]http.Handler{
+		"":          server,
+		connectPath: connectHandler,
+	}, nil
 }
 
 // GetNodeVersionReply are the results from calling GetNodeVersion
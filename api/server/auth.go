@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	bearerPrefix = "Bearer "
+
+	// maxRateLimitedClients is the maximum number of clients whose rate limits
+	// are tracked per endpoint. If more clients send requests, the least
+	// recently seen clients have their limits reset.
+	maxRateLimitedClients = 4096
+)
+
+var (
+	errEmptyToken          = errors.New("empty token")
+	errInvalidFingerprint  = errors.New("invalid client certificate fingerprint")
+	errInvalidRateLimit    = errors.New("invalid rate limit")
+	errMissingRateLimit    = errors.New("burst is set without a rate limit")
+	errInvalidEndpointBase = errors.New("invalid endpoint base")
+)
+
+// AuthConfig specifies how requests to the APIs are authenticated and rate
+// limited.
+type AuthConfig struct {
+	// Endpoints maps the base of an API, such as "admin", "info" or
+	// "bc/<chainID>", to the policy that guards it. The policy of "*" guards
+	// every API that doesn't have its own policy. APIs without a policy are
+	// neither authenticated nor rate limited.
+	Endpoints map[string]EndpointPolicy `json:"endpoints"`
+}
+
+type EndpointPolicy struct {
+	// Tokens that are accepted as bearer tokens in the Authorization header.
+	Tokens []string `json:"tokens"`
+
+	// ClientCertificates are the hex encoded SHA256 fingerprints of the DER
+	// encoded TLS client certificates that are accepted.
+	ClientCertificates []string `json:"clientCertificates"`
+
+	// RequestsPerSecond is the maximum sustained rate of requests of each
+	// client. If 0, requests aren't rate limited.
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+
+	// Burst is the maximum number of requests that each client can send at
+	// once. Defaults to 1.
+	Burst int `json:"burst"`
+}
+
+func (c *AuthConfig) Verify() error {
+	for base, policy := range c.Endpoints {
+		if base == "" || strings.HasPrefix(base, "/") {
+			return fmt.Errorf("%w: %q", errInvalidEndpointBase, base)
+		}
+		if err := policy.Verify(); err != nil {
+			return fmt.Errorf("invalid policy for %q: %w", base, err)
+		}
+	}
+	return nil
+}
+
+// RequiresClientCertificates returns true if any of the APIs accept TLS client
+// certificates.
+func (c *AuthConfig) RequiresClientCertificates() bool {
+	for _, policy := range c.Endpoints {
+		if len(policy.ClientCertificates) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *EndpointPolicy) Verify() error {
+	for _, token := range p.Tokens {
+		if token == "" {
+			return errEmptyToken
+		}
+	}
+	for _, fingerprint := range p.ClientCertificates {
+		if _, err := parseFingerprint(fingerprint); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.RequestsPerSecond < 0:
+		return fmt.Errorf("%w: %f requests per second", errInvalidRateLimit, p.RequestsPerSecond)
+	case p.Burst < 0:
+		return fmt.Errorf("%w: burst of %d", errInvalidRateLimit, p.Burst)
+	case p.RequestsPerSecond == 0 && p.Burst != 0:
+		return errMissingRateLimit
+	default:
+		return nil
+	}
+}
+
+func parseFingerprint(fingerprint string) ([sha256.Size]byte, error) {
+	var parsed [sha256.Size]byte
+	bytes, err := hex.DecodeString(fingerprint)
+	if err != nil {
+		return parsed, fmt.Errorf("%w %q: %w", errInvalidFingerprint, fingerprint, err)
+	}
+	if len(bytes) != sha256.Size {
+		return parsed, fmt.Errorf("%w %q: expected %d bytes but got %d", errInvalidFingerprint, fingerprint, sha256.Size, len(bytes))
+	}
+	copy(parsed[:], bytes)
+	return parsed, nil
+}
+
+// auth enforces the policies of an [AuthConfig].
+type auth struct {
+	// Maps the base of an API to the policy that guards it. Every API that
+	// shares a base shares the rate limits of its clients.
+	policies map[string]*endpointAuth
+}
+
+func newAuth(config AuthConfig) (*auth, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	a := &auth{
+		policies: make(map[string]*endpointAuth, len(config.Endpoints)),
+	}
+	for base, policy := range config.Endpoints {
+		e := &endpointAuth{
+			tokens:  make([][sha256.Size]byte, len(policy.Tokens)),
+			certs:   set.NewSet[[sha256.Size]byte](len(policy.ClientCertificates)),
+			limit:   rate.Limit(policy.RequestsPerSecond),
+			burst:   max(policy.Burst, 1),
+			clients: &cache.LRU[string, *rate.Limiter]{Size: maxRateLimitedClients},
+		}
+		for i, token := range policy.Tokens {
+			e.tokens[i] = sha256.Sum256([]byte(token))
+		}
+		for _, fingerprint := range policy.ClientCertificates {
+			// The fingerprints were verified above.
+			parsed, _ := parseFingerprint(fingerprint)
+			e.certs.Add(parsed)
+		}
+		a.policies[base] = e
+	}
+	return a, nil
+}
+
+// wrapHandler returns [handler] guarded by the policy of [base]. If there is
+// no such policy, [handler] is returned unmodified.
+func (a *auth) wrapHandler(base string, handler http.Handler) http.Handler {
+	policy, ok := a.policies[base]
+	if !ok {
+		policy, ok = a.policies[wildcard]
+	}
+	if !ok {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, ok := policy.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if !policy.allow(client) {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+type endpointAuth struct {
+	// SHA256 hashes of the accepted bearer tokens
+	tokens [][sha256.Size]byte
+	// SHA256 fingerprints of the accepted client certificates
+	certs set.Set[[sha256.Size]byte]
+
+	limit   rate.Limit
+	burst   int
+	clients *cache.LRU[string, *rate.Limiter]
+}
+
+// authenticate returns the identity of the client that sent [r] and true if
+// the client is allowed to access the API.
+func (e *endpointAuth) authenticate(r *http.Request) (string, bool) {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		fingerprint := sha256.Sum256(r.TLS.PeerCertificates[0].Raw)
+		if e.certs.Contains(fingerprint) {
+			return "cert:" + hex.EncodeToString(fingerprint[:]), true
+		}
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix); ok {
+		hash := sha256.Sum256([]byte(token))
+		for i, expected := range e.tokens {
+			if subtle.ConstantTimeCompare(hash[:], expected[:]) == 1 {
+				return fmt.Sprintf("token:%d", i), true
+			}
+		}
+	}
+
+	if len(e.tokens) > 0 || e.certs.Len() > 0 {
+		return "", false
+	}
+
+	// If the API doesn't require authentication, clients are identified by
+	// their IP.
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host, true
+}
+
+// allow returns true if [client] hasn't exceeded its rate limit.
+func (e *endpointAuth) allow(client string) bool {
+	if e.limit == 0 {
+		return true
+	}
+
+	// The limiter of the client may be fetched and created concurrently. In
+	// the worst case, the client is granted a fresh burst.
+	limiter, ok := e.clients.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(e.limit, e.burst)
+		e.clients.Put(client, limiter)
+	}
+	return limiter.Allow()
+}
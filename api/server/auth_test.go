@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthConfigVerify(t *testing.T) {
+	validFingerprint := strings.Repeat("ab", sha256.Size)
+	tests := []struct {
+		name        string
+		config      AuthConfig
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			config:      AuthConfig{},
+			expectedErr: nil,
+		},
+		{
+			name: "valid",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"admin": {
+						Tokens:             []string{"token"},
+						ClientCertificates: []string{validFingerprint},
+					},
+					wildcard: {
+						RequestsPerSecond: 10,
+						Burst:             20,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid base",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"/admin": {},
+				},
+			},
+			expectedErr: errInvalidEndpointBase,
+		},
+		{
+			name: "empty token",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"admin": {
+						Tokens: []string{""},
+					},
+				},
+			},
+			expectedErr: errEmptyToken,
+		},
+		{
+			name: "fingerprint not hex",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"admin": {
+						ClientCertificates: []string{"not hex"},
+					},
+				},
+			},
+			expectedErr: errInvalidFingerprint,
+		},
+		{
+			name: "fingerprint wrong length",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"admin": {
+						ClientCertificates: []string{"abcd"},
+					},
+				},
+			},
+			expectedErr: errInvalidFingerprint,
+		},
+		{
+			name: "negative rate",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"info": {
+						RequestsPerSecond: -1,
+					},
+				},
+			},
+			expectedErr: errInvalidRateLimit,
+		},
+		{
+			name: "burst without rate",
+			config: AuthConfig{
+				Endpoints: map[string]EndpointPolicy{
+					"info": {
+						Burst: 1,
+					},
+				},
+			},
+			expectedErr: errMissingRateLimit,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAuthHandler(t *testing.T) {
+	cert := &x509.Certificate{Raw: []byte("client certificate")}
+	fingerprint := sha256.Sum256(cert.Raw)
+
+	a, err := newAuth(AuthConfig{
+		Endpoints: map[string]EndpointPolicy{
+			"admin": {
+				Tokens:             []string{"admin token"},
+				ClientCertificates: []string{hex.EncodeToString(fingerprint[:])},
+			},
+			wildcard: {
+				Tokens: []string{"default token"},
+			},
+			"info": {},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		base               string
+		token              string
+		cert               *x509.Certificate
+		expectedStatusCode int
+	}{
+		{
+			name:               "missing token",
+			base:               "admin",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "wrong token",
+			base:               "admin",
+			token:              "default token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "valid token",
+			base:               "admin",
+			token:              "admin token",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "valid client certificate",
+			base:               "admin",
+			cert:               cert,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "unknown client certificate",
+			base:               "keystore",
+			cert:               cert,
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "wildcard policy",
+			base:               "keystore",
+			token:              "default token",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "open policy",
+			base:               "info",
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := &testHandler{}
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if test.token != "" {
+				r.Header.Set("Authorization", bearerPrefix+test.token)
+			}
+			if test.cert != nil {
+				r.TLS = &tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{test.cert},
+				}
+			}
+			w := httptest.NewRecorder()
+			a.wrapHandler(test.base, handler).ServeHTTP(w, r)
+			require.Equal(test.expectedStatusCode, w.Code)
+			require.Equal(test.expectedStatusCode == http.StatusOK, handler.called)
+		})
+	}
+}
+
+func TestAuthHandlerNoPolicy(t *testing.T) {
+	a, err := newAuth(AuthConfig{})
+	require.NoError(t, err)
+
+	handler := &testHandler{}
+	require.Equal(t, handler, a.wrapHandler("admin", handler))
+}
+
+func TestAuthHandlerRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	a, err := newAuth(AuthConfig{
+		Endpoints: map[string]EndpointPolicy{
+			"info": {
+				RequestsPerSecond: 1e-9,
+				Burst:             2,
+			},
+		},
+	})
+	require.NoError(err)
+
+	// Every endpoint of an API shares the limits of its clients.
+	handlers := []http.Handler{
+		a.wrapHandler("info", &testHandler{}),
+		a.wrapHandler("info", &testHandler{}),
+	}
+	send := func(handler http.Handler, remoteAddr string) int {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	require.Equal(http.StatusOK, send(handlers[0], "1.2.3.4:1"))
+	require.Equal(http.StatusOK, send(handlers[1], "1.2.3.4:2"))
+	require.Equal(http.StatusTooManyRequests, send(handlers[0], "1.2.3.4:3"))
+
+	// Other clients have their own limits.
+	require.Equal(http.StatusOK, send(handlers[0], "5.6.7.8:1"))
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -91,8 +92,16 @@ func (r *router) forceAddRouter(base, endpoint string, handler http.Handler) err
 	endpoints[endpoint] = handler
 	r.routes[base] = endpoints
 
+	// Endpoints that end with a slash, such as those of Connect services, serve
+	// every path under them. The base is stripped from the path so that the
+	// endpoint is served the same way under every alias of the base.
+	var route *mux.Route
+	if strings.HasSuffix(endpoint, "/") {
+		route = r.router.PathPrefix(url).Handler(http.StripPrefix(base, handler))
+	} else {
+		route = r.router.Handle(url, handler)
+	}
 	// Name routes based on their URL for easy retrieval in the future
-	route := r.router.Handle(url, handler)
 	if route == nil {
 		return fmt.Errorf("failed to create new route for %s", url)
 	}
@@ -5,6 +5,7 @@ package server
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -58,3 +59,42 @@ func TestBlock(t *testing.T) {
 	err := r.AddRouter("1", "", handler1)
 	require.ErrorIs(err, errAlreadyReserved)
 }
+
+func TestPrefixRoute(t *testing.T) {
+	require := require.New(t)
+
+	r := newRouter()
+
+	var servedPath string
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		servedPath = r.URL.Path
+	})
+	require.NoError(r.AddRouter("/ext/bc/1", "/service.Service/", handler))
+	require.NoError(r.AddAlias("/ext/bc/1", "/ext/bc/X"))
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedPath string
+	}{
+		{
+			name:         "base",
+			path:         "/ext/bc/1/service.Service/Method",
+			expectedPath: "/service.Service/Method",
+		},
+		{
+			name:         "alias",
+			path:         "/ext/bc/X/service.Service/Method",
+			expectedPath: "/service.Service/Method",
+		},
+		{
+			name: "other endpoint",
+			path: "/ext/bc/1/other/Method",
+		},
+	}
+	for _, test := range tests {
+		servedPath = ""
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, test.path, nil))
+		require.Equal(test.expectedPath, servedPath, test.name)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/NYTimes/gziphandler"
@@ -17,12 +19,16 @@ import (
 	"github.com/rs/cors"
 	"go.uber.org/zap"
 	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/health/healthconnect"
+	"github.com/ava-labs/avalanchego/proto/pb/index/indexconnect"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -35,6 +41,41 @@ const (
 var (
 	_ PathAdder = readPathAdder{}
 	_ Server    = (*server)(nil)
+
+	// Headers that browsers are allowed to send to the APIs in addition to
+	// the default headers. These include the headers that are used by the
+	// gRPC-Web and Connect protocols.
+	allowedHeaders = []string{
+		"Accept",
+		"Authorization",
+		"Connect-Protocol-Version",
+		"Connect-Timeout-Ms",
+		"Content-Type",
+		"Grpc-Timeout",
+		"X-Grpc-Web",
+		"X-Requested-With",
+		"X-User-Agent",
+	}
+	// Headers that browsers are allowed to read from the responses of the
+	// APIs in addition to the default headers.
+	exposedHeaders = []string{
+		"Grpc-Message",
+		"Grpc-Status",
+		"Grpc-Status-Details-Bin",
+	}
+	// Content types of requests that are framed as streams. Every gRPC and
+	// gRPC-Web request is framed as a stream, while Connect only frames
+	// streaming requests.
+	streamingContentTypes = []string{
+		"application/grpc",
+		"application/connect+",
+	}
+	// Procedures that keep streaming responses for as long as the client is
+	// subscribed.
+	serverStreamingProcedures = []string{
+		healthconnect.HealthStreamHealthProcedure,
+		indexconnect.IndexStreamAcceptedProcedure,
+	}
 )
 
 type PathAdder interface {
@@ -63,7 +104,8 @@ type Server interface {
 	Dispatch() error
 	// RegisterChain registers the API endpoints associated with this chain.
 	// That is, add <route, handler> pairs to server so that API calls can be
-	// made to the VM.
+	// made to the VM. If the chain was already registered, its endpoints are
+	// served by [vm] instead.
 	RegisterChain(chainName string, ctx *snow.ConsensusContext, vm common.VM)
 	// Shutdown this server
 	Shutdown() error
@@ -89,9 +131,17 @@ type server struct {
 
 	metrics *metrics
 
+	// Authenticates and rate limits requests
+	auth *auth
+
 	// Maps endpoints to handlers
 	router *router
 
+	// Maps the URLs of the chains' endpoints to their handlers, so that the
+	// handlers can be replaced when a chain is restarted.
+	chainRoutesLock sync.Mutex
+	chainRoutes     map[string]*utils.Atomic[http.Handler]
+
 	srv *http.Server
 
 	// Listener used to serve traffic
@@ -112,16 +162,24 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	authConfig AuthConfig,
 ) (Server, error) {
 	m, err := newMetrics(namespace, registerer)
 	if err != nil {
 		return nil, err
 	}
 
+	auth, err := newAuth(authConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	router := newRouter()
 	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
+		AllowedHeaders:   allowedHeaders,
+		ExposedHeaders:   exposedHeaders,
 		AllowCredentials: true,
 	}).Handler(allowedHostsHandler)
 	gzipHandler := gziphandler.GzipHandler(corsHandler)
@@ -129,9 +187,30 @@ func New(
 		func(w http.ResponseWriter, r *http.Request) {
 			// Attach this node's ID as a header
 			w.Header().Set("node-id", nodeID.String())
-			gzipHandler.ServeHTTP(w, r)
+
+			if !isStreamingRequest(r) {
+				gzipHandler.ServeHTTP(w, r)
+				return
+			}
+
+			// Streams are flushed as each message is written, so they aren't
+			// buffered for compression. Server streams may stay open
+			// indefinitely, so they aren't cut short by the write timeout.
+			if isServerStreamingRequest(r) {
+				if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+					log.Debug("failed to remove write deadline of stream",
+						zap.Error(err),
+					)
+				}
+			}
+			corsHandler.ServeHTTP(w, r)
 		},
 	)
+	// Serve HTTP/2 over cleartext connections so that gRPC clients can
+	// connect without TLS.
+	handler = h2c.NewHandler(handler, &http2.Server{
+		MaxConcurrentStreams: maxConcurrentStreams,
+	})
 
 	httpServer := &http.Server{
 		Handler:           handler,
@@ -158,7 +237,9 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		auth:            auth,
 		router:          router,
+		chainRoutes:     make(map[string]*utils.Atomic[http.Handler]),
 		srv:             httpServer,
 		listener:        listener,
 	}, nil
@@ -217,8 +298,29 @@ func (s *server) addChainRoute(chainName string, handler http.Handler, ctx *snow
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	handler = rejectMiddleware(handler, ctx)
+	handler = s.auth.wrapHandler(base, handler)
 	handler = s.metrics.wrapHandler(chainName, handler)
-	return s.router.AddRouter(url, endpoint, handler)
+
+	s.chainRoutesLock.Lock()
+	defer s.chainRoutesLock.Unlock()
+
+	// If the chain was restarted, its routes were already added. Routes can't
+	// be removed, so the new handler replaces the handler of the old chain.
+	route := url + endpoint
+	if current, ok := s.chainRoutes[route]; ok {
+		current.Set(handler)
+		return nil
+	}
+
+	current := utils.NewAtomic(handler)
+	err := s.router.AddRouter(url, endpoint, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current.Get().ServeHTTP(w, r)
+	}))
+	if err != nil {
+		return err
+	}
+	s.chainRoutes[route] = current
+	return nil
 }
 
 func (s *server) AddRoute(handler http.Handler, base, endpoint string) error {
@@ -242,10 +344,38 @@ func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
 
+	handler = s.auth.wrapHandler(base, handler)
 	handler = s.metrics.wrapHandler(base, handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
 
+// isStreamingRequest returns true if [r] opens a gRPC, gRPC-Web or Connect
+// stream.
+func isStreamingRequest(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	for _, prefix := range streamingContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isServerStreamingRequest returns true if [r] opens a stream of a procedure
+// that streams responses to the client. Services are served under a path
+// prefix, so only the suffix of the path is compared.
+func isServerStreamingRequest(r *http.Request) bool {
+	if !isStreamingRequest(r) {
+		return false
+	}
+	for _, procedure := range serverStreamingProcedures {
+		if strings.HasSuffix(r.URL.Path, procedure) {
+			return true
+		}
+	}
+	return false
+}
+
 // Reject middleware wraps a handler. If the chain that the context describes is
 // not done state-syncing/bootstrapping, writes back an error.
 func rejectMiddleware(handler http.Handler, ctx *snow.ConsensusContext) http.Handler {
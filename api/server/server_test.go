@@ -8,10 +8,13 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
 func TestRejectMiddleware(t *testing.T) {
@@ -72,3 +75,122 @@ func TestRejectMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestAddChainRouteReplacesHandler(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := newMetrics("", prometheus.NewRegistry())
+	require.NoError(err)
+	s := &server{
+		log:         logging.NoLog{},
+		metrics:     metrics,
+		auth:        &auth{},
+		router:      newRouter(),
+		chainRoutes: make(map[string]*utils.Atomic[http.Handler]),
+	}
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	ctx.State.Set(snow.EngineState{
+		State: snow.NormalOp,
+	})
+
+	serve := func() int {
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ext/bc/chain/rpc", nil))
+		return w.Code
+	}
+
+	oldHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	require.NoError(s.addChainRoute("chain", oldHandler, ctx, "bc/chain", "/rpc"))
+	require.Equal(http.StatusTeapot, serve())
+
+	// Adding the route of a restarted chain replaces the old handler.
+	newHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	require.NoError(s.addChainRoute("chain", newHandler, ctx, "bc/chain", "/rpc"))
+	require.Equal(http.StatusAccepted, serve())
+}
+
+func TestIsStreamingRequest(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    bool
+	}{
+		{
+			contentType: "application/json",
+			expected:    false,
+		},
+		{
+			contentType: "application/proto",
+			expected:    false,
+		},
+		{
+			contentType: "application/grpc",
+			expected:    true,
+		},
+		{
+			contentType: "application/grpc-web+proto",
+			expected:    true,
+		},
+		{
+			contentType: "application/connect+json",
+			expected:    true,
+		},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Content-Type", test.contentType)
+		require.Equal(t, test.expected, isStreamingRequest(r), test.contentType)
+	}
+}
+
+func TestIsServerStreamingRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		expected    bool
+	}{
+		{
+			name:        "unary grpc",
+			path:        "/ext/health/health.Health/Health",
+			contentType: "application/grpc",
+			expected:    false,
+		},
+		{
+			name:        "unary connect",
+			path:        "/ext/index/X/tx/index.Index/GetLastAccepted",
+			contentType: "application/proto",
+			expected:    false,
+		},
+		{
+			name:        "server streaming grpc",
+			path:        "/ext/health/health.Health/StreamHealth",
+			contentType: "application/grpc",
+			expected:    true,
+		},
+		{
+			name:        "server streaming connect",
+			path:        "/ext/index/X/tx/index.Index/StreamAccepted",
+			contentType: "application/connect+proto",
+			expected:    true,
+		},
+		{
+			name:        "server streaming path without stream framing",
+			path:        "/ext/health/health.Health/StreamHealth",
+			contentType: "application/json",
+			expected:    false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, test.path, nil)
+			r.Header.Set("Content-Type", test.contentType)
+			require.Equal(t, test.expected, isServerStreamingRequest(r))
+		})
+	}
+}
@@ -4,12 +4,15 @@
 package chains
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sync"
 	"time"
 
@@ -44,6 +47,7 @@ import (
 	"github.com/ava-labs/avalanchego/staking"
 	"github.com/ava-labs/avalanchego/subnets"
 	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -94,6 +98,12 @@ var (
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errTrackPrimaryNetwork     = errors.New("the primary network is always tracked")
+	errSybilProtectionDisabled = errors.New("all subnets are tracked when sybil protection is disabled")
+	errNoSubnetTracker         = errors.New("platform chain is not initialized")
+	errSubnetUntracked         = errors.New("subnet was untracked since the node started; restart the node to track it again")
+	errChainNotRunning         = errors.New("chain is not running")
+	errRestartCriticalChain    = errors.New("chain doesn't support reloading its config and can't be restarted; restart the node to apply the config")
 
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
@@ -132,10 +142,36 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Chains returns the chains that are currently running
+	Chains() []ChainInfo
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
 
+	// SetSubnetTracker registers the P-chain, which is used to create the
+	// chains of the subnets that are tracked at runtime.
+	SetSubnetTracker(SubnetTracker)
+
+	// TrackSubnet starts validating [subnetID] by creating its chains. The
+	// change is persisted, so the subnet is still tracked after a restart.
+	TrackSubnet(subnetID ids.ID) error
+
+	// UntrackSubnet stops validating [subnetID] by shutting down its chains.
+	// The change is persisted, so the subnet is still untracked after a
+	// restart.
+	UntrackSubnet(ctx context.Context, subnetID ids.ID) error
+
+	// ReloadChainConfig reads the chain and subnet configs again and applies
+	// them to [chainID]. If the chain's VM implements [common.ConfigReloader]
+	// and only the chain's config changed, the config is reloaded by the VM.
+	// Otherwise, the chain is restarted.
+	//
+	// Critical chains (the P-chain, X-chain, and C-chain) can't be restarted,
+	// so changes to their configs are only applied if their VM reloads them.
+	// None of them currently do, so the node must be restarted to apply them.
+	ReloadChainConfig(ctx context.Context, chainID ids.ID) (ReloadResult, error)
+
 	Shutdown()
 }
 
@@ -160,6 +196,25 @@ type chain struct {
 	Context *snow.ConsensusContext
 	VM      common.VM
 	Handler handler.Handler
+
+	Params ChainParameters
+	// The configs that the chain is running with
+	Config       ChainConfig
+	SubnetConfig subnets.Config
+	// Nil if the chain's VM can't reload its config
+	ConfigReloader common.ConfigReloader
+}
+
+// ChainInfo describes a running chain.
+type ChainInfo struct {
+	ID       ids.ID
+	SubnetID ids.ID
+	// Name is the primary alias of the chain
+	Name string
+	// MetricsNamespace is the namespace that the chain's consensus metrics are
+	// registered with in [ManagerConfig.Metrics]. The VM's metrics are
+	// registered with the "vm" namespace appended to it.
+	MetricsNamespace string
 }
 
 // ChainConfig is configuration settings for the current execution.
@@ -170,6 +225,26 @@ type ChainConfig struct {
 	Upgrade []byte
 }
 
+// ConfigReader reads the chain and subnet configs from where they were read
+// when the node started.
+type ConfigReader interface {
+	// ReadChainConfigs returns the chain configs keyed by chain ID or alias.
+	ReadChainConfigs() (map[string]ChainConfig, error)
+
+	// ReadSubnetConfigs returns the configs of [subnetIDs]. Subnets that don't
+	// have a config are not included.
+	ReadSubnetConfigs(subnetIDs []ids.ID) (map[ids.ID]subnets.Config, error)
+}
+
+// ReloadResult describes how the configs were applied to a chain by
+// [Manager.ReloadChainConfig].
+type ReloadResult struct {
+	// True if the chain's config or its subnet's config changed
+	Changed bool
+	// True if the chain was restarted to apply the configs
+	Restarted bool
+}
+
 type ManagerConfig struct {
 	SybilProtectionEnabled bool
 	StakingTLSSigner       crypto.Signer
@@ -203,6 +278,7 @@ type ManagerConfig struct {
 	Health                    health.Registerer
 	SubnetConfigs             map[ids.ID]subnets.Config // ID -> SubnetConfig
 	ChainConfigs              map[string]ChainConfig    // alias -> ChainConfig
+	ConfigReader              ConfigReader              // Reads the configs again when they are reloaded
 	// ShutdownNodeFunc allows the chain manager to issue a request to shutdown the node
 	ShutdownNodeFunc func(exitCode int)
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
@@ -226,11 +302,17 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Scores peers based on their observed behavior.
+	PeerScorer timetracker.PeerScorer
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
 
 	Subnets *Subnets
+
+	// Persists the changes made to the tracked subnets at runtime
+	TrackedSubnetsDB database.Database
 }
 
 type manager struct {
@@ -254,7 +336,15 @@ type manager struct {
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
-	chains map[ids.ID]handler.Handler
+	chains map[ids.ID]*chain
+	// Subnets whose chains were shut down by [UntrackSubnet]. Their chains
+	// are not created again until the node restarts.
+	untrackedSubnets set.Set[ids.ID]
+
+	// Serializes changes to the tracked subnets with the creation of chains,
+	// so that no chain is created for a subnet that is being untracked.
+	trackingLock  sync.Mutex
+	subnetTracker utils.Atomic[SubnetTracker]
 
 	// snowman++ related interface to allow validators retrieval
 	validatorState validators.State
@@ -265,7 +355,7 @@ func New(config *ManagerConfig) Manager {
 	return &manager{
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
-		chains:                 make(map[ids.ID]handler.Handler),
+		chains:                 make(map[ids.ID]*chain),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
 		chainCreatorShutdownCh: make(chan struct{}),
@@ -300,6 +390,22 @@ func (m *manager) QueueChainCreation(chainParams ChainParameters) {
 // Note: it is expected for the subnet to already have the chain registered as
 // bootstrapping before this function is called
 func (m *manager) createChain(chainParams ChainParameters) {
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	m.chainsLock.Lock()
+	untracked := m.untrackedSubnets.Contains(chainParams.SubnetID)
+	m.chainsLock.Unlock()
+	if untracked {
+		m.Log.Info("skipping chain creation",
+			zap.String("reason", "subnet is no longer tracked"),
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+		)
+		return
+	}
+
 	m.Log.Info("creating chain",
 		zap.Stringer("subnetID", chainParams.SubnetID),
 		zap.Stringer("chainID", chainParams.ID),
@@ -313,7 +419,20 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	// issue some internal messages), is delayed until chain dispatching is started and
 	// the chain is registered in the manager. This ensures that no message generated by handler
 	// upon start is dropped.
-	chain, err := m.buildChain(chainParams, sb)
+	chain, err := m.buildChain(chainParams, sb, nil)
+	if err == nil {
+		// The health check is registered once, as health checks can't be
+		// removed. It reports the health of the chain even if the chain is
+		// restarted.
+		err = m.Health.RegisterHealthCheck(
+			chain.Name,
+			m.chainHealthCheck(chainParams.ID, chainParams.SubnetID),
+			chainParams.SubnetID.String(),
+		)
+		if err != nil {
+			err = fmt.Errorf("couldn't add health check for chain %s: %w", chain.Name, err)
+		}
+	}
 	if err != nil {
 		if m.CriticalChains.Contains(chainParams.ID) {
 			// Shut down if we fail to create a required chain (i.e. X, P or C)
@@ -361,7 +480,7 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	}
 
 	m.chainsLock.Lock()
-	m.chains[chainParams.ID] = chain.Handler
+	m.chains[chainParams.ID] = chain
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -399,8 +518,8 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	chain.Handler.Start(context.TODO(), !m.CriticalChains.Contains(chainParams.ID))
 }
 
-// Create a chain
-func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*chain, error) {
+// Create a chain. If [chainLog] is nil, a new log is created for the chain.
+func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet, chainLog logging.Logger) (*chain, error) {
 	if chainParams.ID != constants.PlatformChainID && chainParams.VMID == constants.PlatformVMID {
 		return nil, errCreatePlatformVM
 	}
@@ -413,9 +532,12 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 	}
 
 	// Create the log and context of the chain
-	chainLog, err := m.LogFactory.MakeChain(primaryAlias)
-	if err != nil {
-		return nil, fmt.Errorf("error while creating chain's log %w", err)
+	if chainLog == nil {
+		var err error
+		chainLog, err = m.LogFactory.MakeChain(primaryAlias)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating chain's log %w", err)
+		}
 	}
 
 	consensusMetrics := prometheus.NewRegistry()
@@ -460,6 +582,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			WarpSigner: warp.NewSigner(m.StakingBLSKey, m.NetworkID, chainParams.ID),
 
 			ValidatorState: m.validatorState,
+			PeerScores:     m.PeerScorer,
 			ChainDataDir:   chainDataDir,
 		},
 		BlockAcceptor:       m.BlockAcceptorGroup,
@@ -536,6 +659,11 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		return nil, err
 	}
 
+	chain.Params = chainParams
+	chain.SubnetConfig = sb.Config()
+	// The wrappers of the VM don't forward optional interfaces, so the VM
+	// created by the factory is checked.
+	chain.ConfigReloader, _ = vm.(common.ConfigReloader)
 	return chain, nil
 }
 
@@ -620,7 +748,7 @@ func (m *manager) createAvalancheChain(
 		snowmanMessageSender = sender.Trace(snowmanMessageSender, m.Tracer)
 	}
 
-	chainConfig, err := m.getChainConfig(ctx.ChainID)
+	chainConfig, err := m.getChainConfig(m.ChainConfigs, ctx.ChainID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
 	}
@@ -913,16 +1041,12 @@ func (m *manager) createAvalancheChain(
 		},
 	})
 
-	// Register health check for this chain
-	if err := m.Health.RegisterHealthCheck(chainAlias, h, ctx.SubnetID.String()); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
 		VM:      dagVM,
 		Handler: h,
+		Config:  chainConfig,
 	}, nil
 }
 
@@ -1024,7 +1148,7 @@ func (m *manager) createSnowmanChain(
 	}
 
 	// Initialize the ProposerVM and the vm wrapped inside it
-	chainConfig, err := m.getChainConfig(ctx.ChainID)
+	chainConfig, err := m.getChainConfig(m.ChainConfigs, ctx.ChainID)
 	if err != nil {
 		return nil, fmt.Errorf("error while fetching chain config: %w", err)
 	}
@@ -1225,16 +1349,12 @@ func (m *manager) createSnowmanChain(
 		},
 	})
 
-	// Register health checks
-	if err := m.Health.RegisterHealthCheck(chainAlias, h, ctx.SubnetID.String()); err != nil {
-		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", chainAlias, err)
-	}
-
 	return &chain{
 		Name:    chainAlias,
 		Context: ctx,
 		VM:      vm,
 		Handler: h,
+		Config:  chainConfig,
 	}, nil
 }
 
@@ -1246,7 +1366,23 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 		return false
 	}
 
-	return chain.Context().State.Get().State == snow.NormalOp
+	return chain.Context.State.Get().State == snow.NormalOp
+}
+
+func (m *manager) Chains() []ChainInfo {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	chains := make([]ChainInfo, 0, len(m.chains))
+	for chainID, chain := range m.chains {
+		chains = append(chains, ChainInfo{
+			ID:               chainID,
+			SubnetID:         chain.Params.SubnetID,
+			Name:             chain.Name,
+			MetricsNamespace: metric.AppendNamespace(constants.PlatformName, chain.Name),
+		})
+	}
+	return chains
 }
 
 func (m *manager) registerBootstrappedHealthChecks() error {
@@ -1291,6 +1427,27 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 	return nil
 }
 
+// chainHealthCheck reports the health of [chainID], which is validated by
+// [subnetID], until the subnet is untracked. Health checks can't be removed, so
+// once the chain is shut down it is reported as healthy.
+func (m *manager) chainHealthCheck(chainID ids.ID, subnetID ids.ID) health.Checker {
+	return health.CheckerFunc(func(ctx context.Context) (interface{}, error) {
+		m.chainsLock.Lock()
+		untracked := m.untrackedSubnets.Contains(subnetID)
+		chain, ok := m.chains[chainID]
+		m.chainsLock.Unlock()
+		switch {
+		case untracked:
+			return "subnet is no longer tracked", nil
+		case !ok:
+			// The chain is being created or restarted
+			return nil, errChainNotRunning
+		default:
+			return chain.Handler.HealthCheck(ctx)
+		}
+	})
+}
+
 // Starts chain creation loop to process queued chains
 func (m *manager) StartChainCreator(platformParams ChainParameters) error {
 	// Add the P-Chain to the Primary Network
@@ -1332,6 +1489,254 @@ func (m *manager) dispatchChainCreator() {
 	}
 }
 
+func (m *manager) SetSubnetTracker(tracker SubnetTracker) {
+	m.subnetTracker.Set(tracker)
+}
+
+func (m *manager) TrackSubnet(subnetID ids.ID) error {
+	if err := m.verifyTrackable(subnetID); err != nil {
+		return err
+	}
+
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	tracker := m.subnetTracker.Get()
+	if tracker == nil {
+		return errNoSubnetTracker
+	}
+
+	m.chainsLock.Lock()
+	untracked := m.untrackedSubnets.Contains(subnetID)
+	m.chainsLock.Unlock()
+	if untracked {
+		// The health checks, metrics, and API routes of the chains that were
+		// shut down can't be registered again.
+		return fmt.Errorf("%w: %s", errSubnetUntracked, subnetID)
+	}
+
+	if err := m.TrackedSubnetsDB.Put(subnetID[:], trackedValue); err != nil {
+		return fmt.Errorf("couldn't persist tracked subnet %s: %w", subnetID, err)
+	}
+
+	m.Log.Info("tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+	m.Net.TrackSubnet(subnetID)
+	// Mark this node as connected to the subnet, so that its chains consider
+	// this node when they are created.
+	m.ManagerConfig.Router.Connected(m.NodeID, version.CurrentApp, subnetID)
+	return tracker.TrackSubnet(subnetID)
+}
+
+func (m *manager) UntrackSubnet(ctx context.Context, subnetID ids.ID) error {
+	if err := m.verifyTrackable(subnetID); err != nil {
+		return err
+	}
+
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	tracker := m.subnetTracker.Get()
+	if tracker == nil {
+		return errNoSubnetTracker
+	}
+
+	m.Log.Info("untracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+	if err := tracker.UntrackSubnet(subnetID); err != nil {
+		return err
+	}
+	m.Net.UntrackSubnet(subnetID)
+
+	m.chainsLock.Lock()
+	m.untrackedSubnets.Add(subnetID)
+	var handlers []handler.Handler
+	for chainID, chain := range m.chains {
+		if chain.Params.SubnetID != subnetID {
+			continue
+		}
+		handlers = append(handlers, chain.Handler)
+		delete(m.chains, chainID)
+		m.RemoveAliases(chainID)
+	}
+	m.chainsLock.Unlock()
+
+	for _, chain := range handlers {
+		chain.Stop(ctx)
+	}
+	for _, chain := range handlers {
+		if _, err := chain.AwaitStopped(ctx); err != nil {
+			return fmt.Errorf("couldn't stop chain %s: %w", chain.Context().ChainID, err)
+		}
+	}
+
+	m.Subnets.Remove(subnetID)
+
+	// The subnet is only persisted as untracked once its chains were shut
+	// down, so that it's tracked again on restart if they fail to stop.
+	if err := m.TrackedSubnetsDB.Put(subnetID[:], untrackedValue); err != nil {
+		return fmt.Errorf("couldn't persist untracked subnet %s: %w", subnetID, err)
+	}
+	return nil
+}
+
+func (m *manager) verifyTrackable(subnetID ids.ID) error {
+	if subnetID == constants.PrimaryNetworkID {
+		return errTrackPrimaryNetwork
+	}
+	if !m.SybilProtectionEnabled {
+		return errSybilProtectionDisabled
+	}
+	return nil
+}
+
+func (m *manager) ReloadChainConfig(ctx context.Context, chainID ids.ID) (ReloadResult, error) {
+	// The configs are read when chains are created, so the configs aren't
+	// replaced while a chain is being created.
+	m.trackingLock.Lock()
+	defer m.trackingLock.Unlock()
+
+	m.chainsLock.Lock()
+	chain, ok := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !ok {
+		return ReloadResult{}, fmt.Errorf("%w: %s", errChainNotRunning, chainID)
+	}
+
+	chainConfigs, err := m.ConfigReader.ReadChainConfigs()
+	if err != nil {
+		return ReloadResult{}, fmt.Errorf("couldn't read chain configs: %w", err)
+	}
+	chainConfig, err := m.getChainConfig(chainConfigs, chainID)
+	if err != nil {
+		return ReloadResult{}, fmt.Errorf("couldn't get config of chain %s: %w", chainID, err)
+	}
+
+	// The config of the primary network is provided by flags, rather than by
+	// a subnet config, so it is not reloaded.
+	subnetID := chain.Params.SubnetID
+	subnetConfig := chain.SubnetConfig
+	if subnetID != constants.PrimaryNetworkID {
+		subnetConfigs, err := m.ConfigReader.ReadSubnetConfigs([]ids.ID{subnetID})
+		if err != nil {
+			return ReloadResult{}, fmt.Errorf("couldn't read config of subnet %s: %w", subnetID, err)
+		}
+
+		// Default to the primary network config if a subnet config was not
+		// specified, as is done when the subnet is created.
+		var ok bool
+		subnetConfig, ok = subnetConfigs[subnetID]
+		if !ok {
+			subnetConfig = m.SubnetConfigs[constants.PrimaryNetworkID]
+		}
+	}
+
+	var (
+		configChanged  = !bytes.Equal(chain.Config.Config, chainConfig.Config)
+		upgradeChanged = !bytes.Equal(chain.Config.Upgrade, chainConfig.Upgrade)
+		subnetChanged  = !reflect.DeepEqual(chain.SubnetConfig, subnetConfig)
+	)
+	if !configChanged && !upgradeChanged && !subnetChanged {
+		return ReloadResult{}, nil
+	}
+
+	if !upgradeChanged && !subnetChanged && chain.ConfigReloader != nil {
+		m.Log.Info("reloading chain config",
+			zap.Stringer("subnetID", subnetID),
+			zap.Stringer("chainID", chainID),
+		)
+
+		chain.Context.Lock.Lock()
+		err := chain.ConfigReloader.ReloadConfig(ctx, chainConfig.Config)
+		chain.Context.Lock.Unlock()
+		switch {
+		case err == nil:
+			m.ChainConfigs = chainConfigs
+			chain.Config = chainConfig
+			return ReloadResult{
+				Changed: true,
+			}, nil
+		case !errors.Is(err, common.ErrConfigReloaderNotImplemented):
+			return ReloadResult{}, fmt.Errorf("couldn't reload config of chain %s: %w", chainID, err)
+		}
+
+		// VMs that are run as plugins always implement [common.ConfigReloader],
+		// so the chain is restarted if the plugin doesn't support reloading
+		// its config.
+	}
+
+	// Stopping a critical chain shuts down the node.
+	if m.CriticalChains.Contains(chainID) {
+		return ReloadResult{}, fmt.Errorf("%w: %s", errRestartCriticalChain, chainID)
+	}
+
+	m.ChainConfigs = chainConfigs
+	if subnetChanged {
+		// The subnet configs are shared with [m.Subnets], so they are copied
+		// rather than modified.
+		m.SubnetConfigs = maps.Clone(m.SubnetConfigs)
+		m.SubnetConfigs[subnetID] = subnetConfig
+		m.Subnets.SetConfig(subnetID, subnetConfig)
+	}
+	if err := m.restartChain(ctx, chain); err != nil {
+		return ReloadResult{
+			Changed: true,
+		}, err
+	}
+	return ReloadResult{
+		Changed:   true,
+		Restarted: true,
+	}, nil
+}
+
+// restartChain shuts down [oldChain] and creates it again with the current
+// configs. The new chain reuses the log, health check, and API endpoints of
+// [oldChain], which can't be registered again.
+//
+// Assumes [m.trackingLock] is held.
+func (m *manager) restartChain(ctx context.Context, oldChain *chain) error {
+	chainParams := oldChain.Params
+	m.Log.Info("restarting chain",
+		zap.Stringer("subnetID", chainParams.SubnetID),
+		zap.Stringer("chainID", chainParams.ID),
+		zap.Stringer("vmID", chainParams.VMID),
+	)
+
+	m.chainsLock.Lock()
+	delete(m.chains, chainParams.ID)
+	m.chainsLock.Unlock()
+
+	oldChain.Handler.Stop(ctx)
+	if _, err := oldChain.Handler.AwaitStopped(ctx); err != nil {
+		return fmt.Errorf("couldn't stop chain %s: %w", chainParams.ID, err)
+	}
+
+	// Remove the metrics that were registered by [buildChain], so that they
+	// can be registered by the new chain.
+	chainNamespace := metric.AppendNamespace(constants.PlatformName, oldChain.Name)
+	m.Metrics.Deregister(chainNamespace)
+	m.Metrics.Deregister(metric.AppendNamespace(chainNamespace, "avalanche"))
+	m.Metrics.Deregister(metric.AppendNamespace(chainNamespace, "vm"))
+	m.TimeoutManager.DeregisterChain(chainParams.ID)
+
+	sb, _ := m.Subnets.GetOrCreate(chainParams.SubnetID)
+	chain, err := m.buildChain(chainParams, sb, oldChain.Context.Log)
+	if err != nil {
+		return fmt.Errorf("couldn't restart chain %s: %w", chainParams.ID, err)
+	}
+
+	m.chainsLock.Lock()
+	m.chains[chainParams.ID] = chain
+	m.chainsLock.Unlock()
+
+	m.notifyRegistrants(chain.Name, chain.Context, chain.VM)
+	m.ManagerConfig.Router.AddChain(context.TODO(), chain.Handler)
+	chain.Handler.Start(context.TODO(), true)
+	return nil
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
@@ -1354,10 +1759,11 @@ func (m *manager) notifyRegistrants(name string, ctx *snow.ConsensusContext, vm
 	}
 }
 
-// getChainConfig returns value of a entry by looking at ID key and alias key
-// it first searches ID key, then falls back to it's corresponding primary alias
-func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
-	if val, ok := m.ManagerConfig.ChainConfigs[id.String()]; ok {
+// getChainConfig returns value of a entry in [chainConfigs] by looking at ID key
+// and alias key it first searches ID key, then falls back to it's corresponding
+// primary alias
+func (m *manager) getChainConfig(chainConfigs map[string]ChainConfig, id ids.ID) (ChainConfig, error) {
+	if val, ok := chainConfigs[id.String()]; ok {
 		return val, nil
 	}
 	aliases, err := m.Aliases(id)
@@ -1365,7 +1771,7 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 		return ChainConfig{}, err
 	}
 	for _, alias := range aliases {
-		if val, ok := m.ManagerConfig.ChainConfigs[alias]; ok {
+		if val, ok := chainConfigs[alias]; ok {
 			return val, nil
 		}
 	}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+type testConfigReader struct {
+	chainConfigs  map[string]ChainConfig
+	subnetConfigs map[ids.ID]subnets.Config
+}
+
+func (r *testConfigReader) ReadChainConfigs() (map[string]ChainConfig, error) {
+	return r.chainConfigs, nil
+}
+
+func (r *testConfigReader) ReadSubnetConfigs([]ids.ID) (map[ids.ID]subnets.Config, error) {
+	return r.subnetConfigs, nil
+}
+
+type testConfigReloader struct {
+	configBytes []byte
+	err         error
+}
+
+func (r *testConfigReloader) ReloadConfig(_ context.Context, configBytes []byte) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.configBytes = configBytes
+	return nil
+}
+
+func TestReloadChainConfig(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	oldConfig := ChainConfig{
+		Config:  []byte("old config"),
+		Upgrade: []byte("upgrade"),
+	}
+	newConfig := ChainConfig{
+		Config:  []byte("new config"),
+		Upgrade: []byte("upgrade"),
+	}
+	newUpgrade := ChainConfig{
+		Config:  []byte("old config"),
+		Upgrade: []byte("new upgrade"),
+	}
+
+	tests := []struct {
+		name           string
+		subnetID       ids.ID
+		canReload      bool
+		reloadErr      error
+		critical       bool
+		chainConfig    ChainConfig
+		subnetConfig   *subnets.Config
+		expectedResult ReloadResult
+		expectedErr    error
+		expectedConfig []byte
+	}{
+		{
+			name:        "unchanged",
+			subnetID:    subnetID,
+			canReload:   true,
+			chainConfig: oldConfig,
+		},
+		{
+			name:           "hot reload",
+			subnetID:       subnetID,
+			canReload:      true,
+			chainConfig:    newConfig,
+			expectedResult: ReloadResult{Changed: true},
+			expectedConfig: newConfig.Config,
+		},
+		{
+			name:        "critical chain can't reload its config",
+			subnetID:    constants.PrimaryNetworkID,
+			critical:    true,
+			chainConfig: newConfig,
+			expectedErr: errRestartCriticalChain,
+		},
+		{
+			name:        "plugin can't reload config of critical chain",
+			subnetID:    constants.PrimaryNetworkID,
+			canReload:   true,
+			reloadErr:   common.ErrConfigReloaderNotImplemented,
+			critical:    true,
+			chainConfig: newConfig,
+			expectedErr: errRestartCriticalChain,
+		},
+		{
+			name:        "upgrade changed on critical chain",
+			subnetID:    constants.PrimaryNetworkID,
+			canReload:   true,
+			critical:    true,
+			chainConfig: newUpgrade,
+			expectedErr: errRestartCriticalChain,
+		},
+		{
+			name:      "subnet config changed on critical chain",
+			subnetID:  subnetID,
+			canReload: true,
+			critical:  true,
+			subnetConfig: &subnets.Config{
+				ValidatorOnly: true,
+			},
+			chainConfig: oldConfig,
+			expectedErr: errRestartCriticalChain,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			subnetConfigs := map[ids.ID]subnets.Config{}
+			if test.subnetConfig != nil {
+				subnetConfigs[test.subnetID] = *test.subnetConfig
+			}
+			reloader := &testConfigReloader{
+				err: test.reloadErr,
+			}
+			c := &chain{
+				Context: &snow.ConsensusContext{
+					Context: &snow.Context{},
+				},
+				Params: ChainParameters{
+					ID:       chainID,
+					SubnetID: test.subnetID,
+				},
+				Config: oldConfig,
+			}
+			if test.canReload {
+				c.ConfigReloader = reloader
+			}
+
+			var criticalChains set.Set[ids.ID]
+			if test.critical {
+				criticalChains.Add(chainID)
+			}
+			m := &manager{
+				Aliaser: ids.NewAliaser(),
+				ManagerConfig: ManagerConfig{
+					Log:            logging.NoLog{},
+					CriticalChains: criticalChains,
+					SubnetConfigs: map[ids.ID]subnets.Config{
+						constants.PrimaryNetworkID: {},
+					},
+					ConfigReader: &testConfigReader{
+						chainConfigs: map[string]ChainConfig{
+							chainID.String(): test.chainConfig,
+						},
+						subnetConfigs: subnetConfigs,
+					},
+				},
+				chains: map[ids.ID]*chain{
+					chainID: c,
+				},
+			}
+
+			result, err := m.ReloadChainConfig(context.Background(), chainID)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedResult, result)
+			require.Equal(test.expectedConfig, reloader.configBytes)
+			if test.expectedErr != nil {
+				require.Equal(oldConfig, c.Config)
+			}
+		})
+	}
+}
+
+func TestReloadChainConfigUnknownChain(t *testing.T) {
+	m := &manager{
+		chains: map[ids.ID]*chain{},
+	}
+	_, err := m.ReloadChainConfig(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(t, err, errChainNotRunning)
+}
@@ -5,6 +5,7 @@ package chains
 
 import (
 	"errors"
+	"maps"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -46,6 +47,29 @@ func (s *Subnets) GetOrCreate(subnetID ids.ID) (subnets.Subnet, bool) {
 	return subnet, true
 }
 
+// SetConfig replaces the config of the subnet. If the subnet is running, the
+// config is also replaced for its chains.
+func (s *Subnets) SetConfig(subnetID ids.ID, config subnets.Config) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The configs are shared with the node's config, so they are copied
+	// rather than modified.
+	s.configs = maps.Clone(s.configs)
+	s.configs[subnetID] = config
+	if subnet, ok := s.subnets[subnetID]; ok {
+		subnet.SetConfig(config)
+	}
+}
+
+// Remove the subnet, so that it is no longer reported as bootstrapping.
+func (s *Subnets) Remove(subnetID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.subnets, subnetID)
+}
+
 // Bootstrapping returns the subnetIDs of any chains that are still
 // bootstrapping.
 func (s *Subnets) Bootstrapping() []ids.ID {
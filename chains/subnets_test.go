@@ -167,3 +167,55 @@ func TestSubnetsBootstrapping(t *testing.T) {
 	subnet.Bootstrapped(chainID)
 	require.Empty(subnets.Bootstrapping())
 }
+
+func TestSubnetsRemove(t *testing.T) {
+	require := require.New(t)
+
+	config := map[ids.ID]subnets.Config{
+		constants.PrimaryNetworkID: {},
+	}
+
+	subnets, err := NewSubnets(ids.EmptyNodeID, config)
+	require.NoError(err)
+
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+
+	subnet, _ := subnets.GetOrCreate(subnetID)
+	subnet.AddChain(chainID)
+	require.Contains(subnets.Bootstrapping(), subnetID)
+
+	subnets.Remove(subnetID)
+	require.Empty(subnets.Bootstrapping())
+
+	_, ok := subnets.GetOrCreate(subnetID)
+	require.True(ok)
+}
+
+func TestSubnetsSetConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := map[ids.ID]subnets.Config{
+		constants.PrimaryNetworkID: {},
+	}
+
+	s, err := NewSubnets(ids.EmptyNodeID, config)
+	require.NoError(err)
+
+	runningSubnetID := ids.GenerateTestID()
+	runningSubnet, _ := s.GetOrCreate(runningSubnetID)
+
+	subnetConfig := subnets.Config{
+		ValidatorOnly: true,
+	}
+	s.SetConfig(runningSubnetID, subnetConfig)
+	require.Equal(subnetConfig, runningSubnet.Config())
+
+	newSubnetID := ids.GenerateTestID()
+	s.SetConfig(newSubnetID, subnetConfig)
+	newSubnet, _ := s.GetOrCreate(newSubnetID)
+	require.Equal(subnetConfig, newSubnet.Config())
+
+	// The provided configs must not be modified
+	require.Len(config, 1)
+}
@@ -3,7 +3,11 @@
 
 package chains
 
-import "github.com/ava-labs/avalanchego/ids"
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -29,6 +33,10 @@ func (testManager) PrimaryAliasOrDefault(ids.ID) string {
 	return ""
 }
 
+func (testManager) Chains() []ChainInfo {
+	return nil
+}
+
 func (testManager) Alias(ids.ID, string) error {
 	return nil
 }
@@ -41,6 +49,20 @@ func (testManager) StartChainCreator(ChainParameters) error {
 	return nil
 }
 
+func (testManager) SetSubnetTracker(SubnetTracker) {}
+
+func (testManager) TrackSubnet(ids.ID) error {
+	return nil
+}
+
+func (testManager) UntrackSubnet(context.Context, ids.ID) error {
+	return nil
+}
+
+func (testManager) ReloadChainConfig(context.Context, ids.ID) (ReloadResult, error) {
+	return ReloadResult{}, nil
+}
+
 func (testManager) SubnetID(ids.ID) (ids.ID, error) {
 	return ids.ID{}, nil
 }
@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"bytes"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	trackedValue   = []byte{1}
+	untrackedValue = []byte{0}
+)
+
+// SubnetTracker is implemented by the P-chain, which knows the chains that are
+// validated by each subnet.
+type SubnetTracker interface {
+	// TrackSubnet queues the creation of the chains validated by [subnetID],
+	// including the chains that are created on [subnetID] in the future.
+	TrackSubnet(subnetID ids.ID) error
+
+	// UntrackSubnet stops creating the chains validated by [subnetID].
+	UntrackSubnet(subnetID ids.ID) error
+}
+
+// ApplyTrackedSubnetChanges applies the changes to the tracked subnets that
+// were persisted into [db] by [Manager.TrackSubnet] and [Manager.UntrackSubnet]
+// to [trackedSubnets].
+func ApplyTrackedSubnetChanges(db database.Iteratee, trackedSubnets *set.Set[ids.ID]) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		subnetID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(it.Value(), trackedValue) {
+			trackedSubnets.Add(subnetID)
+		} else {
+			trackedSubnets.Remove(subnetID)
+		}
+	}
+	return it.Error()
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestApplyTrackedSubnetChanges(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		flagSubnetID      = ids.GenerateTestID()
+		untrackedSubnetID = ids.GenerateTestID()
+		trackedSubnetID   = ids.GenerateTestID()
+	)
+
+	db := memdb.New()
+	require.NoError(db.Put(untrackedSubnetID[:], untrackedValue))
+	require.NoError(db.Put(trackedSubnetID[:], trackedValue))
+
+	trackedSubnets := set.Of(flagSubnetID, untrackedSubnetID)
+	require.NoError(ApplyTrackedSubnetChanges(db, &trackedSubnets))
+	require.Equal(set.Of(flagSubnetID, trackedSubnetID), trackedSubnets)
+}
+
+func TestApplyTrackedSubnetChangesInvalidSubnetID(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte{1, 2, 3}, trackedValue))
+
+	var trackedSubnets set.Set[ids.ID]
+	err := ApplyTrackedSubnetChanges(db, &trackedSubnets)
+	require.ErrorIs(err, hashing.ErrInvalidHashLen)
+}
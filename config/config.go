@@ -18,13 +18,17 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -71,6 +75,8 @@ var (
 	errConflictingImplicitACPOpinion          = errors.New("objecting to enabled ACP")
 	errSybilProtectionDisabledStakerWeights   = errors.New("sybil protection disabled weights must be positive")
 	errSybilProtectionDisabledOnPublicNetwork = errors.New("sybil protection disabled on public network")
+	errProxyRequiresTLSTransport              = errors.New("proxy protocol requires the tls transport")
+	errNATTraversalRequiresTLSTransport       = errors.New("NAT traversal requires the tls transport")
 	errInvalidUptimeRequirement               = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax              = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee                   = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -88,6 +94,7 @@ var (
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
+	errHTTPAuthRequiresHTTPS                  = errors.New("client certificate authentication requires https")
 )
 
 func getConsensusConfig(v *viper.Viper) snowball.Parameters {
@@ -175,6 +182,15 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	authConfig, err := getHTTPAuthConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	httpsEnabled := v.GetBool(HTTPSEnabledKey)
+	if authConfig.RequiresClientCertificates() && !httpsEnabled {
+		return node.HTTPConfig{}, fmt.Errorf("%w: %s must be enabled to authenticate TLS client certificates", errHTTPAuthRequiresHTTPS, HTTPSEnabledKey)
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
@@ -186,25 +202,60 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexBackfill:        v.GetBool(IndexBackfillKey),
+				IndexAttributes:      v.GetBool(IndexAttributesKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			EventsAPIEnabled:   v.GetBool(EventsAPIEnabledKey),
 		},
 		HTTPHost:           v.GetString(HTTPHostKey),
 		HTTPPort:           uint16(v.GetUint(HTTPPortKey)),
-		HTTPSEnabled:       v.GetBool(HTTPSEnabledKey),
+		HTTPSEnabled:       httpsEnabled,
 		HTTPSKey:           httpsKey,
 		HTTPSCert:          httpsCert,
 		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
+		HTTPAuthConfig:     authConfig,
 		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:       v.GetDuration(HTTPShutdownWaitKey),
 	}, nil
 }
 
+func getHTTPAuthConfig(v *viper.Viper) (server.AuthConfig, error) {
+	var configBytes []byte
+	switch {
+	case v.IsSet(HTTPAuthConfigContentKey):
+		var err error
+		rawContent := v.GetString(HTTPAuthConfigContentKey)
+		configBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return server.AuthConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPAuthConfigFileKey):
+		var err error
+		configFilepath := GetExpandedArg(v, HTTPAuthConfigFileKey)
+		configBytes, err = os.ReadFile(filepath.Clean(configFilepath))
+		if err != nil {
+			return server.AuthConfig{}, err
+		}
+	default:
+		return server.AuthConfig{}, nil
+	}
+
+	var config server.AuthConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return server.AuthConfig{}, fmt.Errorf("%w on http auth config: %w", errUnmarshalling, err)
+	}
+	if err := config.Verify(); err != nil {
+		return server.AuthConfig{}, fmt.Errorf("invalid http auth config: %w", err)
+	}
+	return config, nil
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -266,6 +317,50 @@ func getNetworkConfig(
 		return network.Config{}, err
 	}
 
+	opCompressionTypes := make(map[message.Op]compression.Type)
+	for opStr, compressionTypeStr := range v.GetStringMapString(NetworkCompressionOpTypesKey) {
+		op, err := message.OpFromString(opStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCompressionOpTypesKey, err)
+		}
+		opCompressionTypes[op], err = compression.TypeFromString(compressionTypeStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s for %s: %w", NetworkCompressionOpTypesKey, op, err)
+		}
+	}
+
+	zstdDictionaries := make(map[message.Op][]byte)
+	for opStr, path := range v.GetStringMapString(NetworkCompressionZstdDictionariesKey) {
+		op, err := message.OpFromString(opStr)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCompressionZstdDictionariesKey, err)
+		}
+		dictionary, err := os.ReadFile(filepath.Clean(GetExpandedString(v, path)))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't read zstd dictionary for %s: %w", op, err)
+		}
+		if _, err := compression.ZstdDictionaryID(dictionary); err != nil {
+			return network.Config{}, fmt.Errorf("invalid zstd dictionary for %s: %w", op, err)
+		}
+		zstdDictionaries[op] = dictionary
+	}
+
+	transportType, err := transport.TypeFromString(v.GetString(NetworkTransportKey))
+	if err != nil {
+		return network.Config{}, err
+	}
+	proxyEnabled := v.GetBool(NetworkTCPProxyEnabledKey)
+	if proxyEnabled && transportType != transport.TypeTLS {
+		return network.Config{}, fmt.Errorf("%w: %s", errProxyRequiresTLSTransport, transportType)
+	}
+	// NAT traversal is used when the public IP is neither provided nor
+	// resolved. The port mapper only opens a TCP mapping for the staking port,
+	// so it can't be used with a transport that listens on UDP.
+	natTraversalEnabled := v.GetString(PublicIPKey) == "" && v.GetString(PublicIPResolutionServiceKey) == ""
+	if natTraversalEnabled && transportType != transport.TypeTLS {
+		return network.Config{}, fmt.Errorf("%w: %s, provide --%s or --%s", errNATTraversalRequiresTLSTransport, transportType, PublicIPKey, PublicIPResolutionServiceKey)
+	}
+
 	allowPrivateIPs := !constants.ProductionNetworkIDs.Contains(networkID)
 	if v.IsSet(NetworkAllowPrivateIPsKey) {
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
@@ -329,6 +424,25 @@ func getNetworkConfig(
 				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
 				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
 			},
+
+			OutboundMsgQueueConfig: peer.MessageQueueConfig{
+				Consensus: peer.PriorityClassConfig{
+					Weight:   v.GetUint64(OutboundQueueConsensusWeightKey),
+					MaxBytes: v.GetUint64(OutboundQueueConsensusMaxBytesKey),
+				},
+				Bootstrapping: peer.PriorityClassConfig{
+					Weight:   v.GetUint64(OutboundQueueBootstrappingWeightKey),
+					MaxBytes: v.GetUint64(OutboundQueueBootstrappingMaxBytesKey),
+				},
+				App: peer.PriorityClassConfig{
+					Weight:   v.GetUint64(OutboundQueueAppWeightKey),
+					MaxBytes: v.GetUint64(OutboundQueueAppMaxBytesKey),
+				},
+				Gossip: peer.PriorityClassConfig{
+					Weight:   v.GetUint64(OutboundQueueGossipWeightKey),
+					MaxBytes: v.GetUint64(OutboundQueueGossipMaxBytesKey),
+				},
+			},
 		},
 
 		HealthConfig: network.HealthConfig{
@@ -341,7 +455,7 @@ func getNetworkConfig(
 			SendFailRateHalflife:         halflife,
 		},
 
-		ProxyEnabled:           v.GetBool(NetworkTCPProxyEnabledKey),
+		ProxyEnabled:           proxyEnabled,
 		ProxyReadHeaderTimeout: v.GetDuration(NetworkTCPProxyReadTimeoutKey),
 
 		DialerConfig: dialer.Config{
@@ -367,8 +481,16 @@ func getNetworkConfig(
 			InitialReconnectDelay: v.GetDuration(NetworkInitialReconnectDelayKey),
 		},
 
+		PeerScoreConfig: tracker.PeerScoreConfig{
+			Halflife:      v.GetDuration(NetworkPeerScoreHalflifeKey),
+			TargetLatency: v.GetDuration(NetworkPeerScoreTargetLatencyKey),
+		},
+
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionType:              compressionType,
+		OpCompressionTypes:           opCompressionTypes,
+		ZstdDictionaries:             zstdDictionaries,
+		Transport:                    transportType,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              allowPrivateIPs,
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -407,6 +529,10 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkInitialReconnectDelayKey)
 	case config.MaxReconnectDelay < config.InitialReconnectDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %s", NetworkMaxReconnectDelayKey, NetworkInitialReconnectDelayKey)
+	case config.PeerScoreConfig.Halflife <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerScoreHalflifeKey)
+	case config.PeerScoreConfig.TargetLatency <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkPeerScoreTargetLatencyKey)
 	case config.PingPongTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPingTimeoutKey)
 	case config.PingFrequency < 0:
@@ -848,7 +974,10 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:      configBytes,
+		BackupDir:   GetExpandedArg(v, DBBackupDirKey),
+		RestoreDir:  GetExpandedArg(v, DBRestoreDirKey),
+		MigrateFrom: v.GetString(DBMigrateFromKey),
 	}, nil
 }
 
@@ -1094,6 +1223,20 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 	}
 }
 
+// configReader reads the chain and subnet configs from the same flags and
+// directories as when the node started.
+type configReader struct {
+	v *viper.Viper
+}
+
+func (r configReader) ReadChainConfigs() (map[string]chains.ChainConfig, error) {
+	return getChainConfigs(r.v)
+}
+
+func (r configReader) ReadSubnetConfigs(subnetIDs []ids.ID) (map[ids.ID]subnets.Config, error) {
+	return getSubnetConfigs(r.v, subnetIDs)
+}
+
 func getCPUTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 	vdrAlloc := v.GetFloat64(CPUVdrAllocKey)
 	maxNonVdrUsage := v.GetFloat64(CPUMaxNonVdrUsageKey)
@@ -1278,6 +1421,14 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if nodeConfig.HealthCheckFreq < 0 {
 		return node.Config{}, fmt.Errorf("%s must be positive", HealthCheckFreqKey)
 	}
+	nodeConfig.HealthConfig = health.Config{
+		HistorySize:       v.GetInt(HealthCheckHistorySizeKey),
+		FlappingThreshold: v.GetInt(HealthCheckFlappingThresholdKey),
+		FlappingWindow:    v.GetDuration(HealthCheckFlappingWindowKey),
+	}
+	if err := nodeConfig.HealthConfig.Verify(); err != nil {
+		return node.Config{}, fmt.Errorf("invalid health check config: %w", err)
+	}
 	// Halflife of continuous averager used in health checks
 	healthCheckAveragerHalflife := v.GetDuration(HealthCheckAveragerHalflifeKey)
 	if healthCheckAveragerHalflife <= 0 {
@@ -1360,6 +1511,7 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if err != nil {
 		return node.Config{}, fmt.Errorf("couldn't read chain configs: %w", err)
 	}
+	nodeConfig.ConfigReader = configReader{v: v}
 
 	// Profiler
 	nodeConfig.ProfilerConfig, err = getProfilerConfig(v)
@@ -13,10 +13,13 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/pebble"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/compression"
@@ -40,6 +43,7 @@ var (
 	// [defaultUnexpandedDataDir] will be expanded when reading the flags
 	defaultDataDir              = filepath.Join("$HOME", ".avalanchego")
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
+	defaultDBBackupDir          = filepath.Join(defaultUnexpandedDataDir, "db-backups")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
@@ -114,6 +118,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.String(DBBackupDirKey, defaultDBBackupDir, "Directory that database checkpoints created by the admin API are written to")
+	fs.String(DBMigrateFromKey, "", fmt.Sprintf("Database type to migrate the database from before it is opened. Must be one of {%s, %s}. If the database of type %s already exists, the migration is skipped", leveldb.Name, pebble.Name, DBTypeKey))
+	fs.String(DBRestoreDirKey, "", "Path to a database checkpoint to restore before the database is opened. The restore is skipped if the database directory isn't empty. The checkpoint must have been created with the same database type, database version, and network ID")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Avalanche")
@@ -154,6 +161,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s]", compression.TypeZstd, compression.TypeNone))
+	fs.StringToString(NetworkCompressionOpTypesKey, map[string]string{}, fmt.Sprintf("Compression type for outbound messages of specific ops, overriding %s. Keys are op names, such as app_gossip. Values must be one of [%s, %s]", NetworkCompressionTypeKey, compression.TypeZstd, compression.TypeNone))
+	fs.StringToString(NetworkCompressionZstdDictionariesKey, map[string]string{}, "Paths of the zstd dictionaries that outbound messages of specific ops are compressed with, when they are compressed with zstd. Keys are op names, such as app_gossip. Dictionaries must be trained with `zstd --train` and are only used with peers that advertise them in their handshake")
+	fs.String(NetworkTransportKey, transport.TypeTLS.String(), fmt.Sprintf("Transport used to connect to peers. Must be one of [%s, %s]. The %s transport listens on the UDP staking port and can't connect to peers using a different transport, so every peer in the network must use the same transport. NAT traversal and the proxy protocol are only supported by the %s transport, so --%s or --%s must be provided when using %s", transport.TypeTLS, transport.TypeQUIC, transport.TypeQUIC, transport.TypeTLS, PublicIPKey, PublicIPResolutionServiceKey, transport.TypeQUIC))
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -198,10 +208,18 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, constants.DefaultOutboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the outbound message throttler's at-large allocation. Must be at least the max message size")
+	fs.Uint64(OutboundQueueConsensusWeightKey, peer.DefaultMessageQueueConfig.Consensus.Weight, "Share of a peer's outbound bandwidth given to consensus messages while other messages are queued")
+	fs.Uint64(OutboundQueueConsensusMaxBytesKey, peer.DefaultMessageQueueConfig.Consensus.MaxBytes, "Max number of bytes of consensus messages queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Uint64(OutboundQueueBootstrappingWeightKey, peer.DefaultMessageQueueConfig.Bootstrapping.Weight, "Share of a peer's outbound bandwidth given to bootstrapping and state sync messages while other messages are queued")
+	fs.Uint64(OutboundQueueBootstrappingMaxBytesKey, peer.DefaultMessageQueueConfig.Bootstrapping.MaxBytes, "Max number of bytes of bootstrapping and state sync messages queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Uint64(OutboundQueueAppWeightKey, peer.DefaultMessageQueueConfig.App.Weight, "Share of a peer's outbound bandwidth given to app requests and responses while other messages are queued")
+	fs.Uint64(OutboundQueueAppMaxBytesKey, peer.DefaultMessageQueueConfig.App.MaxBytes, "Max number of bytes of app requests and responses queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
+	fs.Uint64(OutboundQueueGossipWeightKey, peer.DefaultMessageQueueConfig.Gossip.Weight, "Share of a peer's outbound bandwidth given to gossip messages while other messages are queued")
+	fs.Uint64(OutboundQueueGossipMaxBytesKey, peer.DefaultMessageQueueConfig.Gossip.MaxBytes, "Max number of bytes of gossip messages queued to be sent to a peer. If 0, only the outbound message throttler limits the queue")
 
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server. If the address is empty or a literal unspecified IP address, the server will bind on all available unicast and anycast IP addresses of the local system")
-	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server. If the port is 0 a port number is automatically chosen")
+	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server. If the port is 0 a port number is automatically chosen. The gRPC, gRPC-Web and Connect services are served under the path of their API, such as /ext/info, so gRPC clients that can't send a path prefix can't reach them")
 	fs.Bool(HTTPSEnabledKey, false, "Upgrade the HTTP server to HTTPs")
 	fs.String(HTTPSKeyFileKey, "", fmt.Sprintf("TLS private key file for the HTTPs server. Ignored if %s is specified", HTTPSKeyContentKey))
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
@@ -209,6 +227,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
+	fs.String(HTTPAuthConfigFileKey, "", fmt.Sprintf("Specifies a JSON file that configures the bearer tokens and TLS client certificates that are required to access each API, and how many requests each client can send. Ignored if %s is specified", HTTPAuthConfigContentKey))
+	fs.String(HTTPAuthConfigContentKey, "", "Specifies base64 encoded JSON that configures the bearer tokens and TLS client certificates that are required to access each API, and how many requests each client can send")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
 	fs.Duration(HTTPReadTimeoutKey, 30*time.Second, "Maximum duration for reading the entire request, including the body. A zero or negative value means there will be no timeout")
@@ -222,10 +242,14 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(KeystoreAPIEnabledKey, false, "If true, this node exposes the Keystore API")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
+	fs.Bool(EventsAPIEnabledKey, false, "If true, this node exposes a websocket API that streams the containers accepted by every chain. Connections to this API can't resume from a cursor")
 
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, constants.DefaultHealthCheckAveragerHalflife, "Halflife of averager when calculating a running average in a health check")
+	fs.Int(HealthCheckHistorySizeKey, health.DefaultConfig.HistorySize, "Maximum number of state transitions kept for each health check")
+	fs.Int(HealthCheckFlappingThresholdKey, health.DefaultConfig.FlappingThreshold, fmt.Sprintf("Health checks are reported as flapping if they change state more than this many times within %s. If 0, health checks are never reported as flapping", HealthCheckFlappingWindowKey))
+	fs.Duration(HealthCheckFlappingWindowKey, health.DefaultConfig.FlappingWindow, "Period of time over which the state transitions of a health check are counted")
 	// Network Layer Health
 	fs.Duration(NetworkHealthMaxTimeSinceMsgSentKey, constants.DefaultNetworkHealthMaxTimeSinceMsgSent, "Network layer returns unhealthy if haven't sent a message for at least this much time")
 	fs.Duration(NetworkHealthMaxTimeSinceMsgReceivedKey, constants.DefaultNetworkHealthMaxTimeSinceMsgReceived, "Network layer returns unhealthy if haven't received a message for at least this much time")
@@ -270,7 +294,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(StakeMintingPeriodKey, genesis.LocalParams.RewardConfig.MintingPeriod, "Consumption period of the staking function")
 	fs.Uint64(StakeSupplyCapKey, genesis.LocalParams.RewardConfig.SupplyCap, "Supply cap of the staking function")
 	// Subnets
-	fs.String(TrackSubnetsKey, "", "List of subnets for the node to track. A node tracking a subnet will track the uptimes of the subnet validators and attempt to sync all the chains in the subnet. Before validating a subnet, a node should be tracking the subnet to avoid impacting their subnet validation uptime")
+	fs.String(TrackSubnetsKey, "", "List of subnets for the node to track. A node tracking a subnet will track the uptimes of the subnet validators and attempt to sync all the chains in the subnet. Before validating a subnet, a node should be tracking the subnet to avoid impacting their subnet validation uptime. Subnets tracked or untracked through the admin API take precedence over this list")
 
 	// State syncing
 	fs.String(StateSyncIPsKey, "", "Comma separated list of state sync peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
@@ -311,6 +335,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Bool(IndexBackfillKey, false, "If true, fill in the missing blocks of incomplete block indices in the background from the accepted history of their chains. Ignored if index is disabled")
+	fs.Bool(IndexAttributesKey, false, "If true, also index the blocks and transactions of the P-Chain and X-Chain by the addresses they send funds or ownership to and by their transaction types. The addresses whose funds they spend aren't indexed. Ignored if index is disabled")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -337,6 +363,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkInitialReconnectDelayKey, constants.DefaultNetworkInitialReconnectDelay, "Initial delay duration must be waited before attempting to reconnect a peer")
 	fs.Duration(NetworkMaxReconnectDelayKey, constants.DefaultNetworkMaxReconnectDelay, "Maximum delay duration must be waited before attempting to reconnect a peer")
 
+	// Peer Scoring
+	fs.Duration(NetworkPeerScoreHalflifeKey, constants.DefaultNetworkPeerScoreHalflife, "Halflife of the observed peer behavior that peer scores are based on. Larger halflife --> peer scores change more slowly")
+	fs.Duration(NetworkPeerScoreTargetLatencyKey, constants.DefaultNetworkPeerScoreTargetLatency, "Average response latency that peers are expected to stay within. Peers with a higher average latency are scored lower")
+
 	// System resource trackers
 	fs.Duration(SystemTrackerFrequencyKey, 500*time.Millisecond, "Frequency to check the real system usage of tracked processes. More frequent checks --> usage metrics are more accurate, but more expensive to track")
 	fs.Duration(SystemTrackerProcessingHalflifeKey, 15*time.Second, "Halflife to use for the processing requests tracker. Larger halflife --> usage metrics change more slowly")
@@ -40,6 +40,9 @@ const (
 	DBPathKey                                          = "db-dir"
 	DBConfigFileKey                                    = "db-config-file"
 	DBConfigContentKey                                 = "db-config-file-content"
+	DBBackupDirKey                                     = "db-backup-dir"
+	DBRestoreDirKey                                    = "db-restore-dir"
+	DBMigrateFromKey                                   = "db-migrate-from"
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
@@ -52,6 +55,8 @@ const (
 	HTTPSCertContentKey                                = "http-tls-cert-file-content"
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPAllowedHostsKey                                = "http-allowed-hosts"
+	HTTPAuthConfigFileKey                              = "http-auth-config-file"
+	HTTPAuthConfigContentKey                           = "http-auth-config-file-content"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
 	HTTPReadTimeoutKey                                 = "http-read-timeout"
@@ -95,6 +100,11 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkCompressionOpTypesKey                       = "network-compression-op-types"
+	NetworkCompressionZstdDictionariesKey              = "network-compression-zstd-dictionaries"
+	NetworkTransportKey                                = "network-transport"
+	NetworkPeerScoreHalflifeKey                        = "network-peer-score-halflife"
+	NetworkPeerScoreTargetLatencyKey                   = "network-peer-score-target-latency"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
@@ -137,6 +147,7 @@ const (
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	EventsAPIEnabledKey                                = "api-events-enabled"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
@@ -145,10 +156,15 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexBackfillKey                                   = "index-backfill"
+	IndexAttributesKey                                 = "index-attributes"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
+	HealthCheckHistorySizeKey                          = "health-check-history-size"
+	HealthCheckFlappingThresholdKey                    = "health-check-flapping-threshold"
+	HealthCheckFlappingWindowKey                       = "health-check-flapping-window"
 	PluginDirKey                                       = "plugin-dir"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
@@ -186,6 +202,14 @@ const (
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
+	OutboundQueueConsensusWeightKey                    = "throttler-outbound-queue-consensus-weight"
+	OutboundQueueConsensusMaxBytesKey                  = "throttler-outbound-queue-consensus-max-bytes"
+	OutboundQueueBootstrappingWeightKey                = "throttler-outbound-queue-bootstrapping-weight"
+	OutboundQueueBootstrappingMaxBytesKey              = "throttler-outbound-queue-bootstrapping-max-bytes"
+	OutboundQueueAppWeightKey                          = "throttler-outbound-queue-app-weight"
+	OutboundQueueAppMaxBytesKey                        = "throttler-outbound-queue-app-max-bytes"
+	OutboundQueueGossipWeightKey                       = "throttler-outbound-queue-gossip-weight"
+	OutboundQueueGossipMaxBytesKey                     = "throttler-outbound-queue-gossip-max-bytes"
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
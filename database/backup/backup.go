@@ -0,0 +1,326 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package backup creates consistent checkpoints of a database while it is
+// being written to and restores them before the database is opened.
+//
+// A checkpoint is a directory with the layout:
+//
+//	[dir]/metadata.json
+//	[dir]/db/...
+//
+// where [dir]/db is a database of the same type as the database that was
+// checkpointed. The metadata file is written last, so a directory without it
+// is an incomplete checkpoint.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	metadataFileName = "metadata.json"
+	dbDirName        = "db"
+	// restoreDirSuffix is appended to the database directory to get the
+	// directory that a checkpoint is copied to while it is restored.
+	restoreDirSuffix = ".restore"
+
+	// writeSize is the size of the batches used to copy the database into a
+	// checkpoint.
+	writeSize = units.MiB
+)
+
+var (
+	ErrUnsupportedDatabase  = errors.New("database type doesn't support checkpoints")
+	ErrCheckpointExists     = errors.New("checkpoint directory already exists")
+	ErrIncompleteCheckpoint = errors.New("checkpoint is missing its metadata")
+	ErrDatabaseExists       = errors.New("database directory isn't empty")
+	ErrVersionMismatch      = errors.New("checkpoint database version mismatch")
+	ErrNetworkIDMismatch    = errors.New("checkpoint network ID mismatch")
+	ErrDatabaseMismatch     = errors.New("checkpoint database type mismatch")
+)
+
+// Metadata describes the database that a checkpoint was created from.
+type Metadata struct {
+	// DatabaseVersion is the version of the database format.
+	DatabaseVersion string `json:"databaseVersion"`
+	// NodeVersion is the version of the node that created the checkpoint.
+	NodeVersion string `json:"nodeVersion"`
+	// NetworkID is the ID of the network the database belongs to.
+	NetworkID uint32 `json:"networkID"`
+	// DatabaseName is the type of the database. e.g. leveldb or pebble.
+	DatabaseName string `json:"databaseName"`
+	// Timestamp is the time the checkpoint was started.
+	Timestamp time.Time `json:"timestamp"`
+	// NumKeys is the number of keys in the checkpoint.
+	NumKeys uint64 `json:"numKeys"`
+}
+
+// Verify returns an error if a database of type [dbName] on [networkID] can't
+// be restored from the checkpoint described by [m].
+func (m *Metadata) Verify(dbName string, networkID uint32) error {
+	switch {
+	case m.DatabaseVersion != version.CurrentDatabase.String():
+		return fmt.Errorf("%w: expected %s but got %s",
+			ErrVersionMismatch,
+			version.CurrentDatabase,
+			m.DatabaseVersion,
+		)
+	case m.NetworkID != networkID:
+		return fmt.Errorf("%w: expected %d but got %d",
+			ErrNetworkIDMismatch,
+			networkID,
+			m.NetworkID,
+		)
+	case m.DatabaseName != dbName:
+		return fmt.Errorf("%w: expected %s but got %s",
+			ErrDatabaseMismatch,
+			dbName,
+			m.DatabaseName,
+		)
+	default:
+		return nil
+	}
+}
+
+// Checkpoint writes a consistent copy of [db] to [dir].
+//
+// [db] may be written to concurrently. The checkpoint contains the state of
+// [db] at the time the checkpoint was started. [dbName] is the type of
+// database to write the checkpoint as and must be either leveldb or pebble.
+//
+// If the checkpoint fails, [dir] is removed.
+func Checkpoint(
+	log logging.Logger,
+	db database.Database,
+	dbName string,
+	networkID uint32,
+	dir string,
+) (*Metadata, error) {
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCheckpointExists, dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	snapshot, err := database.NewSnapshot(db)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
+
+	metadata := &Metadata{
+		DatabaseVersion: version.CurrentDatabase.String(),
+		NodeVersion:     version.Current.String(),
+		NetworkID:       networkID,
+		DatabaseName:    dbName,
+		Timestamp:       time.Now().UTC(),
+	}
+
+	if err := os.MkdirAll(dir, perms.ReadWriteExecute); err != nil {
+		return nil, err
+	}
+	if err := writeCheckpoint(log, snapshot, metadata, dir); err != nil {
+		if removeErr := os.RemoveAll(dir); removeErr != nil {
+			log.Warn("failed to remove incomplete checkpoint",
+				zap.String("path", dir),
+				zap.Error(removeErr),
+			)
+		}
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func writeCheckpoint(
+	log logging.Logger,
+	snapshot database.Snapshot,
+	metadata *Metadata,
+	dir string,
+) error {
+	checkpointDB, err := openDatabase(log, metadata.DatabaseName, filepath.Join(dir, dbDirName))
+	if err != nil {
+		return err
+	}
+
+	numKeys, err := copyDatabase(checkpointDB, snapshot)
+	if err != nil {
+		_ = checkpointDB.Close()
+		return err
+	}
+	if err := checkpointDB.Close(); err != nil {
+		return err
+	}
+
+	metadata.NumKeys = numKeys
+	metadataBytes, err := json.MarshalIndent(metadata, "", "\t")
+	if err != nil {
+		return err
+	}
+	return perms.WriteFile(filepath.Join(dir, metadataFileName), metadataBytes, perms.ReadWrite)
+}
+
+func openDatabase(log logging.Logger, dbName string, path string) (database.Database, error) {
+	switch dbName {
+	case leveldb.Name:
+		return leveldb.New(path, nil, log, "checkpoint", prometheus.NewRegistry())
+	case pebble.Name:
+		return pebble.New(path, nil, log, "checkpoint", prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDatabase, dbName)
+	}
+}
+
+// copyDatabase writes every key-value pair in [src] to [dst] and returns the
+// number of keys that were written.
+func copyDatabase(dst database.Database, src database.Iteratee) (uint64, error) {
+	it := src.NewIterator()
+	defer it.Release()
+
+	var (
+		batch   = dst.NewBatch()
+		numKeys uint64
+	)
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return 0, err
+		}
+		numKeys++
+
+		if batch.Size() < writeSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return 0, err
+		}
+		batch.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	return numKeys, batch.Write()
+}
+
+// ReadMetadata returns the metadata of the checkpoint in [dir].
+func ReadMetadata(dir string) (*Metadata, error) {
+	metadataBytes, err := os.ReadFile(filepath.Join(dir, metadataFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrIncompleteCheckpoint, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, fmt.Errorf("couldn't parse checkpoint metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// Restore copies the checkpoint in [checkpointDir] to [dbDir] after verifying
+// that it was created from a database of type [dbName] on [networkID].
+//
+// [dbDir] must either not exist or be empty. The checkpoint is copied next to
+// [dbDir] and then renamed, so an interrupted restore never leaves a partial
+// database in [dbDir].
+func Restore(checkpointDir string, dbDir string, dbName string, networkID uint32) (*Metadata, error) {
+	entries, err := os.ReadDir(dbDir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	case len(entries) != 0:
+		return nil, fmt.Errorf("%w: %s", ErrDatabaseExists, dbDir)
+	}
+
+	metadata, err := ReadMetadata(checkpointDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := metadata.Verify(dbName, networkID); err != nil {
+		return nil, err
+	}
+
+	// Remove any copy left behind by an interrupted restore.
+	restoreDir := dbDir + restoreDirSuffix
+	if err := os.RemoveAll(restoreDir); err != nil {
+		return nil, err
+	}
+	if err := copyDir(filepath.Join(checkpointDir, dbDirName), restoreDir); err != nil {
+		return nil, fmt.Errorf("couldn't copy checkpoint to %s: %w", restoreDir, err)
+	}
+	if err := os.Remove(dbDir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err := os.Rename(restoreDir, dbDir); err != nil {
+		return nil, fmt.Errorf("couldn't move restored checkpoint to %s: %w", dbDir, err)
+	}
+	return metadata, nil
+}
+
+// copyDir recursively copies the files in [src] to [dst].
+func copyDir(src, dst string) error {
+	if err := os.MkdirAll(dst, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		srcPath := filepath.Join(src, entry.Name())
+		dstPath := filepath.Join(dst, entry.Name())
+		if entry.IsDir() {
+			if err := copyDir(srcPath, dstPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyFile(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := perms.Create(dst, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		_ = dstFile.Close()
+		return err
+	}
+	if err := dstFile.Sync(); err != nil {
+		_ = dstFile.Close()
+		return err
+	}
+	return dstFile.Close()
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const testNetworkID = 12345
+
+func TestCheckpointAndRestore(t *testing.T) {
+	for _, dbName := range []string{leveldb.Name, pebble.Name} {
+		t.Run(dbName, func(t *testing.T) {
+			require := require.New(t)
+
+			log := logging.NoLog{}
+			db, err := openDatabase(log, dbName, t.TempDir())
+			require.NoError(err)
+			defer db.Close()
+
+			for i := 0; i < 1000; i++ {
+				key := []byte(fmt.Sprintf("key%04d", i))
+				require.NoError(db.Put(key, key))
+			}
+
+			checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+			metadata, err := Checkpoint(log, db, dbName, testNetworkID, checkpointDir)
+			require.NoError(err)
+			require.Equal(uint64(1000), metadata.NumKeys)
+			require.Equal(version.CurrentDatabase.String(), metadata.DatabaseVersion)
+
+			// Writes after the checkpoint aren't included.
+			require.NoError(db.Put([]byte("after"), []byte("after")))
+
+			readMetadata, err := ReadMetadata(checkpointDir)
+			require.NoError(err)
+			require.Equal(metadata.NumKeys, readMetadata.NumKeys)
+			require.Equal(metadata.NetworkID, readMetadata.NetworkID)
+
+			dbDir := filepath.Join(t.TempDir(), "restored")
+			_, err = Restore(checkpointDir, dbDir, dbName, testNetworkID)
+			require.NoError(err)
+
+			restoredDB, err := openDatabase(log, dbName, dbDir)
+			require.NoError(err)
+			defer restoredDB.Close()
+
+			for i := 0; i < 1000; i++ {
+				key := []byte(fmt.Sprintf("key%04d", i))
+				value, err := restoredDB.Get(key)
+				require.NoError(err)
+				require.Equal(key, value)
+			}
+			has, err := restoredDB.Has([]byte("after"))
+			require.NoError(err)
+			require.False(has)
+		})
+	}
+}
+
+func TestCheckpointExists(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	_, err := Checkpoint(logging.NoLog{}, memdb.New(), leveldb.Name, testNetworkID, dir)
+	require.ErrorIs(err, ErrCheckpointExists)
+}
+
+func TestCheckpointUnsupportedDatabase(t *testing.T) {
+	require := require.New(t)
+
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	_, err := Checkpoint(logging.NoLog{}, memdb.New(), memdb.Name, testNetworkID, dir)
+	require.ErrorIs(err, ErrUnsupportedDatabase)
+
+	// The incomplete checkpoint is removed.
+	_, err = os.Stat(dir)
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestRestoreIncompleteCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Restore(t.TempDir(), t.TempDir(), leveldb.Name, testNetworkID)
+	require.ErrorIs(err, ErrIncompleteCheckpoint)
+}
+
+func TestRestoreDatabaseExists(t *testing.T) {
+	require := require.New(t)
+
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+	_, err := Checkpoint(logging.NoLog{}, memdb.New(), pebble.Name, testNetworkID, checkpointDir)
+	require.NoError(err)
+
+	dbDir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(dbDir, "file"), nil, 0o600))
+
+	_, err = Restore(checkpointDir, dbDir, pebble.Name, testNetworkID)
+	require.ErrorIs(err, ErrDatabaseExists)
+}
+
+func TestRestoreTwice(t *testing.T) {
+	require := require.New(t)
+
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+	_, err := Checkpoint(logging.NoLog{}, memdb.New(), pebble.Name, testNetworkID, checkpointDir)
+	require.NoError(err)
+
+	// An empty database directory is replaced by the checkpoint.
+	dbDir := t.TempDir()
+	_, err = Restore(checkpointDir, dbDir, pebble.Name, testNetworkID)
+	require.NoError(err)
+
+	_, err = os.Stat(dbDir + restoreDirSuffix)
+	require.ErrorIs(err, os.ErrNotExist)
+
+	// The restored database isn't overwritten, even if the checkpoint was
+	// removed.
+	require.NoError(os.RemoveAll(checkpointDir))
+	_, err = Restore(checkpointDir, dbDir, pebble.Name, testNetworkID)
+	require.ErrorIs(err, ErrDatabaseExists)
+}
+
+func TestMetadataVerify(t *testing.T) {
+	valid := Metadata{
+		DatabaseVersion: version.CurrentDatabase.String(),
+		NetworkID:       testNetworkID,
+		DatabaseName:    leveldb.Name,
+	}
+	tests := []struct {
+		name        string
+		metadata    func() Metadata
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			metadata: func() Metadata {
+				return valid
+			},
+		},
+		{
+			name: "wrong version",
+			metadata: func() Metadata {
+				m := valid
+				m.DatabaseVersion = "v0.0.0"
+				return m
+			},
+			expectedErr: ErrVersionMismatch,
+		},
+		{
+			name: "wrong network ID",
+			metadata: func() Metadata {
+				m := valid
+				m.NetworkID++
+				return m
+			},
+			expectedErr: ErrNetworkIDMismatch,
+		},
+		{
+			name: "wrong database type",
+			metadata: func() Metadata {
+				m := valid
+				m.DatabaseName = pebble.Name
+				return m
+			},
+			expectedErr: ErrDatabaseMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := test.metadata()
+			err := metadata.Verify(leveldb.Name, testNetworkID)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+// Ensure that a memdb can be checkpointed as an on-disk database.
+func TestCheckpointFromMemDB(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+	metadata, err := Checkpoint(logging.NoLog{}, db, leveldb.Name, testNetworkID, checkpointDir)
+	require.NoError(err)
+	require.Equal(uint64(1), metadata.NumKeys)
+
+	restoredDB, err := openDatabase(logging.NoLog{}, leveldb.Name, filepath.Join(checkpointDir, dbDirName))
+	require.NoError(err)
+	defer restoredDB.Close()
+
+	value, err := restoredDB.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
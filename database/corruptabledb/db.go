@@ -9,11 +9,18 @@ import (
 	"sync"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
+// deleteRangeWriteSize is the size of the batches used to delete a range if
+// the underlying database doesn't support range deletions.
+const deleteRangeWriteSize = units.MiB
+
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -67,6 +74,22 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// DeleteRange removes every key in the range [start, limit)
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(database.DeleteRange(db.Database, start, limit, deleteRangeWriteSize))
+}
+
+// NewSnapshot returns a snapshot of the underlying database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	return database.NewSnapshot(db.Database)
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
@@ -84,6 +84,41 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// RangeDeleter wraps the DeleteRange method of a backing data store.
+type RangeDeleter interface {
+	// DeleteRange removes every key in the range [start, limit) from the DB.
+	//
+	// A nil start is treated as a key before all keys in the DB.
+	// And a nil limit is treated as a key after all keys in the DB.
+	// Therefore if both are nil then every key in the DB is removed.
+	//
+	// Note: [start] and [limit] are safe to modify and read after calling
+	// DeleteRange.
+	DeleteRange(start []byte, limit []byte) error
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a read-only view of the DB as of the time it was
+	// called. Modifications made to the DB after NewSnapshot returns aren't
+	// visible through the snapshot.
+	NewSnapshot() (Snapshot, error)
+}
+
+// Snapshot is a consistent, read-only view of a backing data store at a point
+// in time.
+//
+// A snapshot must be released after use. Reads after Release, or after the
+// backing data store is closed, return [ErrClosed].
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed
+	// and can be called multiple times without causing error.
+	Release()
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
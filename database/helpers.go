@@ -4,9 +4,11 @@
 package database
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -235,3 +237,68 @@ func ClearPrefix(db Database, prefix []byte, writeSize int) error {
 	}
 	return it.Error()
 }
+
+// DeleteRange removes all keys in the range [start, limit) from [db].
+//
+// If [db] implements [RangeDeleter], the range is deleted natively. Otherwise,
+// the keys are iterated over and deleted in batches that are written when they
+// reach [writeSize].
+func DeleteRange(db Database, start, limit []byte, writeSize int) error {
+	if rangeDeleter, ok := db.(RangeDeleter); ok {
+		return rangeDeleter.DeleteRange(start, limit)
+	}
+
+	b := db.NewBatch()
+	it := db.NewIteratorWithStart(start)
+	// Defer the release of the iterator inside a closure to guarantee that the
+	// latest, not the first, iterator is released on return.
+	defer func() {
+		it.Release()
+	}()
+
+	for it.Next() {
+		key := it.Key()
+		if limit != nil && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+
+		// Avoid too much memory pressure by periodically writing to the
+		// database.
+		if b.Size() < writeSize {
+			continue
+		}
+
+		// The iterator must be restarted after the last deleted key.
+		start = slices.Clone(key)
+		if err := b.Write(); err != nil {
+			return err
+		}
+		b.Reset()
+
+		// Reset the iterator to release references to now deleted keys.
+		if err := it.Error(); err != nil {
+			return err
+		}
+		it.Release()
+		it = db.NewIteratorWithStart(start)
+	}
+
+	if err := b.Write(); err != nil {
+		return err
+	}
+	return it.Error()
+}
+
+// NewSnapshot returns a snapshot of [db].
+//
+// Returns [ErrSnapshotNotSupported] if [db] doesn't implement [Snapshotter].
+func NewSnapshot(db Database) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
@@ -5,6 +5,7 @@ package memdb
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -21,9 +22,12 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -108,6 +112,38 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	startString := string(start)
+	limitString := string(limit)
+	for key := range db.db {
+		if key >= startString && (limit == nil || key < limitString) {
+			delete(db.db, key)
+		}
+	}
+	return nil
+}
+
+// NewSnapshot returns a copy of the current contents of the database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	return &snapshot{
+		db:       db,
+		snapshot: &Database{db: maps.Clone(db.db)},
+	}, nil
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -199,6 +235,52 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// snapshot is a copy of a database. Reads fail once either the snapshot is
+// released or the original database is closed.
+type snapshot struct {
+	db       *Database
+	snapshot *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	return s.snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	return s.snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return s.snapshot.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+func (s *snapshot) Release() {
+	_ = s.snapshot.Close()
+}
+
 type iterator struct {
 	db          *Database
 	initialized bool
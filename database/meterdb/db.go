@@ -10,12 +10,19 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
+// deleteRangeWriteSize is the size of the batches used to delete a range if
+// the underlying database doesn't support range deletions.
+const deleteRangeWriteSize = units.MiB
+
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
@@ -116,6 +123,22 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	startTime := db.clock.Time()
+	err := database.DeleteRange(db.db, start, limit, deleteRangeWriteSize)
+	end := db.clock.Time()
+	db.deleteRange.Observe(float64(end.Sub(startTime)))
+	return err
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snapshot, err := database.NewSnapshot(db.db)
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	return snapshot, err
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := db.clock.Time()
 	err := db.db.Compact(start, limit)
@@ -39,8 +39,10 @@ type metrics struct {
 	get, getSize,
 	put, putSize,
 	delete, deleteSize,
+	deleteRange,
 	newBatch,
 	newIterator,
+	newSnapshot,
 	compact,
 	close,
 	healthCheck,
@@ -71,8 +73,10 @@ func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
 		putSize:     newSizeMetric(namespace, "put", reg, &errs),
 		delete:      newTimeMetric(namespace, "delete", reg, &errs),
 		deleteSize:  newSizeMetric(namespace, "delete", reg, &errs),
+		deleteRange: newTimeMetric(namespace, "delete_range", reg, &errs),
 		newBatch:    newTimeMetric(namespace, "new_batch", reg, &errs),
 		newIterator: newTimeMetric(namespace, "new_iterator", reg, &errs),
+		newSnapshot: newTimeMetric(namespace, "new_snapshot", reg, &errs),
 		compact:     newTimeMetric(namespace, "compact", reg, &errs),
 		close:       newTimeMetric(namespace, "close", reg, &errs),
 		healthCheck: newTimeMetric(namespace, "health_check", reg, &errs),
@@ -0,0 +1,399 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migration copies a database from one on-disk backend to another.
+//
+// The migration is written to a temporary directory next to the target
+// directory. Progress is committed atomically with every batch of copied keys,
+// so an interrupted migration resumes from the last written batch. Once every
+// key has been copied, the number of keys and a checksum of the copied entries
+// are verified against the target before the temporary directory is renamed
+// to the target directory. The source directory is never modified.
+package migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// tempDirSuffix is appended to the target directory to get the directory that
+// the migration is written to.
+const tempDirSuffix = ".migrating"
+
+var (
+	// progressKey is the key in the temporary database that the migration
+	// progress is stored under. It is removed before the migration completes.
+	progressKey = []byte("\x00avalanchego/database/migration/progress")
+
+	ErrUnsupportedDatabase = errors.New("database type can't be migrated")
+	ErrSameDatabase        = errors.New("source and target databases are the same type")
+	ErrSourceNotFound      = errors.New("source database doesn't exist")
+	ErrReservedKey         = errors.New("source database contains the reserved migration key")
+	ErrCountMismatch       = errors.New("migrated key count mismatch")
+	ErrChecksumMismatch    = errors.New("migrated checksum mismatch")
+
+	errInvalidBatchSize = errors.New("batch size must be positive")
+	errInvalidProgress  = errors.New("invalid migration progress")
+)
+
+type Config struct {
+	Log logging.Logger
+
+	// SourceName is the type of the database being migrated from.
+	SourceName string
+	// SourcePath is the directory of the database being migrated from.
+	SourcePath string
+	// TargetName is the type of the database being migrated to.
+	TargetName string
+	// TargetPath is the directory of the database being migrated to.
+	TargetPath string
+
+	// BatchSize is the number of bytes to write to the target in each batch.
+	BatchSize int
+	// ProgressFrequency is the frequency that progress is logged at.
+	ProgressFrequency time.Duration
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.SourceName == c.TargetName:
+		return fmt.Errorf("%w: %s", ErrSameDatabase, c.SourceName)
+	case !isSupported(c.SourceName):
+		return fmt.Errorf("%w: %s", ErrUnsupportedDatabase, c.SourceName)
+	case !isSupported(c.TargetName):
+		return fmt.Errorf("%w: %s", ErrUnsupportedDatabase, c.TargetName)
+	case c.BatchSize <= 0:
+		return errInvalidBatchSize
+	default:
+		return nil
+	}
+}
+
+// Migrate copies the database at [config.SourcePath] to [config.TargetPath].
+//
+// If [config.TargetPath] already exists and isn't empty, the database is
+// assumed to have already been migrated and nothing is copied.
+func Migrate(ctx context.Context, config Config) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	migrated, err := isNonEmptyDir(config.TargetPath)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		config.Log.Info("skipping database migration",
+			zap.String("reason", "target database already exists"),
+			zap.String("path", config.TargetPath),
+		)
+		return nil
+	}
+
+	sourceExists, err := isNonEmptyDir(config.SourcePath)
+	if err != nil {
+		return err
+	}
+	if !sourceExists {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, config.SourcePath)
+	}
+
+	tempPath := config.TargetPath + tempDirSuffix
+	if err := migrate(ctx, config, tempPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, config.TargetPath); err != nil {
+		return fmt.Errorf("couldn't move migrated database to %s: %w", config.TargetPath, err)
+	}
+
+	config.Log.Info("finished database migration",
+		zap.String("source", config.SourcePath),
+		zap.String("target", config.TargetPath),
+	)
+	return nil
+}
+
+func migrate(ctx context.Context, config Config, tempPath string) error {
+	source, err := openDatabase(config.Log, config.SourceName, config.SourcePath)
+	if err != nil {
+		return fmt.Errorf("couldn't open source database: %w", err)
+	}
+	defer source.Close()
+
+	target, err := openDatabase(config.Log, config.TargetName, tempPath)
+	if err != nil {
+		return fmt.Errorf("couldn't open target database: %w", err)
+	}
+
+	err = migrateDatabase(ctx, config, source, target)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func migrateDatabase(
+	ctx context.Context,
+	config Config,
+	source database.Database,
+	target database.Database,
+) error {
+	p, err := getProgress(target)
+	if err != nil {
+		return err
+	}
+
+	if !p.done {
+		if p.numKeys != 0 {
+			config.Log.Info("resuming database migration",
+				zap.Uint64("numKeys", p.numKeys),
+			)
+		} else {
+			config.Log.Info("starting database migration",
+				zap.String("source", config.SourcePath),
+				zap.String("target", config.TargetPath),
+			)
+		}
+		if err := copyKeys(ctx, config, source, target, p); err != nil {
+			return err
+		}
+	}
+
+	numKeys, checksum, err := computeChecksum(ctx, target)
+	if err != nil {
+		return err
+	}
+	if numKeys != p.numKeys {
+		return fmt.Errorf("%w: expected %d but got %d",
+			ErrCountMismatch,
+			p.numKeys,
+			numKeys,
+		)
+	}
+	if checksum != p.checksum {
+		return fmt.Errorf("%w: expected %x but got %x",
+			ErrChecksumMismatch,
+			p.checksum,
+			checksum,
+		)
+	}
+
+	config.Log.Info("verified migrated database",
+		zap.Uint64("numKeys", numKeys),
+		zap.Binary("checksum", checksum[:]),
+	)
+	return target.Delete(progressKey)
+}
+
+// copyKeys copies every key in [source] after [p.lastKey] into [target],
+// committing [p] with every batch.
+func copyKeys(
+	ctx context.Context,
+	config Config,
+	source database.Database,
+	target database.Database,
+	p *progress,
+) error {
+	var start []byte
+	if p.numKeys != 0 {
+		// Start after the last copied key.
+		start = append(bytes.Clone(p.lastKey), 0)
+	}
+	it := source.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var (
+		batch       = target.NewBatch()
+		startTime   = time.Now()
+		startKeys   = p.numKeys
+		lastLogTime = startTime
+	)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		key := it.Key()
+		if bytes.Equal(key, progressKey) {
+			return ErrReservedKey
+		}
+		value := it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		p.add(key, value)
+
+		if batch.Size() < config.BatchSize {
+			continue
+		}
+		if err := writeBatch(batch, p); err != nil {
+			return err
+		}
+
+		if now := time.Now(); now.Sub(lastLogTime) >= config.ProgressFrequency {
+			lastLogTime = now
+			config.Log.Info("migrating database",
+				zap.Uint64("numKeys", p.numKeys),
+				zap.Binary("lastKey", p.lastKey),
+				zap.Float64("keysPerSecond", float64(p.numKeys-startKeys)/now.Sub(startTime).Seconds()),
+			)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	p.done = true
+	if err := writeBatch(batch, p); err != nil {
+		return err
+	}
+
+	config.Log.Info("copied database",
+		zap.Uint64("numKeys", p.numKeys),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// writeBatch atomically writes [batch] and [p] and resets [batch].
+func writeBatch(batch database.Batch, p *progress) error {
+	if err := batch.Put(progressKey, p.bytes()); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	return nil
+}
+
+// computeChecksum returns the number of keys in [db] and their checksum,
+// excluding the progress key.
+func computeChecksum(ctx context.Context, db database.Iteratee) (uint64, [sha256.Size]byte, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	p := &progress{}
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return 0, [sha256.Size]byte{}, err
+		}
+
+		key := it.Key()
+		if bytes.Equal(key, progressKey) {
+			continue
+		}
+		p.add(key, it.Value())
+	}
+	return p.numKeys, p.checksum, it.Error()
+}
+
+// progress of a migration.
+//
+// The checksum is the XOR of the hash of every copied entry, which allows it
+// to be updated as keys are copied and compared against a checksum computed
+// in a different order.
+type progress struct {
+	done     bool
+	numKeys  uint64
+	checksum [sha256.Size]byte
+	lastKey  []byte
+}
+
+func getProgress(db database.KeyValueReader) (*progress, error) {
+	progressBytes, err := db.Get(progressKey)
+	if err == database.ErrNotFound {
+		return &progress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseProgress(progressBytes)
+}
+
+func (p *progress) add(key, value []byte) {
+	var length [wrappers.LongLen]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(key)))
+
+	h := sha256.New()
+	_, _ = h.Write(length[:])
+	_, _ = h.Write(key)
+	_, _ = h.Write(value)
+
+	var entryHash [sha256.Size]byte
+	h.Sum(entryHash[:0])
+	for i := range p.checksum {
+		p.checksum[i] ^= entryHash[i]
+	}
+
+	p.numKeys++
+	p.lastKey = append(p.lastKey[:0], key...)
+}
+
+// bytes returns the serialized progress:
+//
+//	done (1 byte) || numKeys (8 bytes) || checksum (32 bytes) || lastKey
+func (p *progress) bytes() []byte {
+	b := make([]byte, wrappers.BoolLen+wrappers.LongLen+sha256.Size, wrappers.BoolLen+wrappers.LongLen+sha256.Size+len(p.lastKey))
+	if p.done {
+		b[0] = 1
+	}
+	binary.BigEndian.PutUint64(b[wrappers.BoolLen:], p.numKeys)
+	copy(b[wrappers.BoolLen+wrappers.LongLen:], p.checksum[:])
+	return append(b, p.lastKey...)
+}
+
+func parseProgress(b []byte) (*progress, error) {
+	if len(b) < wrappers.BoolLen+wrappers.LongLen+sha256.Size || b[0] > 1 {
+		return nil, errInvalidProgress
+	}
+	p := &progress{
+		done:    b[0] == 1,
+		numKeys: binary.BigEndian.Uint64(b[wrappers.BoolLen:]),
+		lastKey: bytes.Clone(b[wrappers.BoolLen+wrappers.LongLen+sha256.Size:]),
+	}
+	copy(p.checksum[:], b[wrappers.BoolLen+wrappers.LongLen:])
+	return p, nil
+}
+
+func isSupported(dbName string) bool {
+	return dbName == leveldb.Name || dbName == pebble.Name
+}
+
+func openDatabase(log logging.Logger, dbName string, path string) (database.Database, error) {
+	switch dbName {
+	case leveldb.Name:
+		return leveldb.New(path, nil, log, "migration", prometheus.NewRegistry())
+	case pebble.Name:
+		return pebble.New(path, nil, log, "migration", prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDatabase, dbName)
+	}
+}
+
+func isNonEmptyDir(path string) (bool, error) {
+	entries, err := os.ReadDir(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(entries) != 0, nil
+}
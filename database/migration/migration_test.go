@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/pebble"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const numTestKeys = 1000
+
+func testKey(i int) []byte {
+	return []byte(fmt.Sprintf("key%04d", i))
+}
+
+func newTestConfig(t *testing.T, sourceName, targetName string) Config {
+	dir := t.TempDir()
+	return Config{
+		Log:               logging.NoLog{},
+		SourceName:        sourceName,
+		SourcePath:        filepath.Join(dir, sourceName),
+		TargetName:        targetName,
+		TargetPath:        filepath.Join(dir, targetName),
+		BatchSize:         1024,
+		ProgressFrequency: time.Millisecond,
+	}
+}
+
+// writeSource populates the source database of [config] with [numTestKeys]
+// keys.
+func writeSource(t *testing.T, config Config) {
+	require := require.New(t)
+
+	db, err := openDatabase(config.Log, config.SourceName, config.SourcePath)
+	require.NoError(err)
+	for i := 0; i < numTestKeys; i++ {
+		require.NoError(db.Put(testKey(i), testKey(i)))
+	}
+	require.NoError(db.Close())
+}
+
+func requireMigrated(t *testing.T, config Config) {
+	require := require.New(t)
+
+	db, err := openDatabase(config.Log, config.TargetName, config.TargetPath)
+	require.NoError(err)
+	defer db.Close()
+
+	numKeys, _, err := computeChecksum(context.Background(), db)
+	require.NoError(err)
+	require.Equal(uint64(numTestKeys), numKeys)
+
+	for i := 0; i < numTestKeys; i++ {
+		value, err := db.Get(testKey(i))
+		require.NoError(err)
+		require.Equal(testKey(i), value)
+	}
+
+	has, err := db.Has(progressKey)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = os.Stat(config.TargetPath + tempDirSuffix)
+	require.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		sourceName string
+		targetName string
+	}{
+		{
+			sourceName: leveldb.Name,
+			targetName: pebble.Name,
+		},
+		{
+			sourceName: pebble.Name,
+			targetName: leveldb.Name,
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s to %s", test.sourceName, test.targetName), func(t *testing.T) {
+			require := require.New(t)
+
+			config := newTestConfig(t, test.sourceName, test.targetName)
+			writeSource(t, config)
+
+			require.NoError(Migrate(context.Background(), config))
+			requireMigrated(t, config)
+
+			// Migrating again is a no-op.
+			require.NoError(Migrate(context.Background(), config))
+			requireMigrated(t, config)
+		})
+	}
+}
+
+// Tests that an interrupted migration resumes from the last written batch.
+func TestMigrateResume(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(t, leveldb.Name, pebble.Name)
+	writeSource(t, config)
+
+	// Copy the first half of the keys.
+	source, err := openDatabase(config.Log, config.SourceName, config.SourcePath)
+	require.NoError(err)
+	target, err := openDatabase(config.Log, config.TargetName, config.TargetPath+tempDirSuffix)
+	require.NoError(err)
+
+	p := &progress{}
+	batch := target.NewBatch()
+	for i := 0; i < numTestKeys/2; i++ {
+		require.NoError(batch.Put(testKey(i), testKey(i)))
+		p.add(testKey(i), testKey(i))
+	}
+	require.NoError(writeBatch(batch, p))
+	require.NoError(target.Close())
+	require.NoError(source.Close())
+
+	require.NoError(Migrate(context.Background(), config))
+	requireMigrated(t, config)
+}
+
+func TestMigrateCancelled(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(t, pebble.Name, leveldb.Name)
+	writeSource(t, config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Migrate(ctx, config)
+	require.ErrorIs(err, context.Canceled)
+
+	// The target isn't created until the migration completes.
+	_, err = os.Stat(config.TargetPath)
+	require.ErrorIs(err, os.ErrNotExist)
+
+	require.NoError(Migrate(context.Background(), config))
+	requireMigrated(t, config)
+}
+
+func TestMigrateVerification(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(database.Database) error
+		expectedErr error
+	}{
+		{
+			name: "extra key",
+			modify: func(db database.Database) error {
+				return db.Put([]byte("extra"), nil)
+			},
+			expectedErr: ErrCountMismatch,
+		},
+		{
+			name: "modified value",
+			modify: func(db database.Database) error {
+				return db.Put(testKey(0), []byte("modified"))
+			},
+			expectedErr: ErrChecksumMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := newTestConfig(t, leveldb.Name, pebble.Name)
+			source := memdb.New()
+			for i := 0; i < numTestKeys; i++ {
+				require.NoError(source.Put(testKey(i), testKey(i)))
+			}
+
+			target := memdb.New()
+			p := &progress{}
+			require.NoError(copyKeys(context.Background(), config, source, target, p))
+			require.NoError(test.modify(target))
+
+			err := migrateDatabase(context.Background(), config, source, target)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestMigrateSourceNotFound(t *testing.T) {
+	config := newTestConfig(t, leveldb.Name, pebble.Name)
+	err := Migrate(context.Background(), config)
+	require.ErrorIs(t, err, ErrSourceNotFound)
+}
+
+func TestMigrateReservedKey(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig(t, leveldb.Name, pebble.Name)
+	source := memdb.New()
+	require.NoError(source.Put(progressKey, nil))
+
+	err := copyKeys(context.Background(), config, source, memdb.New(), &progress{})
+	require.ErrorIs(err, ErrReservedKey)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		sourceName  string
+		targetName  string
+		batchSize   int
+		expectedErr error
+	}{
+		{
+			name:       "valid",
+			sourceName: leveldb.Name,
+			targetName: pebble.Name,
+			batchSize:  1,
+		},
+		{
+			name:        "same database",
+			sourceName:  pebble.Name,
+			targetName:  pebble.Name,
+			batchSize:   1,
+			expectedErr: ErrSameDatabase,
+		},
+		{
+			name:        "unsupported source",
+			sourceName:  memdb.Name,
+			targetName:  pebble.Name,
+			batchSize:   1,
+			expectedErr: ErrUnsupportedDatabase,
+		},
+		{
+			name:        "unsupported target",
+			sourceName:  leveldb.Name,
+			targetName:  memdb.Name,
+			batchSize:   1,
+			expectedErr: ErrUnsupportedDatabase,
+		},
+		{
+			name:        "invalid batch size",
+			sourceName:  leveldb.Name,
+			targetName:  pebble.Name,
+			expectedErr: errInvalidBatchSize,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Config{
+				SourceName: test.sourceName,
+				TargetName: test.targetName,
+				BatchSize:  test.batchSize,
+			}
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestProgressSerialization(t *testing.T) {
+	require := require.New(t)
+
+	p := &progress{}
+	p.add([]byte("key"), []byte("value"))
+	p.done = true
+
+	parsed, err := parseProgress(p.bytes())
+	require.NoError(err)
+	require.Equal(p, parsed)
+
+	_, err = parseProgress(nil)
+	require.ErrorIs(err, errInvalidProgress)
+}
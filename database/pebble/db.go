@@ -30,7 +30,9 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
 
 	errInvalidOperation = errors.New("invalid operation")
 
@@ -61,6 +63,7 @@ type Database struct {
 	pebbleDB      *pebble.DB
 	closed        bool
 	openIterators set.Set[*iter]
+	openSnapshots set.Set[*snapshot]
 }
 
 type Config struct {
@@ -103,6 +106,7 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 	return &Database{
 		pebbleDB:      db,
 		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
 	}, err
 }
 
@@ -123,6 +127,11 @@ func (db *Database) Close() error {
 	}
 	db.openIterators.Clear()
 
+	for snapshot := range db.openSnapshots {
+		snapshot.release()
+	}
+	db.openSnapshots.Clear()
+
 	return updateError(db.pebbleDB.Close())
 }
 
@@ -191,6 +200,43 @@ func (db *Database) Delete(key []byte) error {
 	return updateError(db.pebbleDB.Delete(key, pebble.Sync))
 }
 
+func (db *Database) DeleteRange(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	batch := db.pebbleDB.NewBatch()
+	if limit == nil {
+		// The database.Database spec treats a nil [limit] as a key after all
+		// keys but pebble has no such key. Delete up to the greatest key in the
+		// database and then delete the greatest key.
+		it := db.pebbleDB.NewIter(&pebble.IterOptions{LowerBound: start})
+		if !it.Last() {
+			// There are no keys to delete.
+			return it.Close()
+		}
+
+		limit = slices.Clone(it.Key())
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if err := batch.Delete(limit, nil); err != nil {
+			return err
+		}
+	}
+
+	if pebble.DefaultComparer.Compare(start, limit) < 0 {
+		// pebble requires [start] < [limit]
+		if err := batch.DeleteRange(start, limit, nil); err != nil {
+			return err
+		}
+	}
+	return updateError(batch.Commit(pebble.Sync))
+}
+
 func (db *Database) Compact(start []byte, end []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
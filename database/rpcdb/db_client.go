@@ -18,9 +18,12 @@ import (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*DatabaseClient)(nil)
+	_ database.RangeDeleter = (*DatabaseClient)(nil)
+	_ database.Snapshotter  = (*DatabaseClient)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -80,6 +83,39 @@ func (db *DatabaseClient) Delete(key []byte) error {
 	return ErrEnumToError[resp.Err]
 }
 
+// DeleteRange attempts to remove every key in the range [start, limit)
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	if limit != nil && len(limit) == 0 {
+		// Every key is >= the empty key, so the range is empty. This is
+		// handled locally because an empty limit is sent as a nil limit.
+		return nil
+	}
+
+	resp, err := db.client.DeleteRange(context.Background(), &rpcdbpb.DeleteRangeRequest{
+		Start: start,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+	return ErrEnumToError[resp.Err]
+}
+
+// NewSnapshot attempts to create a snapshot of the remote database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbpb.NewSnapshotRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if err := ErrEnumToError[resp.Err]; err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // NewBatch returns a new batch
 func (db *DatabaseClient) NewBatch() database.Batch {
 	return &batch{db: db}
@@ -182,6 +218,73 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+type snapshot struct {
+	db   *DatabaseClient
+	id   uint64
+	once sync.Once
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbpb.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Has, ErrEnumToError[resp.Err]
+}
+
+// Get attempts to return the value that was mapped to the key that was
+// provided
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbpb.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, ErrEnumToError[resp.Err]
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbpb.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return newIterator(s.db, resp.Id)
+}
+
+// Release frees the resources held by the snapshot on the server
+func (s *snapshot) Release() {
+	s.once.Do(func() {
+		_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbpb.SnapshotReleaseRequest{
+			Id: s.id,
+		})
+	})
+}
+
 type iterator struct {
 	db *DatabaseClient
 	id uint64
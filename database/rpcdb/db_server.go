@@ -17,7 +17,13 @@ import (
 	rpcdbpb "github.com/ava-labs/avalanchego/proto/pb/rpcdb"
 )
 
-const iterationBatchSize = 128 * units.KiB
+const (
+	iterationBatchSize = 128 * units.KiB
+
+	// deleteRangeWriteSize is the size of the batches used to delete a range
+	// if the managed database doesn't support range deletions.
+	deleteRangeWriteSize = units.MiB
+)
 
 var errUnknownIterator = errors.New("unknown iterator")
 
@@ -35,6 +41,12 @@ type DatabaseServer struct {
 	iteratorLock   sync.RWMutex
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// snapshotLock protects [nextSnapshotID] and [snapshots] from concurrent
+	// modifications.
+	snapshotLock   sync.RWMutex
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -42,6 +54,7 @@ func NewServer(db database.Database) *DatabaseServer {
 	return &DatabaseServer{
 		db:        db,
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -76,6 +89,18 @@ func (db *DatabaseServer) Delete(_ context.Context, req *rpcdbpb.DeleteRequest)
 	return &rpcdbpb.DeleteResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
 }
 
+// DeleteRange delegates the DeleteRange call to the managed database and
+// returns the result
+func (db *DatabaseServer) DeleteRange(_ context.Context, req *rpcdbpb.DeleteRangeRequest) (*rpcdbpb.DeleteRangeResponse, error) {
+	// The client never sends an empty, non-nil, limit.
+	var limit []byte
+	if len(req.Limit) > 0 {
+		limit = req.Limit
+	}
+	err := database.DeleteRange(db.db, req.Start, limit, deleteRangeWriteSize)
+	return &rpcdbpb.DeleteRangeResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
+}
+
 // Compact delegates the Compact call to the managed database and returns the
 // result
 func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbpb.CompactRequest) (*rpcdbpb.CompactResponse, error) {
@@ -131,14 +156,20 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchR
 // ID
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.NewIteratorWithStartAndPrefixRequest) (*rpcdbpb.NewIteratorWithStartAndPrefixResponse, error) {
 	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{
+		Id: db.addIterator(it),
+	}, nil
+}
 
+// addIterator stores [it] and returns its ID
+func (db *DatabaseServer) addIterator(it database.Iterator) uint64 {
 	db.iteratorLock.Lock()
 	defer db.iteratorLock.Unlock()
 
 	id := db.nextIteratorID
 	db.iterators[id] = it
 	db.nextIteratorID++
-	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+	return id
 }
 
 // IteratorNext attempts to call next on the requested iterator
@@ -195,3 +226,109 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	it.Release()
 	return &rpcdbpb.IteratorReleaseResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
 }
+
+// NewSnapshot allocates a snapshot of the managed database and returns the
+// snapshot ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbpb.NewSnapshotRequest) (*rpcdbpb.NewSnapshotResponse, error) {
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return &rpcdbpb.NewSnapshotResponse{
+			Err: ErrorToErrEnum[err],
+		}, ErrorToRPCError(err)
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+	db.nextSnapshotID++
+	return &rpcdbpb.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbpb.SnapshotHasRequest) (*rpcdbpb.HasResponse, error) {
+	has, err := db.getSnapshot(req.Id).Has(req.Key)
+	return &rpcdbpb.HasResponse{
+		Has: has,
+		Err: ErrorToErrEnum[err],
+	}, ErrorToRPCError(err)
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbpb.SnapshotGetRequest) (*rpcdbpb.GetResponse, error) {
+	value, err := db.getSnapshot(req.Id).Get(req.Key)
+	return &rpcdbpb.GetResponse{
+		Value: value,
+		Err:   ErrorToErrEnum[err],
+	}, ErrorToRPCError(err)
+}
+
+// SnapshotNewIteratorWithStartAndPrefix allocates an iterator over the
+// requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.SnapshotNewIteratorWithStartAndPrefixRequest) (*rpcdbpb.NewIteratorWithStartAndPrefixResponse, error) {
+	it := db.getSnapshot(req.Id).NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	return &rpcdbpb.NewIteratorWithStartAndPrefixResponse{
+		Id: db.addIterator(it),
+	}, nil
+}
+
+// SnapshotRelease releases the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbpb.SnapshotReleaseRequest) (*rpcdbpb.SnapshotReleaseResponse, error) {
+	db.snapshotLock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	delete(db.snapshots, req.Id)
+	db.snapshotLock.Unlock()
+
+	if exists {
+		snapshot.Release()
+	}
+	return &rpcdbpb.SnapshotReleaseResponse{}, nil
+}
+
+// getSnapshot returns the snapshot with the provided ID. If the snapshot
+// doesn't exist, it has been released, so a snapshot that reports
+// [database.ErrClosed] is returned.
+func (db *DatabaseServer) getSnapshot(id uint64) database.Snapshot {
+	db.snapshotLock.RLock()
+	defer db.snapshotLock.RUnlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return releasedSnapshot{}
+	}
+	return snapshot
+}
+
+// releasedSnapshot is a snapshot that has already been released.
+type releasedSnapshot struct{}
+
+func (releasedSnapshot) Has([]byte) (bool, error) {
+	return false, database.ErrClosed
+}
+
+func (releasedSnapshot) Get([]byte) ([]byte, error) {
+	return nil, database.ErrClosed
+}
+
+func (s releasedSnapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s releasedSnapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s releasedSnapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (releasedSnapshot) NewIteratorWithStartAndPrefix([]byte, []byte) database.Iterator {
+	return &database.IteratorError{
+		Err: database.ErrClosed,
+	}
+}
+
+func (releasedSnapshot) Release() {}
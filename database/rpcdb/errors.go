@@ -11,12 +11,14 @@ import (
 
 var (
 	ErrEnumToError = map[rpcdbpb.Error]error{
-		rpcdbpb.Error_ERROR_CLOSED:    database.ErrClosed,
-		rpcdbpb.Error_ERROR_NOT_FOUND: database.ErrNotFound,
+		rpcdbpb.Error_ERROR_CLOSED:                 database.ErrClosed,
+		rpcdbpb.Error_ERROR_NOT_FOUND:              database.ErrNotFound,
+		rpcdbpb.Error_ERROR_SNAPSHOT_NOT_SUPPORTED: database.ErrSnapshotNotSupported,
 	}
 	ErrorToErrEnum = map[error]rpcdbpb.Error{
-		database.ErrClosed:   rpcdbpb.Error_ERROR_CLOSED,
-		database.ErrNotFound: rpcdbpb.Error_ERROR_NOT_FOUND,
+		database.ErrClosed:               rpcdbpb.Error_ERROR_CLOSED,
+		database.ErrNotFound:             rpcdbpb.Error_ERROR_NOT_FOUND,
+		database.ErrSnapshotNotSupported: rpcdbpb.Error_ERROR_SNAPSHOT_NOT_SUPPORTED,
 	}
 )
 
@@ -53,6 +53,10 @@ var Tests = map[string]func(t *testing.T, db Database){
 	"Clear":                            TestClear,
 	"AtomicClearPrefix":                TestAtomicClearPrefix,
 	"ClearPrefix":                      TestClearPrefix,
+	"DeleteRange":                      TestDeleteRange,
+	"DeleteRangeBatched":               TestDeleteRangeBatched,
+	"Snapshot":                         TestSnapshot,
+	"SnapshotRelease":                  TestSnapshotRelease,
 	"ModifyValueAfterPut":              TestModifyValueAfterPut,
 	"ModifyValueAfterBatchPut":         TestModifyValueAfterBatchPut,
 	"ModifyValueAfterBatchPutReplay":   TestModifyValueAfterBatchPutReplay,
@@ -992,6 +996,176 @@ func TestAtomicClearPrefix(t *testing.T, db Database) {
 	})
 }
 
+func TestDeleteRange(t *testing.T, db Database) {
+	testDeleteRange(t, db, func(db Database, start, limit []byte) error {
+		return DeleteRange(db, start, limit, math.MaxInt)
+	})
+}
+
+// TestDeleteRangeBatched tests to make sure range deletion works as expected
+// when deletions are written one at a time.
+//
+// Note: Databases that implement [RangeDeleter] ignore the write size.
+func TestDeleteRangeBatched(t *testing.T, db Database) {
+	testDeleteRange(t, db, func(db Database, start, limit []byte) error {
+		return DeleteRange(db, start, limit, 0)
+	})
+}
+
+// testDeleteRange tests to make sure range deletion works as expected.
+func testDeleteRange(t *testing.T, db Database, deleteRangeF func(Database, []byte, []byte) error) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		{},
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("b1"),
+		[]byte("c"),
+		[]byte("d"),
+		{0xff, 0xff},
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, key))
+	}
+
+	// Keys are compared as strings because some databases return the empty
+	// key as nil.
+	requireKeys := func(expected ...[]byte) {
+		var actual []string
+		it := db.NewIterator()
+		for it.Next() {
+			actual = append(actual, string(it.Key()))
+		}
+		require.NoError(it.Error())
+		it.Release()
+
+		expectedKeys := make([]string, len(expected))
+		for i, key := range expected {
+			expectedKeys[i] = string(key)
+		}
+		if len(expectedKeys) == 0 {
+			require.Empty(actual)
+			return
+		}
+		require.Equal(expectedKeys, actual)
+	}
+
+	// An empty range doesn't delete anything.
+	require.NoError(deleteRangeF(db, []byte("c"), []byte("b")))
+	require.NoError(deleteRangeF(db, []byte("b"), []byte("b")))
+	require.NoError(deleteRangeF(db, nil, []byte{}))
+	requireKeys(keys...)
+
+	// [limit] is exclusive.
+	require.NoError(deleteRangeF(db, []byte("b"), []byte("c")))
+	requireKeys(keys[0], keys[1], keys[4], keys[5], keys[6])
+
+	// A nil [limit] is after all keys.
+	require.NoError(deleteRangeF(db, []byte("d"), nil))
+	requireKeys(keys[0], keys[1], keys[4])
+
+	// A nil [start] is before all keys.
+	require.NoError(deleteRangeF(db, nil, []byte("c")))
+	requireKeys(keys[4])
+
+	require.NoError(db.Put([]byte("e"), []byte("e")))
+	require.NoError(deleteRangeF(db, nil, nil))
+	requireKeys()
+}
+
+// TestSnapshot tests to make sure that a snapshot isn't modified by writes
+// made after it was created.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		t.Skip("snapshots aren't supported")
+	}
+	require.NoError(err)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key1, value2))
+	require.NoError(db.Delete(key2))
+	require.NoError(db.Put(key3, value3))
+
+	v, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, v)
+
+	has, err := snapshot.Has(key2)
+	require.NoError(err)
+	require.True(has)
+
+	_, err = snapshot.Get(key3)
+	require.Equal(ErrNotFound, err)
+
+	has, err = snapshot.Has(key3)
+	require.NoError(err)
+	require.False(has)
+
+	it := snapshot.NewIteratorWithPrefix([]byte("hello"))
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal(key1, it.Key())
+	require.Equal(value1, it.Value())
+
+	require.True(it.Next())
+	require.Equal(key2, it.Key())
+	require.Equal(value2, it.Value())
+
+	require.False(it.Next())
+	require.NoError(it.Error())
+
+	// The database reflects the writes.
+	v, err = db.Get(key1)
+	require.NoError(err)
+	require.Equal(value2, v)
+}
+
+// TestSnapshotRelease tests to make sure that a released snapshot can't be
+// read.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello")
+	value := []byte("world")
+	require.NoError(db.Put(key, value))
+
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		t.Skip("snapshots aren't supported")
+	}
+	require.NoError(err)
+
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err = snapshot.Get(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = snapshot.Has(key)
+	require.Equal(ErrClosed, err)
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	require.False(it.Next())
+	require.Equal(ErrClosed, it.Error())
+}
+
 func TestClearPrefix(t *testing.T, db Database) {
 	testClearPrefix(t, db, func(db Database, prefix []byte) error {
 		return ClearPrefix(db, prefix, math.MaxInt)
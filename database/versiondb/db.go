@@ -5,6 +5,7 @@ package versiondb
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -14,10 +15,13 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ Commitable            = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Snapshot     = (*snapshot)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -128,26 +132,68 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
+	keys, values := memEntries(db.mem, start, prefix)
+	return &iterator{
+		db:       db,
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		keys:     keys,
+		values:   values,
+	}
+}
+
+// DeleteRange marks every key in the range [start, limit) as deleted. Keys in
+// the underlying database are removed when the changes are committed.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+
 	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
+	limitString := string(limit)
 	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
+		if key >= startString && (limit == nil || key < limitString) {
+			db.mem[key] = valueDelete{delete: true}
 		}
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	for it.Next() {
+		key := string(it.Key())
+		if limit != nil && key >= limitString {
+			break
+		}
+		db.mem[key] = valueDelete{delete: true}
 	}
+	return it.Error()
+}
 
-	return &iterator{
-		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+// NewSnapshot returns a snapshot that contains the uncommitted changes on top
+// of a snapshot of the underlying database.
+//
+// Returns [database.ErrSnapshotNotSupported] if the underlying database
+// doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+
+	dbSnapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
 	}
+	return &snapshot{
+		db:       db,
+		mem:      maps.Clone(db.mem),
+		snapshot: dbSnapshot,
+	}, nil
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -273,6 +319,121 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 	return db.db.HealthCheck(ctx)
 }
 
+// snapshot merges a copy of the uncommitted changes of a [Database] with a
+// snapshot of its underlying database.
+type snapshot struct {
+	db *Database
+
+	// Must be held when reading/writing [mem].
+	lock     sync.RWMutex
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	keys, values := memEntries(s.mem, start, prefix)
+	return &iterator{
+		db:       s.db,
+		Iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		keys:     keys,
+		values:   values,
+	}
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.snapshot.Release()
+}
+
+// memEntries returns the sorted keys in [mem] that have [prefix] and are
+// >= [start], along with their values.
+func memEntries(mem map[string]valueDelete, start, prefix []byte) ([]string, []valueDelete) {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys) // Keys need to be in sorted order
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+	return keys, values
+}
+
 type batch struct {
 	database.BatchOps
 
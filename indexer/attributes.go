@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"reflect"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	avmblock "github.com/ava-labs/avalanchego/vms/avm/block"
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	platformblock "github.com/ava-labs/avalanchego/vms/platformvm/block"
+	platformtxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+// Number of containers that are indexed by their attributes at a time when
+// indexing the attributes of containers that were indexed before their
+// attributes were.
+const attributesBatchSize = 1024
+
+var (
+	_ AttributeParser = (*proposerBlockParser)(nil)
+	_ AttributeParser = (*platformBlockParser)(nil)
+	_ AttributeParser = (*avmBlockParser)(nil)
+	_ AttributeParser = (*avmTxParser)(nil)
+)
+
+// Attributes of a container that it can be looked up by.
+type Attributes struct {
+	// Addresses that the container sends funds or ownership to. The owners of
+	// the funds that the container consumes aren't included, since resolving
+	// them would require the UTXOs that the container's inputs spend.
+	Addresses [][]byte
+	// Types of the transactions in the container
+	Types []string
+}
+
+// AttributeParser parses the attributes of containers.
+type AttributeParser interface {
+	Attributes(containerBytes []byte) (Attributes, error)
+}
+
+// attributeParsers returns the parsers of the containers in the block index
+// and the tx index of the chain. Returns nil parsers if the chain's containers
+// can't be indexed by their attributes.
+func attributeParsers(ctx *snow.ConsensusContext) (AttributeParser, AttributeParser, error) {
+	switch ctx.ChainID {
+	case constants.PlatformChainID:
+		return &proposerBlockParser{
+			parser: &platformBlockParser{},
+		}, nil, nil
+	case ctx.XChainID:
+		parser, err := avmblock.NewParser([]fxs.Fx{
+			&secp256k1fx.Fx{},
+			&nftfx.Fx{},
+			&propertyfx.Fx{},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		blockParser := &proposerBlockParser{
+			parser: &avmBlockParser{parser: parser},
+		}
+		return blockParser, &avmTxParser{parser: parser}, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+// proposerBlockParser parses the attributes of the blocks wrapped by
+// proposervm blocks. Blocks that were accepted before the proposervm was
+// activated aren't wrapped.
+type proposerBlockParser struct {
+	parser AttributeParser
+}
+
+func (p *proposerBlockParser) Attributes(containerBytes []byte) (Attributes, error) {
+	blk, err := proposerblock.Parse(containerBytes)
+	if err != nil {
+		return p.parser.Attributes(containerBytes)
+	}
+	return p.parser.Attributes(blk.Block())
+}
+
+type platformBlockParser struct{}
+
+func (*platformBlockParser) Attributes(containerBytes []byte) (Attributes, error) {
+	blk, err := platformblock.Parse(platformblock.Codec, containerBytes)
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	a := &attributeSet{}
+	for _, tx := range blk.Txs() {
+		a.addType(tx.Unsigned)
+		for _, utxo := range tx.UTXOs() {
+			a.addOutput(utxo.Out)
+		}
+		switch utx := tx.Unsigned.(type) {
+		case platformtxs.ValidatorTx:
+			a.addTransferableOutputs(utx.Stake())
+			a.addOutput(utx.ValidationRewardsOwner())
+			a.addOutput(utx.DelegationRewardsOwner())
+		case platformtxs.DelegatorTx:
+			a.addTransferableOutputs(utx.Stake())
+			a.addOutput(utx.RewardsOwner())
+		case *platformtxs.CreateSubnetTx:
+			a.addOutput(utx.Owner)
+		case *platformtxs.TransferSubnetOwnershipTx:
+			a.addOutput(utx.Owner)
+		case *platformtxs.ExportTx:
+			a.addTransferableOutputs(utx.ExportedOutputs)
+		}
+	}
+	return a.attributes(), nil
+}
+
+type avmBlockParser struct {
+	parser avmblock.Parser
+}
+
+func (p *avmBlockParser) Attributes(containerBytes []byte) (Attributes, error) {
+	blk, err := p.parser.ParseBlock(containerBytes)
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	a := &attributeSet{}
+	for _, tx := range blk.Txs() {
+		a.addAVMTx(tx)
+	}
+	return a.attributes(), nil
+}
+
+type avmTxParser struct {
+	parser avmtxs.Parser
+}
+
+func (p *avmTxParser) Attributes(containerBytes []byte) (Attributes, error) {
+	tx, err := p.parser.ParseTx(containerBytes)
+	if err != nil {
+		return Attributes{}, err
+	}
+
+	a := &attributeSet{}
+	a.addAVMTx(tx)
+	return a.attributes(), nil
+}
+
+// attributeSet collects the unique attributes of a container.
+type attributeSet struct {
+	addresses set.Set[string]
+	types     set.Set[string]
+}
+
+func (a *attributeSet) addAVMTx(tx *avmtxs.Tx) {
+	a.addType(tx.Unsigned)
+	for _, utxo := range tx.UTXOs() {
+		a.addOutput(utxo.Out)
+	}
+	if exportTx, ok := tx.Unsigned.(*avmtxs.ExportTx); ok {
+		a.addTransferableOutputs(exportTx.ExportedOuts)
+	}
+}
+
+// addType adds the name of the type of [tx], such as "BaseTx".
+func (a *attributeSet) addType(tx interface{}) {
+	txType := reflect.TypeOf(tx)
+	if txType.Kind() == reflect.Pointer {
+		txType = txType.Elem()
+	}
+	a.types.Add(txType.Name())
+}
+
+func (a *attributeSet) addTransferableOutputs(outs []*avax.TransferableOutput) {
+	for _, out := range outs {
+		a.addOutput(out.Out)
+	}
+}
+
+// addOutput adds the addresses of [out], if it has any.
+func (a *attributeSet) addOutput(out interface{}) {
+	if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		out = lockedOut.TransferableOut
+	}
+	addressable, ok := out.(avax.Addressable)
+	if !ok {
+		return
+	}
+	for _, address := range addressable.Addresses() {
+		a.addresses.Add(string(address))
+	}
+}
+
+func (a *attributeSet) attributes() Attributes {
+	attributes := Attributes{
+		Addresses: make([][]byte, 0, a.addresses.Len()),
+		Types:     a.types.List(),
+	}
+	for address := range a.addresses {
+		attributes.Addresses = append(attributes.Addresses, []byte(address))
+	}
+	return attributes
+}
+
+// indexAttributes indexes the containers in [index] that were indexed before
+// their attributes were by their attributes.
+func (i *indexer) indexAttributes(chainName string, endpoint string, index *index) {
+	next, end := index.AttributesRange()
+	if next >= end {
+		return
+	}
+
+	i.log.Info("indexing containers by attributes",
+		zap.String("chainName", chainName),
+		zap.String("endpoint", endpoint),
+		zap.Uint64("numToIndex", end-next),
+	)
+	var (
+		startTime   = time.Now()
+		lastLogTime = startTime
+	)
+	for next < end {
+		if i.backfillCtx.Err() != nil {
+			return
+		}
+
+		numToIndex := min(end-next, attributesBatchSize)
+		if err := index.PutAttributes(numToIndex); err != nil {
+			if i.backfillCtx.Err() != nil {
+				return
+			}
+			i.log.Error("failed to index containers by attributes",
+				zap.String("chainName", chainName),
+				zap.String("endpoint", endpoint),
+				zap.Uint64("index", next),
+				zap.Error(err),
+			)
+			return
+		}
+		next += numToIndex
+
+		if now := time.Now(); now.Sub(lastLogTime) >= backfillLogFrequency {
+			lastLogTime = now
+			i.log.Info("indexing containers by attributes",
+				zap.String("chainName", chainName),
+				zap.String("endpoint", endpoint),
+				zap.Uint64("numIndexed", next),
+				zap.Uint64("numToIndex", end-next),
+			)
+		}
+	}
+	i.log.Info("finished indexing containers by attributes",
+		zap.String("chainName", chainName),
+		zap.String("endpoint", endpoint),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+}
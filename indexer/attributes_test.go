@@ -0,0 +1,99 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	platformblock "github.com/ava-labs/avalanchego/vms/platformvm/block"
+	platformtxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	proposerblock "github.com/ava-labs/avalanchego/vms/proposervm/block"
+)
+
+func TestPlatformBlockAttributes(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		changeAddr = ids.ShortID{1}
+		lockedAddr = ids.ShortID{2}
+		ownerAddr  = ids.ShortID{3}
+	)
+	createSubnetTx, err := platformtxs.NewSigned(&platformtxs.CreateSubnetTx{
+		BaseTx: platformtxs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{
+				{
+					Out: &secp256k1fx.TransferOutput{
+						OutputOwners: secp256k1fx.OutputOwners{
+							Addrs: []ids.ShortID{changeAddr},
+						},
+					},
+				},
+				{
+					Out: &stakeable.LockOut{
+						TransferableOut: &secp256k1fx.TransferOutput{
+							OutputOwners: secp256k1fx.OutputOwners{
+								Addrs: []ids.ShortID{lockedAddr},
+							},
+						},
+					},
+				},
+			},
+		}},
+		Owner: &secp256k1fx.OutputOwners{
+			Addrs: []ids.ShortID{ownerAddr, changeAddr},
+		},
+	}, platformtxs.Codec, nil)
+	require.NoError(err)
+
+	blk, err := platformblock.NewBanffStandardBlock(
+		time.Unix(0, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*platformtxs.Tx{createSubnetTx},
+	)
+	require.NoError(err)
+
+	proposerBlk, err := proposerblock.BuildUnsigned(
+		ids.GenerateTestID(),
+		time.Unix(0, 0),
+		0,
+		blk.Bytes(),
+	)
+	require.NoError(err)
+
+	parser := &proposerBlockParser{
+		parser: &platformBlockParser{},
+	}
+	tests := []struct {
+		name           string
+		containerBytes []byte
+	}{
+		{
+			name:           "wrapped block",
+			containerBytes: proposerBlk.Bytes(),
+		},
+		{
+			name:           "unwrapped block",
+			containerBytes: blk.Bytes(),
+		},
+	}
+	for _, test := range tests {
+		attributes, err := parser.Attributes(test.containerBytes)
+		require.NoError(err, test.name)
+		require.ElementsMatch(
+			[][]byte{changeAddr[:], lockedAddr[:], ownerAddr[:]},
+			attributes.Addresses,
+			test.name,
+		)
+		require.Equal([]string{"CreateSubnetTx"}, attributes.Types, test.name)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Number of blocks that are read from the VM and indexed at a time when
+	// backfilling an index.
+	backfillBatchSize = 256
+	// Size of the writes used to clear an index before it is backfilled.
+	clearWriteSize = units.MiB
+	// Frequency that backfill progress is logged at.
+	backfillLogFrequency = 30 * time.Second
+)
+
+var errHistoryUnavailable = errors.New("accepted history isn't available")
+
+// prepareBackfill ensures that the accepted history of [vm] can be used to
+// rebuild the block index of the chain and clears the index so that it can be
+// rebuilt.
+//
+// The block index is rebuilt so that the block at height h is at index h-1.
+// This matches an index that was enabled when the chain was created, because
+// the genesis block is never accepted.
+//
+// Returns the number of indices that must be reserved for the blocks that
+// were accepted before the index was created. If a backfill of the index is
+// already in progress, the index isn't cleared and 0 is returned.
+//
+// Assumes [ctx.Lock] is not held.
+func (i *indexer) prepareBackfill(ctx *snow.ConsensusContext, vm block.ChainVM) (uint64, error) {
+	indexDB := i.indexDB(ctx.ChainID, blockPrefix)
+	defer indexDB.Close()
+
+	inProgress, err := indexDB.Has(backfillKey)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get whether index is being backfilled: %w", err)
+	}
+	if inProgress {
+		return 0, nil
+	}
+
+	lastAcceptedHeight, err := getLastAcceptedHeight(ctx, vm)
+	if err != nil {
+		return 0, err
+	}
+
+	i.log.Info("clearing index to be backfilled",
+		zap.Stringer("chainID", ctx.ChainID),
+		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+	)
+	if err := database.Clear(indexDB, clearWriteSize); err != nil {
+		return 0, fmt.Errorf("couldn't clear index: %w", err)
+	}
+	return lastAcceptedHeight, nil
+}
+
+// getLastAcceptedHeight returns the height of the last accepted block of
+// [vm] after making sure that every accepted block can be fetched by height.
+//
+// Assumes [ctx.Lock] is not held.
+func getLastAcceptedHeight(ctx *snow.ConsensusContext, vm block.ChainVM) (uint64, error) {
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	if err := vm.VerifyHeightIndex(context.TODO()); err != nil {
+		return 0, fmt.Errorf("%w: %w", errHistoryUnavailable, err)
+	}
+
+	lastAcceptedID, err := vm.LastAccepted(context.TODO())
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get last accepted block: %w", err)
+	}
+	lastAccepted, err := vm.GetBlock(context.TODO(), lastAcceptedID)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+
+	lastAcceptedHeight := lastAccepted.Height()
+	if lastAcceptedHeight == 0 {
+		return 0, nil
+	}
+
+	// If the chain was state synced, the oldest blocks aren't available.
+	if _, err := vm.GetBlockIDAtHeight(context.TODO(), 1); err != nil {
+		return 0, fmt.Errorf("%w: %w", errHistoryUnavailable, err)
+	}
+	return lastAcceptedHeight, nil
+}
+
+// backfill indexes the blocks of [vm] that haven't been backfilled in [index]
+// and marks the chain's index as complete once they all have been.
+//
+// Assumes [ctx.Lock] is not held.
+func (i *indexer) backfill(chainName string, ctx *snow.ConsensusContext, vm block.ChainVM, index *index) {
+	next, end := index.BackfillRange()
+	if next < end {
+		i.log.Info("backfilling index",
+			zap.String("chainName", chainName),
+			zap.Uint64("numToBackfill", end-next),
+		)
+	}
+
+	var (
+		startTime   = time.Now()
+		lastLogTime = startTime
+	)
+	for next < end {
+		if i.backfillCtx.Err() != nil {
+			return
+		}
+
+		numToFetch := min(end-next, backfillBatchSize)
+		containers, err := getBlocks(i.backfillCtx, ctx, vm, next+1, numToFetch)
+		if err == nil {
+			err = index.PutBackfilled(containers)
+		}
+		if err != nil {
+			if i.backfillCtx.Err() != nil {
+				return
+			}
+			i.log.Error("failed to backfill index",
+				zap.String("chainName", chainName),
+				zap.Uint64("index", next),
+				zap.Error(err),
+			)
+			return
+		}
+		next += numToFetch
+
+		if now := time.Now(); now.Sub(lastLogTime) >= backfillLogFrequency {
+			lastLogTime = now
+			i.log.Info("backfilling index",
+				zap.String("chainName", chainName),
+				zap.Uint64("numBackfilled", next),
+				zap.Uint64("numToBackfill", end-next),
+			)
+		}
+	}
+
+	if err := i.markComplete(ctx.ChainID); err != nil {
+		if i.backfillCtx.Err() != nil {
+			return
+		}
+		i.log.Error("couldn't mark chain as complete",
+			zap.String("chainName", chainName),
+			zap.Error(err),
+		)
+		return
+	}
+	i.log.Info("finished backfilling index",
+		zap.String("chainName", chainName),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+}
+
+// getBlocks returns the [numToFetch] blocks of [vm] starting at [height] as
+// containers.
+//
+// Assumes [ctx.Lock] is not held.
+func getBlocks(
+	backfillCtx context.Context,
+	ctx *snow.ConsensusContext,
+	vm block.ChainVM,
+	height uint64,
+	numToFetch uint64,
+) ([]Container, error) {
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	containers := make([]Container, numToFetch)
+	for j := range containers {
+		blkHeight := height + uint64(j)
+		blkID, err := vm.GetBlockIDAtHeight(backfillCtx, blkHeight)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get block ID at height %d: %w", blkHeight, err)
+		}
+		blk, err := vm.GetBlock(backfillCtx, blkID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+		containers[j] = Container{
+			ID:        blkID,
+			Bytes:     blk.Bytes(),
+			Timestamp: blk.Timestamp().UnixNano(),
+		}
+	}
+	return containers, nil
+}
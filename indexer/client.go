@@ -32,6 +32,18 @@ type Client interface {
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container and its index by its ID
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, uint64, error)
+	// GetContainersByAddress returns up to [numToFetch] containers that send
+	// funds or ownership to [addr], starting with the first one at or after
+	// [startIndex], and the index to pass as [startIndex] to get the
+	// following containers.
+	// Only recipients are indexed, so containers that spend funds owned by
+	// [addr] without sending any funds or ownership to [addr] aren't returned.
+	GetContainersByAddress(ctx context.Context, addr string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error)
+	// GetContainersByType returns up to [numToFetch] containers that include a
+	// transaction of type [txType], starting with the first one at or after
+	// [startIndex], and the index to pass as [startIndex] to get the
+	// following containers.
+	GetContainersByType(ctx context.Context, txType string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error)
 }
 
 // Client implementation for Avalanche Indexer API Endpoint
@@ -62,8 +74,44 @@ func (c *client) GetContainerRange(ctx context.Context, startIndex uint64, numTo
 		return nil, err
 	}
 
-	response := make([]Container, len(fcs.Containers))
-	for i, resp := range fcs.Containers {
+	return decodeContainers(fcs.Containers)
+}
+
+func (c *client) GetContainersByAddress(ctx context.Context, addr string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error) {
+	var fcs GetContainerRangeResponse
+	err := c.requester.SendRequest(ctx, "index.getContainersByAddress", &GetContainersByAddressArgs{
+		Address:    addr,
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	containers, err := decodeContainers(fcs.Containers)
+	return containers, nextIndex(fcs.Containers, startIndex), err
+}
+
+func (c *client) GetContainersByType(ctx context.Context, txType string, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error) {
+	var fcs GetContainerRangeResponse
+	err := c.requester.SendRequest(ctx, "index.getContainersByType", &GetContainersByTypeArgs{
+		Type:       txType,
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	containers, err := decodeContainers(fcs.Containers)
+	return containers, nextIndex(fcs.Containers, startIndex), err
+}
+
+func decodeContainers(fcs []FormattedContainer) ([]Container, error) {
+	response := make([]Container, len(fcs))
+	for i, resp := range fcs {
 		containerBytes, err := formatting.Decode(resp.Encoding, resp.Bytes)
 		if err != nil {
 			return nil, fmt.Errorf("couldn't decode container %s: %w", resp.ID, err)
@@ -77,6 +125,15 @@ func (c *client) GetContainerRange(ctx context.Context, startIndex uint64, numTo
 	return response, nil
 }
 
+// nextIndex returns the index after the last of [fcs], or [startIndex] if
+// [fcs] is empty.
+func nextIndex(fcs []FormattedContainer, startIndex uint64) uint64 {
+	if len(fcs) == 0 {
+		return startIndex
+	}
+	return uint64(fcs[len(fcs)-1].Index) + 1
+}
+
 func (c *client) GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error) {
 	var fc FormattedContainer
 	err := c.requester.SendRequest(ctx, "index.getContainerByIndex", &GetContainerByIndexArgs{
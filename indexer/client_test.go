@@ -91,6 +91,46 @@ func TestIndexClient(t *testing.T) {
 		require.Equal(id, containers[0].ID)
 		require.Equal(bytes, containers[0].Bytes)
 	}
+	{
+		// Test GetContainersByAddress
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainersByAddress",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainerRangeResponse)) = GetContainerRangeResponse{Containers: []FormattedContainer{{
+					ID:    id,
+					Bytes: bytesStr,
+					Index: json.Uint64(7),
+				}}}
+				return nil
+			},
+		}
+		containers, next, err := client.GetContainersByAddress(context.Background(), "P-local1", 1, 10)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.Equal(id, containers[0].ID)
+		require.Equal(bytes, containers[0].Bytes)
+		require.Equal(uint64(8), next)
+	}
+	{
+		// Test GetContainersByType
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "index.getContainersByType",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainerRangeResponse)) = GetContainerRangeResponse{}
+				return nil
+			},
+		}
+		containers, next, err := client.GetContainersByType(context.Background(), "BaseTx", 3, 10)
+		require.NoError(err)
+		require.Empty(containers)
+		require.Equal(uint64(3), next)
+	}
 	{
 		// Test IsAccepted
 		client.requester = &mockClient{
@@ -0,0 +1,253 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/index/indexconnect"
+
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
+)
+
+var _ indexconnect.IndexHandler = (*connectService)(nil)
+
+// connectService serves an index over gRPC, gRPC-Web and Connect.
+type connectService struct {
+	index *index
+}
+
+func (s *connectService) GetLastAccepted(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[indexpb.GetLastAcceptedResponse], error) {
+	container, err := s.index.GetLastAccepted()
+	if err != nil {
+		return nil, connectError(err)
+	}
+	pbContainer, err := s.newContainer(container)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&indexpb.GetLastAcceptedResponse{
+		Container: pbContainer,
+	}), nil
+}
+
+func (s *connectService) GetContainerByIndex(
+	_ context.Context,
+	req *connect.Request[indexpb.GetContainerByIndexRequest],
+) (*connect.Response[indexpb.GetContainerByIndexResponse], error) {
+	container, err := s.index.GetContainerByIndex(req.Msg.Index)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&indexpb.GetContainerByIndexResponse{
+		Container: newContainer(container, req.Msg.Index),
+	}), nil
+}
+
+func (s *connectService) GetContainerByID(
+	_ context.Context,
+	req *connect.Request[indexpb.GetContainerByIDRequest],
+) (*connect.Response[indexpb.GetContainerByIDResponse], error) {
+	id, err := ids.ToID(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	container, err := s.index.GetContainerByID(id)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	pbContainer, err := s.newContainer(container)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&indexpb.GetContainerByIDResponse{
+		Container: pbContainer,
+	}), nil
+}
+
+func (s *connectService) GetContainerRange(
+	_ context.Context,
+	req *connect.Request[indexpb.GetContainerRangeRequest],
+) (*connect.Response[indexpb.GetContainerRangeResponse], error) {
+	containers, err := s.index.GetContainerRange(req.Msg.StartIndex, req.Msg.NumToFetch)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	pbContainers := make([]*indexpb.Container, len(containers))
+	for i, container := range containers {
+		pbContainers[i] = newContainer(container, req.Msg.StartIndex+uint64(i))
+	}
+	return connect.NewResponse(&indexpb.GetContainerRangeResponse{
+		Containers: pbContainers,
+	}), nil
+}
+
+func (s *connectService) GetContainersByAddress(
+	_ context.Context,
+	req *connect.Request[indexpb.GetContainersByAddressRequest],
+) (*connect.Response[indexpb.GetContainersByAddressResponse], error) {
+	addr, err := ids.ToShortID(req.Msg.Address)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	containers, err := s.index.GetContainersByAddress(addr[:], req.Msg.StartIndex, req.Msg.NumToFetch)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	pbContainers, err := s.newContainers(containers)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&indexpb.GetContainersByAddressResponse{
+		Containers: pbContainers,
+	}), nil
+}
+
+func (s *connectService) GetContainersByType(
+	_ context.Context,
+	req *connect.Request[indexpb.GetContainersByTypeRequest],
+) (*connect.Response[indexpb.GetContainersByTypeResponse], error) {
+	containers, err := s.index.GetContainersByType(req.Msg.Type, req.Msg.StartIndex, req.Msg.NumToFetch)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	pbContainers, err := s.newContainers(containers)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&indexpb.GetContainersByTypeResponse{
+		Containers: pbContainers,
+	}), nil
+}
+
+func (s *connectService) GetIndex(
+	_ context.Context,
+	req *connect.Request[indexpb.GetIndexRequest],
+) (*connect.Response[indexpb.GetIndexResponse], error) {
+	id, err := ids.ToID(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	index, err := s.index.GetIndex(id)
+	if err != nil {
+		return nil, connectError(err)
+	}
+	return connect.NewResponse(&indexpb.GetIndexResponse{
+		Index: index,
+	}), nil
+}
+
+func (s *connectService) IsAccepted(
+	_ context.Context,
+	req *connect.Request[indexpb.IsAcceptedRequest],
+) (*connect.Response[indexpb.IsAcceptedResponse], error) {
+	id, err := ids.ToID(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	_, err = s.index.GetIndex(id)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		return connect.NewResponse(&indexpb.IsAcceptedResponse{}), nil
+	default:
+		return nil, err
+	}
+	return connect.NewResponse(&indexpb.IsAcceptedResponse{
+		IsAccepted: true,
+	}), nil
+}
+
+func (s *connectService) StreamAccepted(
+	ctx context.Context,
+	req *connect.Request[indexpb.StreamAcceptedRequest],
+	stream *connect.ServerStream[indexpb.StreamAcceptedResponse],
+) error {
+	nextIndex := req.Msg.StartIndex
+	for {
+		nextAcceptedIndex, accepted := s.index.NextAccepted()
+		for nextIndex < nextAcceptedIndex {
+			numToFetch := min(nextAcceptedIndex-nextIndex, MaxFetchedByRange)
+			containers, err := s.index.GetContainerRange(nextIndex, numToFetch)
+			if err != nil {
+				return connectError(err)
+			}
+			for _, container := range containers {
+				err := stream.Send(&indexpb.StreamAcceptedResponse{
+					Container: newContainer(container, nextIndex),
+				})
+				if err != nil {
+					return err
+				}
+				nextIndex++
+			}
+		}
+
+		select {
+		case <-accepted:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *connectService) newContainers(containers []Container) ([]*indexpb.Container, error) {
+	pbContainers := make([]*indexpb.Container, len(containers))
+	for i, container := range containers {
+		var err error
+		pbContainers[i], err = s.newContainer(container)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pbContainers, nil
+}
+
+// newContainer converts [container] after looking up its index.
+func (s *connectService) newContainer(container Container) (*indexpb.Container, error) {
+	index, err := s.index.GetIndex(container.ID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get index: %w", err)
+	}
+	return newContainer(container, index), nil
+}
+
+func newContainer(container Container, index uint64) *indexpb.Container {
+	return &indexpb.Container{
+		Id:        container.ID[:],
+		Bytes:     container.Bytes,
+		Timestamp: timestamppb.New(time.Unix(0, container.Timestamp)),
+		Index:     index,
+	}
+}
+
+// connectError attaches the matching status code to the errors that are caused
+// by the request.
+func connectError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound),
+		errors.Is(err, errNoneAccepted),
+		errors.Is(err, errNoContainerAtIndex):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, errNumToFetchInvalid):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, errNotBackfilled),
+		errors.Is(err, errNoAttributes):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	default:
+		return err
+	}
+}
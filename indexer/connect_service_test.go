@@ -0,0 +1,193 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/index/indexconnect"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	indexpb "github.com/ava-labs/avalanchego/proto/pb/index"
+)
+
+func newConnectClient(t *testing.T, idx *index) indexconnect.IndexClient {
+	path, handler := indexconnect.NewIndexHandler(&connectService{index: idx})
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return indexconnect.NewIndexClient(server.Client(), server.URL)
+}
+
+func TestConnectGetContainerRange(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, nil)
+	require.NoError(err)
+
+	containerIDs := []ids.ID{
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+	}
+	for _, containerID := range containerIDs {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	client := newConnectClient(t, idx)
+	response, err := client.GetContainerRange(context.Background(), connect.NewRequest(&indexpb.GetContainerRangeRequest{
+		StartIndex: 1,
+		NumToFetch: MaxFetchedByRange,
+	}))
+	require.NoError(err)
+	require.Len(response.Msg.Containers, 2)
+	for i, container := range response.Msg.Containers {
+		require.Equal(containerIDs[i+1][:], container.Id)
+		require.Equal(uint64(i+1), container.Index)
+	}
+
+	_, err = client.GetContainerRange(context.Background(), connect.NewRequest(&indexpb.GetContainerRangeRequest{
+		StartIndex: 0,
+		NumToFetch: MaxFetchedByRange + 1,
+	}))
+	require.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	unknownID := ids.GenerateTestID()
+	_, err = client.GetContainerByID(context.Background(), connect.NewRequest(&indexpb.GetContainerByIDRequest{
+		Id: unknownID[:],
+	}))
+	require.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestConnectStreamAccepted(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{}, nil)
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+	require.NoError(idx.Accept(ctx, containerIDs[0], utils.RandomBytes(32)))
+	require.NoError(idx.Accept(ctx, containerIDs[1], utils.RandomBytes(32)))
+
+	streamCtx, cancel := context.WithCancel(context.Background())
+
+	client := newConnectClient(t, idx)
+	stream, err := client.StreamAccepted(streamCtx, connect.NewRequest(&indexpb.StreamAcceptedRequest{
+		StartIndex: 1,
+	}))
+	require.NoError(err)
+	defer stream.Close()
+	// The stream must be canceled before it is closed, as closing it waits
+	// for the server to end the stream.
+	defer cancel()
+
+	// Containers that were accepted before the stream was opened are sent
+	// first.
+	require.True(stream.Receive())
+	require.Equal(containerIDs[1][:], stream.Msg().Container.Id)
+	require.Equal(uint64(1), stream.Msg().Container.Index)
+
+	// Then containers are sent as they are accepted.
+	require.NoError(idx.Accept(ctx, containerIDs[2], utils.RandomBytes(32)))
+	require.True(stream.Receive())
+	require.Equal(containerIDs[2][:], stream.Msg().Container.Id)
+	require.Equal(uint64(2), stream.Msg().Container.Index)
+}
+
+// The Connect service of an index must be guarded by the policy of the index.
+func TestConnectServiceAuth(t *testing.T) {
+	require := require.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+
+	apiServer, err := server.New(
+		logging.NoLog{},
+		nil,
+		listener,
+		nil,
+		time.Second,
+		ids.EmptyNodeID,
+		false,
+		nil,
+		"",
+		prometheus.NewRegistry(),
+		server.HTTPConfig{},
+		[]string{"*"},
+		server.AuthConfig{
+			Endpoints: map[string]server.EndpointPolicy{
+				"index/X": {
+					Tokens: []string{"token"},
+				},
+			},
+		},
+	)
+	require.NoError(err)
+	go func() {
+		_ = apiServer.Dispatch()
+	}()
+	defer func() {
+		require.NoError(apiServer.Shutdown())
+	}()
+
+	idxrIntf, err := NewIndexer(Config{
+		Log:                 logging.NoLog{},
+		DB:                  memdb.New(),
+		BlockAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		TxAcceptorGroup:     snow.NewAcceptorGroup(logging.NoLog{}),
+		VertexAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:           apiServer,
+		ShutdownF:           func() {},
+	})
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+
+	idx, err := idxr.registerChainHelper(ids.GenerateTestID(), blockPrefix, "X", "block", idxr.blockAcceptorGroup, nil)
+	require.NoError(err)
+	defer func() {
+		require.NoError(idx.Close())
+	}()
+
+	client := indexconnect.NewIndexClient(
+		http.DefaultClient,
+		"http://"+listener.Addr().String()+"/ext/index/X/block",
+	)
+
+	_, err = client.GetLastAccepted(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	request := connect.NewRequest(&emptypb.Empty{})
+	request.Header().Set("Authorization", "Bearer token")
+	_, err = client.GetLastAccepted(context.Background(), request)
+	// The index is empty, but the request was authenticated.
+	require.NotEqual(connect.CodeUnauthenticated, connect.CodeOf(err))
+	require.NotEqual(connect.CodeUnimplemented, connect.CodeOf(err))
+}
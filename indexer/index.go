@@ -4,8 +4,10 @@
 package indexer
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 
 	"go.uber.org/zap"
@@ -18,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 // Maximum number of containers IDs that can be fetched at a time in a call to
@@ -29,9 +32,26 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
-	errNoneAccepted        = errors.New("no containers have been accepted")
-	errNumToFetchInvalid   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
-	errNoContainerAtIndex  = errors.New("no container at index")
+	// Maps to the byte representation of the next index to backfill followed
+	// by the byte representation of the index after the last one to backfill.
+	// Only present while the index is being backfilled.
+	backfillKey          = []byte{0x03}
+	addressToIndexPrefix = []byte{0x04}
+	typeToIndexPrefix    = []byte{0x05}
+	// Maps to the byte representation of the next index whose attributes
+	// should be indexed followed by the byte representation of the index
+	// after the last one whose attributes should be indexed. Only present if
+	// containers are indexed by attributes.
+	attributesKey         = []byte{0x06}
+	errNoneAccepted       = errors.New("no containers have been accepted")
+	errNumToFetchInvalid  = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errNoContainerAtIndex = errors.New("no container at index")
+	errNotBackfilled      = errors.New("container hasn't been backfilled yet")
+	errIndexNotEmpty      = errors.New("can't reserve indices in a non-empty index")
+	errTooManyBackfilled  = errors.New("more containers than indices left to backfill")
+	errInvalidBackfill    = errors.New("invalid backfill progress")
+	errNoAttributes       = errors.New("containers aren't indexed by attributes")
+	errInvalidAttributes  = errors.New("invalid attribute indexing progress")
 
 	_ snow.Acceptor = (*index)(nil)
 )
@@ -46,6 +66,10 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// Containers at indices in [backfillNext, backfillEnd) were accepted but
+	// haven't been indexed yet.
+	backfillNext uint64
+	backfillEnd  uint64
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -54,20 +78,40 @@ type index struct {
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
-	log              logging.Logger
+	// If non-nil, containers are indexed by the attributes it parses.
+	parser AttributeParser
+	// Both [addressToIndex] and [typeToIndex] have [vDB] underneath
+	// Address + Index --> nil
+	addressToIndex database.Database
+	// Type + Index --> nil
+	typeToIndex database.Database
+	// Containers at indices in [attributesNext, attributesEnd) were indexed
+	// before their attributes were and their attributes haven't been indexed
+	// yet.
+	attributesNext uint64
+	attributesEnd  uint64
+	// accepted is closed, and replaced, whenever a container is indexed as
+	// accepted.
+	accepted chan struct{}
+	log      logging.Logger
 }
 
 // Create a new thread-safe index.
 //
+// If [parser] is non-nil, containers are also indexed by their attributes.
+//
 // Invariant: Closes [baseDB] on close.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	clock mockable.Clock,
+	parser AttributeParser,
 ) (*index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	addressToIndex := prefixdb.New(addressToIndexPrefix, vDB)
+	typeToIndex := prefixdb.New(typeToIndexPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -75,22 +119,40 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		parser:           parser,
+		addressToIndex:   addressToIndex,
+		typeToIndex:      typeToIndex,
+		accepted:         make(chan struct{}),
 		log:              log,
 	}
 
+	backfillBytes, err := i.vDB.Get(backfillKey)
+	switch err {
+	case nil:
+		if len(backfillBytes) != 2*wrappers.LongLen {
+			return nil, errInvalidBackfill
+		}
+		i.backfillNext = binary.BigEndian.Uint64(backfillBytes)
+		i.backfillEnd = binary.BigEndian.Uint64(backfillBytes[wrappers.LongLen:])
+	case database.ErrNotFound:
+	default:
+		return nil, fmt.Errorf("couldn't get backfill progress from database: %w", err)
+	}
+
 	// Get next accepted index from db
 	nextAcceptedIndex, err := database.GetUInt64(i.vDB, nextAcceptedIndexKey)
-	if err == database.ErrNotFound {
+	switch err {
+	case nil:
+		i.nextAcceptedIndex = nextAcceptedIndex
+	case database.ErrNotFound:
 		// Couldn't find it in the database. Must not have accepted any containers in previous runs.
-		i.log.Info("created new index",
-			zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
-		)
-		return i, nil
-	}
-	if err != nil {
+	default:
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
-	i.nextAcceptedIndex = nextAcceptedIndex
+
+	if err := i.initAttributes(); err != nil {
+		return nil, err
+	}
 	i.log.Info("created new index",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 	)
@@ -102,6 +164,8 @@ func (i *index) Close() error {
 	return utils.Err(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.addressToIndex.Close(),
+		i.typeToIndex.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -152,6 +216,11 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't map container %s to index: %w", containerID, err)
 	}
 
+	// Persist attributes --> index
+	if err := i.putAttributes(i.nextAcceptedIndex, containerID, containerBytes); err != nil {
+		return err
+	}
+
 	// Persist next accepted index
 	i.nextAcceptedIndex++
 	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
@@ -159,7 +228,22 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
+}
+
+// NextAccepted returns the index that the next accepted container will be
+// indexed at and a channel that is closed once it is indexed.
+func (i *index) NextAccepted() (uint64, <-chan struct{}) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.nextAcceptedIndex, i.accepted
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -179,6 +263,9 @@ func (i *index) getContainerByIndex(index uint64) (Container, error) {
 	if !ok || index > lastAcceptedIndex {
 		return Container{}, fmt.Errorf("%w %d", errNoContainerAtIndex, index)
 	}
+	if i.backfillNext <= index && index < i.backfillEnd {
+		return Container{}, fmt.Errorf("%w: %d", errNotBackfilled, index)
+	}
 	indexBytes := database.PackUInt64(index)
 	return i.getContainerByIndexBytes(indexBytes)
 }
@@ -280,3 +367,303 @@ func (i *index) GetLastAccepted() (Container, error) {
 func (i *index) lastAcceptedIndex() (uint64, bool) {
 	return i.nextAcceptedIndex - 1, i.nextAcceptedIndex != 0
 }
+
+// Reserve the first [numContainers] indices for containers that were accepted
+// before the index was created. The containers are indexed later by calling
+// PutBackfilled.
+//
+// Invariant: Reserve must be called before any containers are indexed.
+func (i *index) Reserve(numContainers uint64) error {
+	if numContainers == 0 {
+		return nil
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.nextAcceptedIndex != 0 {
+		return errIndexNotEmpty
+	}
+
+	i.nextAcceptedIndex = numContainers
+	i.backfillNext = 0
+	i.backfillEnd = numContainers
+	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
+		return fmt.Errorf("couldn't put next accepted index: %w", err)
+	}
+	if err := i.putBackfillProgress(i.backfillNext, i.backfillEnd); err != nil {
+		return err
+	}
+	return i.vDB.Commit()
+}
+
+// BackfillRange returns the indices [start, end) that haven't been backfilled
+// yet.
+func (i *index) BackfillRange() (uint64, uint64) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.backfillNext, i.backfillEnd
+}
+
+// PutBackfilled indexes [containers] at the next indices that haven't been
+// backfilled yet. If an error is returned, none of [containers] are indexed.
+func (i *index) PutBackfilled(containers []Container) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if uint64(len(containers)) > i.backfillEnd-i.backfillNext {
+		return fmt.Errorf("%w: %d > %d", errTooManyBackfilled, len(containers), i.backfillEnd-i.backfillNext)
+	}
+
+	next, end, err := i.putBackfilled(containers)
+	if err != nil {
+		// Drop the containers that were written before the error, so that
+		// they are written again at the same indices when retried.
+		i.vDB.Abort()
+		return err
+	}
+	i.backfillNext = next
+	i.backfillEnd = end
+	return nil
+}
+
+// putBackfilled writes and commits [containers] and returns the backfill
+// progress after they were written. The in-memory backfill progress is only
+// updated by the caller once the commit succeeded.
+//
+// Assumes [i.lock] is held
+func (i *index) putBackfilled(containers []Container) (uint64, uint64, error) {
+	next, end := i.backfillNext, i.backfillEnd
+	for _, container := range containers {
+		indexBytes := database.PackUInt64(next)
+		bytes, err := Codec.Marshal(CodecVersion, container)
+		if err != nil {
+			return 0, 0, fmt.Errorf("couldn't serialize container %s: %w", container.ID, err)
+		}
+		if err := i.indexToContainer.Put(indexBytes, bytes); err != nil {
+			return 0, 0, fmt.Errorf("couldn't put backfilled container %s into index: %w", container.ID, err)
+		}
+		if err := i.containerToIndex.Put(container.ID[:], indexBytes); err != nil {
+			return 0, 0, fmt.Errorf("couldn't map container %s to index: %w", container.ID, err)
+		}
+		if err := i.putAttributes(next, container.ID, container.Bytes); err != nil {
+			return 0, 0, err
+		}
+		next++
+	}
+
+	if next == end {
+		next = 0
+		end = 0
+		if err := i.vDB.Delete(backfillKey); err != nil {
+			return 0, 0, fmt.Errorf("couldn't delete backfill progress: %w", err)
+		}
+	} else if err := i.putBackfillProgress(next, end); err != nil {
+		return 0, 0, err
+	}
+	return next, end, i.vDB.Commit()
+}
+
+// Assumes [i.lock] is held
+func (i *index) putBackfillProgress(next, end uint64) error {
+	backfillBytes := make([]byte, 2*wrappers.LongLen)
+	binary.BigEndian.PutUint64(backfillBytes, next)
+	binary.BigEndian.PutUint64(backfillBytes[wrappers.LongLen:], end)
+	if err := i.vDB.Put(backfillKey, backfillBytes); err != nil {
+		return fmt.Errorf("couldn't put backfill progress: %w", err)
+	}
+	return nil
+}
+
+// initAttributes loads the progress of indexing the attributes of the
+// containers that were indexed before their attributes were.
+//
+// Assumes [i.nextAcceptedIndex] has been loaded.
+func (i *index) initAttributes() error {
+	if i.parser == nil {
+		// If attributes were indexed in a previous run, they will be missing
+		// for the containers accepted during this run. Forget the progress so
+		// that every container is indexed by its attributes if attributes
+		// are indexed again.
+		if err := i.vDB.Delete(attributesKey); err != nil {
+			return fmt.Errorf("couldn't delete attribute indexing progress: %w", err)
+		}
+		return i.vDB.Commit()
+	}
+
+	attributesBytes, err := i.vDB.Get(attributesKey)
+	switch err {
+	case nil:
+		if len(attributesBytes) != 2*wrappers.LongLen {
+			return errInvalidAttributes
+		}
+		i.attributesNext = binary.BigEndian.Uint64(attributesBytes)
+		i.attributesEnd = binary.BigEndian.Uint64(attributesBytes[wrappers.LongLen:])
+		return nil
+	case database.ErrNotFound:
+		// Attributes weren't indexed in the previous run, so every container
+		// that was already indexed must be indexed by its attributes.
+		i.attributesNext = 0
+		i.attributesEnd = i.nextAcceptedIndex
+		if err := i.putAttributesProgress(); err != nil {
+			return err
+		}
+		return i.vDB.Commit()
+	default:
+		return fmt.Errorf("couldn't get attribute indexing progress from database: %w", err)
+	}
+}
+
+// putAttributes indexes the container [containerID] at [index] by its
+// attributes, if attributes are indexed.
+//
+// Containers that can't be parsed aren't indexed by their attributes, since
+// the bytes of a container are opaque to the index.
+//
+// Assumes [i.lock] is held
+func (i *index) putAttributes(index uint64, containerID ids.ID, containerBytes []byte) error {
+	if i.parser == nil {
+		return nil
+	}
+
+	attributes, err := i.parser.Attributes(containerBytes)
+	if err != nil {
+		i.log.Debug("not indexing container by its attributes",
+			zap.Stringer("containerID", containerID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	for _, address := range attributes.Addresses {
+		if err := i.addressToIndex.Put(attributeKey(address, index), nil); err != nil {
+			return fmt.Errorf("couldn't map address of container %s to index: %w", containerID, err)
+		}
+	}
+	for _, typ := range attributes.Types {
+		if err := i.typeToIndex.Put(attributeKey([]byte(typ), index), nil); err != nil {
+			return fmt.Errorf("couldn't map type of container %s to index: %w", containerID, err)
+		}
+	}
+	return nil
+}
+
+// AttributesRange returns the indices [start, end) of the containers whose
+// attributes haven't been indexed yet.
+func (i *index) AttributesRange() (uint64, uint64) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	return i.attributesNext, i.attributesEnd
+}
+
+// PutAttributes indexes the next [numToIndex] containers whose attributes
+// haven't been indexed yet by their attributes.
+func (i *index) PutAttributes(numToIndex uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.parser == nil {
+		return errNoAttributes
+	}
+
+	end := min(i.attributesNext+numToIndex, i.attributesEnd)
+	for ; i.attributesNext < end; i.attributesNext++ {
+		// Containers that haven't been backfilled are indexed by their
+		// attributes when they're backfilled.
+		if i.backfillNext <= i.attributesNext && i.attributesNext < i.backfillEnd {
+			continue
+		}
+
+		indexBytes := database.PackUInt64(i.attributesNext)
+		container, err := i.getContainerByIndexBytes(indexBytes)
+		if err != nil {
+			return err
+		}
+		if err := i.putAttributes(i.attributesNext, container.ID, container.Bytes); err != nil {
+			return err
+		}
+	}
+	if err := i.putAttributesProgress(); err != nil {
+		return err
+	}
+	return i.vDB.Commit()
+}
+
+// Assumes [i.lock] is held
+func (i *index) putAttributesProgress() error {
+	attributesBytes := make([]byte, 2*wrappers.LongLen)
+	binary.BigEndian.PutUint64(attributesBytes, i.attributesNext)
+	binary.BigEndian.PutUint64(attributesBytes[wrappers.LongLen:], i.attributesEnd)
+	if err := i.vDB.Put(attributesKey, attributesBytes); err != nil {
+		return fmt.Errorf("couldn't put attribute indexing progress: %w", err)
+	}
+	return nil
+}
+
+// GetContainersByAddress returns up to [numToFetch] containers that send
+// funds or ownership to [address], in the order they were accepted, starting
+// with the first one at or after [startIndex]. Containers that only spend
+// funds owned by [address] aren't returned.
+// [numToFetch] should be in [1, MaxFetchedByRange]
+func (i *index) GetContainersByAddress(address []byte, startIndex, numToFetch uint64) ([]Container, error) {
+	return i.getContainersByAttribute(i.addressToIndex, address, startIndex, numToFetch)
+}
+
+// GetContainersByType returns up to [numToFetch] containers that include a
+// transaction of type [typ], in the order they were accepted, starting with
+// the first one at or after [startIndex].
+// [numToFetch] should be in [1, MaxFetchedByRange]
+func (i *index) GetContainersByType(typ string, startIndex, numToFetch uint64) ([]Container, error) {
+	return i.getContainersByAttribute(i.typeToIndex, []byte(typ), startIndex, numToFetch)
+}
+
+func (i *index) getContainersByAttribute(
+	db database.Database,
+	attribute []byte,
+	startIndex uint64,
+	numToFetch uint64,
+) ([]Container, error) {
+	if numToFetch == 0 || numToFetch > MaxFetchedByRange {
+		return nil, fmt.Errorf("%w but is %d", errNumToFetchInvalid, numToFetch)
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if i.parser == nil {
+		return nil, errNoAttributes
+	}
+	if len(attribute) > math.MaxUint16 {
+		// No container has an attribute this long.
+		return nil, nil
+	}
+
+	prefix := attributeKey(attribute, 0)
+	prefix = prefix[:len(prefix)-wrappers.LongLen]
+	it := db.NewIteratorWithStartAndPrefix(attributeKey(attribute, startIndex), prefix)
+	defer it.Release()
+
+	var containers []Container
+	for uint64(len(containers)) < numToFetch && it.Next() {
+		key := it.Key()
+		indexBytes := key[len(key)-wrappers.LongLen:]
+		container, err := i.getContainerByIndexBytes(indexBytes)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, it.Error()
+}
+
+// attributeKey returns the key that maps [attribute] to [index]. Attributes
+// are prefixed by their length so that an attribute can't be a prefix of
+// another.
+func attributeKey(attribute []byte, index uint64) []byte {
+	key := make([]byte, wrappers.ShortLen+len(attribute)+wrappers.LongLen)
+	binary.BigEndian.PutUint16(key, uint16(len(attribute)))
+	copy(key[wrappers.ShortLen:], attribute)
+	binary.BigEndian.PutUint64(key[wrappers.ShortLen+len(attribute):], index)
+	return key
+}
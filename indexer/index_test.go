@@ -4,10 +4,12 @@
 package indexer
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -27,7 +29,7 @@ func TestIndex(t *testing.T) {
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
 
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
 	require.NoError(err)
 
 	// Populate "containers" with random IDs/bytes
@@ -79,7 +81,7 @@ func TestIndex(t *testing.T) {
 	require.NoError(db.Commit())
 	require.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
 	require.NoError(err)
 
 	// Get all of the containers
@@ -111,7 +113,7 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	db := memdb.New()
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
 	require.NoError(err)
 
 	// Insert [MaxFetchedByRange] + 1 containers
@@ -149,7 +151,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	db := memdb.New()
 	snowCtx := snowtest.Context(t, snowtest.CChainID)
 	ctx := snowtest.ConsensusContext(snowCtx)
-	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
 	require.NoError(err)
 
 	// Accept the same container twice
@@ -162,3 +164,227 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.Equal([]byte{1, 2, 3}, gotContainer.Bytes)
 }
+
+var errTestParse = errors.New("non-nil error")
+
+// testParser parses containers whose first byte is an address and whose
+// remaining bytes are a type.
+type testParser struct{}
+
+func (testParser) Attributes(containerBytes []byte) (Attributes, error) {
+	if len(containerBytes) == 0 {
+		return Attributes{}, errTestParse
+	}
+	return Attributes{
+		Addresses: [][]byte{containerBytes[:1]},
+		Types:     []string{string(containerBytes[1:])},
+	}, nil
+}
+
+func TestIndexAttributes(t *testing.T) {
+	require := require.New(t)
+	db := memdb.New()
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, testParser{})
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 6)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		// Containers alternate between address 0 with type "a" and address 1
+		// with type "bb".
+		containerBytes := []byte{0, 'a'}
+		if i%2 == 1 {
+			containerBytes = []byte{1, 'b', 'b'}
+		}
+		require.NoError(idx.Accept(ctx, containerIDs[i], containerBytes))
+	}
+	// Containers that can't be parsed are still indexed.
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), nil))
+
+	containers, err := idx.GetContainersByAddress([]byte{0}, 0, 2)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[0], containers[0].ID)
+	require.Equal(containerIDs[2], containers[1].ID)
+
+	// The next page starts after the last container of the previous page.
+	containers, err = idx.GetContainersByAddress([]byte{0}, 3, 2)
+	require.NoError(err)
+	require.Len(containers, 1)
+	require.Equal(containerIDs[4], containers[0].ID)
+
+	containers, err = idx.GetContainersByType("bb", 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 3)
+	require.Equal(containerIDs[1], containers[0].ID)
+	require.Equal(containerIDs[3], containers[1].ID)
+	require.Equal(containerIDs[5], containers[2].ID)
+
+	// Types that are prefixes of other types don't match them.
+	containers, err = idx.GetContainersByType("b", 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Empty(containers)
+
+	_, err = idx.GetContainersByType("a", 0, MaxFetchedByRange+1)
+	require.ErrorIs(err, errNumToFetchInvalid)
+
+	// Indices without a parser can't be queried by attributes.
+	require.NoError(idx.Close())
+	db = memdb.New()
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
+	require.NoError(err)
+	_, err = idx.GetContainersByType("a", 0, 1)
+	require.ErrorIs(err, errNoAttributes)
+}
+
+func TestIndexAttributesOfIndexedContainers(t *testing.T) {
+	require := require.New(t)
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
+	require.NoError(err)
+
+	// Index containers before attributes are indexed.
+	containerIDs := make([]ids.ID, 3)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerIDs[i], []byte{byte(i), 'a'}))
+	}
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, testParser{})
+	require.NoError(err)
+	next, end := idx.AttributesRange()
+	require.Zero(next)
+	require.Equal(uint64(3), end)
+
+	// New containers are indexed by their attributes when they are accepted.
+	newContainerID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, newContainerID, []byte{0, 'a'}))
+	containers, err := idx.GetContainersByType("a", 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 1)
+	require.Equal(newContainerID, containers[0].ID)
+
+	require.NoError(idx.PutAttributes(2))
+	next, end = idx.AttributesRange()
+	require.Equal(uint64(2), next)
+	require.Equal(uint64(3), end)
+	require.NoError(idx.PutAttributes(2))
+	next, end = idx.AttributesRange()
+	require.Equal(uint64(3), next)
+	require.Equal(uint64(3), end)
+
+	containers, err = idx.GetContainersByAddress([]byte{0}, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[0], containers[0].ID)
+	require.Equal(newContainerID, containers[1].ID)
+
+	// The progress persists across restarts.
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, testParser{})
+	require.NoError(err)
+	next, end = idx.AttributesRange()
+	require.Equal(uint64(3), next)
+	require.Equal(uint64(3), end)
+	require.NoError(idx.Close())
+
+	// If attributes aren't indexed during a run, every container must be
+	// indexed by its attributes the next time they are.
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, nil)
+	require.NoError(err)
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{}, testParser{})
+	require.NoError(err)
+	next, end = idx.AttributesRange()
+	require.Zero(next)
+	require.Equal(uint64(4), end)
+}
+
+var errTestWrite = errors.New("non-nil error")
+
+// failingDB fails to write batches while [fail] is true.
+type failingDB struct {
+	database.Database
+
+	fail bool
+}
+
+func (db *failingDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+
+	db *failingDB
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.fail {
+		return errTestWrite
+	}
+	return b.Batch.Write()
+}
+
+func TestPutBackfilledFailureIsNotApplied(t *testing.T) {
+	require := require.New(t)
+	db := &failingDB{Database: memdb.New()}
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{}, testParser{})
+	require.NoError(err)
+	require.NoError(idx.Reserve(3))
+
+	containers := make([]Container, 3)
+	for i := range containers {
+		containers[i] = Container{
+			ID:    ids.GenerateTestID(),
+			Bytes: []byte{byte(i), 'a'},
+		}
+	}
+
+	// None of the containers are indexed if they can't be committed.
+	db.fail = true
+	err = idx.PutBackfilled(containers[:2])
+	require.ErrorIs(err, errTestWrite)
+
+	next, end := idx.BackfillRange()
+	require.Zero(next)
+	require.Equal(uint64(3), end)
+	_, err = idx.GetIndex(containers[0].ID)
+	require.ErrorIs(err, database.ErrNotFound)
+	_, err = idx.GetContainerByIndex(0)
+	require.ErrorIs(err, errNotBackfilled)
+	_, err = idx.indexToContainer.Get(database.PackUInt64(0))
+	require.ErrorIs(err, database.ErrNotFound)
+	addressContainers, err := idx.GetContainersByAddress([]byte{0}, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Empty(addressContainers)
+
+	// Retrying indexes the containers at the same indices.
+	db.fail = false
+	require.NoError(idx.PutBackfilled(containers))
+	next, end = idx.BackfillRange()
+	require.Zero(next)
+	require.Zero(end)
+	for i, container := range containers {
+		index, err := idx.GetIndex(container.ID)
+		require.NoError(err)
+		require.Equal(uint64(i), index)
+	}
+	require.NoError(idx.Close())
+}
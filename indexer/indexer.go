@@ -4,8 +4,10 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
@@ -16,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/index/indexconnect"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -44,15 +47,17 @@ var (
 
 // Config for an indexer
 type Config struct {
-	DB                   database.Database
-	Log                  logging.Logger
-	IndexingEnabled      bool
-	AllowIncompleteIndex bool
-	BlockAcceptorGroup   snow.AcceptorGroup
-	TxAcceptorGroup      snow.AcceptorGroup
-	VertexAcceptorGroup  snow.AcceptorGroup
-	APIServer            server.PathAdder
-	ShutdownF            func()
+	DB                      database.Database
+	Log                     logging.Logger
+	IndexingEnabled         bool
+	AllowIncompleteIndex    bool
+	BackfillIncompleteIndex bool
+	IndexAttributes         bool
+	BlockAcceptorGroup      snow.AcceptorGroup
+	TxAcceptorGroup         snow.AcceptorGroup
+	VertexAcceptorGroup     snow.AcceptorGroup
+	APIServer               server.PathAdder
+	ShutdownF               func()
 }
 
 // Indexer causes accepted containers for a given chain
@@ -67,19 +72,24 @@ type Indexer interface {
 
 // NewIndexer returns a new Indexer and registers a new endpoint on the given API server.
 func NewIndexer(config Config) (Indexer, error) {
+	backfillCtx, cancelBackfills := context.WithCancel(context.Background())
 	indexer := &indexer{
-		log:                  config.Log,
-		db:                   config.DB,
-		allowIncompleteIndex: config.AllowIncompleteIndex,
-		indexingEnabled:      config.IndexingEnabled,
-		blockAcceptorGroup:   config.BlockAcceptorGroup,
-		txAcceptorGroup:      config.TxAcceptorGroup,
-		vertexAcceptorGroup:  config.VertexAcceptorGroup,
-		txIndices:            map[ids.ID]*index{},
-		vtxIndices:           map[ids.ID]*index{},
-		blockIndices:         map[ids.ID]*index{},
-		pathAdder:            config.APIServer,
-		shutdownF:            config.ShutdownF,
+		log:                     config.Log,
+		db:                      config.DB,
+		allowIncompleteIndex:    config.AllowIncompleteIndex,
+		backfillIncompleteIndex: config.BackfillIncompleteIndex,
+		attributesEnabled:       config.IndexAttributes,
+		backfillCtx:             backfillCtx,
+		cancelBackfills:         cancelBackfills,
+		indexingEnabled:         config.IndexingEnabled,
+		blockAcceptorGroup:      config.BlockAcceptorGroup,
+		txAcceptorGroup:         config.TxAcceptorGroup,
+		vertexAcceptorGroup:     config.VertexAcceptorGroup,
+		txIndices:               map[ids.ID]*index{},
+		vtxIndices:              map[ids.ID]*index{},
+		blockIndices:            map[ids.ID]*index{},
+		pathAdder:               config.APIServer,
+		shutdownF:               config.ShutdownF,
 	}
 
 	hasRun, err := indexer.hasRun()
@@ -110,6 +120,16 @@ type indexer struct {
 	// of an index which could be missing accepted containers.
 	allowIncompleteIndex bool
 
+	// If true, backfill incomplete block indices.
+	backfillIncompleteIndex bool
+	// Cancelled when the indexer is closed to stop any ongoing backfills.
+	backfillCtx     context.Context
+	cancelBackfills context.CancelFunc
+
+	// If true, index the containers of the P-Chain and X-Chain by their
+	// attributes.
+	attributesEnabled bool
+
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
@@ -149,8 +169,11 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 
 	chainID := ctx.ChainID
 	if i.blockIndices[chainID] != nil || i.txIndices[chainID] != nil || i.vtxIndices[chainID] != nil {
-		i.log.Warn("chain is already being indexed",
-			zap.Stringer("chainID", chainID),
+		// The chain was restarted. Its containers are still indexed by the
+		// acceptors that were registered when it was first created.
+		i.log.Debug("not registering chain to indexer",
+			zap.String("reason", "chain is already being indexed"),
+			zap.String("chainName", chainName),
 		)
 		return
 	}
@@ -217,7 +240,27 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	if !i.allowIncompleteIndex && isIncomplete && (previouslyIndexed || i.hasRunBefore) {
+	// If the index is incomplete, try to fill in the missing containers from
+	// the chain's accepted history.
+	var (
+		backfill           bool
+		numToReserve       uint64
+		chainVM, isChainVM = vm.(block.ChainVM)
+		_, isDAGVM         = vm.(vertex.DAGVM)
+	)
+	if i.backfillIncompleteIndex && isIncomplete && isChainVM && !isDAGVM {
+		numToReserve, err = i.prepareBackfill(ctx, chainVM)
+		if err != nil {
+			i.log.Warn("can't backfill index",
+				zap.String("chainName", chainName),
+				zap.Error(err),
+			)
+		} else {
+			backfill = true
+		}
+	}
+
+	if !i.allowIncompleteIndex && !backfill && isIncomplete && (previouslyIndexed || i.hasRunBefore) {
 		i.log.Fatal("index is incomplete but incomplete indices are disabled. Shutting down",
 			zap.String("chainName", chainName),
 		)
@@ -243,7 +286,24 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 
-	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup)
+	var blockParser, txParser AttributeParser
+	if i.attributesEnabled {
+		blockParser, txParser, err = attributeParsers(ctx)
+		if err != nil {
+			i.log.Fatal("couldn't create attribute parsers",
+				zap.String("chainName", chainName),
+				zap.Error(err),
+			)
+			if err := i.close(); err != nil {
+				i.log.Error("failed to close indexer",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+	}
+
+	index, err := i.registerChainHelper(chainID, blockPrefix, chainName, "block", i.blockAcceptorGroup, blockParser)
 	if err != nil {
 		i.log.Fatal("failed to create index",
 			zap.String("chainName", chainName),
@@ -258,10 +318,27 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		return
 	}
 	i.blockIndices[chainID] = index
+	go i.indexAttributes(chainName, "block", index)
+
+	if backfill {
+		if err := index.Reserve(numToReserve); err != nil {
+			i.log.Fatal("couldn't reserve indices to backfill",
+				zap.String("chainName", chainName),
+				zap.Error(err),
+			)
+			if err := i.close(); err != nil {
+				i.log.Error("failed to close indexer",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		go i.backfill(chainName, ctx, chainVM, index)
+	}
 
 	switch vm.(type) {
 	case vertex.DAGVM:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, chainName, "vtx", i.vertexAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -277,7 +354,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, chainName, "tx", i.txAcceptorGroup, txParser)
 		if err != nil {
 			i.log.Fatal("couldn't create index",
 				zap.String("chainName", chainName),
@@ -292,6 +369,7 @@ func (i *indexer) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 			return
 		}
 		i.txIndices[chainID] = txIndex
+		go i.indexAttributes(chainName, "tx", txIndex)
 	case block.ChainVM:
 	default:
 		vmType := fmt.Sprintf("%T", vm)
@@ -311,12 +389,10 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	parser AttributeParser,
 ) (*index, error) {
-	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
-	copy(prefix, chainID[:])
-	prefix[ids.IDLen] = prefixEnd
-	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.clock)
+	indexDB := i.indexDB(chainID, prefixEnd)
+	index, err := newIndex(indexDB, i.log, i.clock, parser)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
@@ -337,13 +413,34 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
-	if err := i.pathAdder.AddRoute(apiServer, "index/"+name, "/"+endpoint); err != nil {
+	var (
+		base         = "index/" + name
+		endpointPath = "/" + endpoint
+	)
+	if err := i.pathAdder.AddRoute(apiServer, base, endpointPath); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
+
+	// The Connect service is added under the same base as the JSON-RPC
+	// service so that both are guarded by the same API policy.
+	connectPath, connectHandler := indexconnect.NewIndexHandler(&connectService{index: index})
+	connectHandler = http.StripPrefix(endpointPath, connectHandler)
+	if err := i.pathAdder.AddRoute(connectHandler, base, endpointPath+connectPath); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
 	return index, nil
 }
 
+// Returns the database of the index of [chainID] with [prefixEnd].
+func (i *indexer) indexDB(chainID ids.ID, prefixEnd byte) database.Database {
+	prefix := make([]byte, ids.IDLen+wrappers.ByteLen)
+	copy(prefix, chainID[:])
+	prefix[ids.IDLen] = prefixEnd
+	return prefixdb.New(prefix, i.db)
+}
+
 // Close this indexer. Stops indexing all chains.
 // Closes [i.db]. Assumes Close is only called after
 // the node is done making decisions.
@@ -360,6 +457,7 @@ func (i *indexer) close() error {
 		return nil
 	}
 	i.closed = true
+	i.cancelBackfills()
 
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
@@ -393,6 +491,13 @@ func (i *indexer) markIncomplete(chainID ids.ID) error {
 	return i.db.Put(key, nil)
 }
 
+func (i *indexer) markComplete(chainID ids.ID) error {
+	key := make([]byte, ids.IDLen+wrappers.ByteLen)
+	copy(key, chainID[:])
+	key[ids.IDLen] = isIncompletePrefix
+	return i.db.Delete(key)
+}
+
 // Returns true if this chain is incomplete
 func (i *indexer) isIncomplete(chainID ids.ID) (bool, error) {
 	key := make([]byte, ids.IDLen+wrappers.ByteLen)
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sync"
@@ -14,10 +15,13 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/snowtest"
@@ -170,9 +174,11 @@ func TestIndexer(t *testing.T) {
 	previouslyIndexed, err = idxr.previouslyIndexed(chain1Ctx.ChainID)
 	require.NoError(err)
 	require.True(previouslyIndexed)
-	require.Equal(1, server.timesCalled)
+	require.Equal(2, server.timesCalled) // JSON-RPC and Connect handlers
 	require.Equal("index/chain1", server.bases[0])
 	require.Equal("/block", server.endpoints[0])
+	require.Equal("index/chain1", server.bases[1])
+	require.Equal("/block/index.Index/", server.endpoints[1])
 	require.Len(idxr.blockIndices, 1)
 	require.Empty(idxr.txIndices)
 	require.Empty(idxr.vtxIndices)
@@ -253,7 +259,7 @@ func TestIndexer(t *testing.T) {
 	container, err = blkIdx.GetLastAccepted()
 	require.NoError(err)
 	require.Equal(blkID, container.ID)
-	require.Equal(1, server.timesCalled) // block index for chain
+	require.Equal(2, server.timesCalled) // block index for chain
 	require.Contains(server.endpoints, "/block")
 
 	// Register a DAG chain
@@ -268,7 +274,7 @@ func TestIndexer(t *testing.T) {
 	dagVM := vertex.NewMockLinearizableVM(ctrl)
 	idxr.RegisterChain("chain2", chain2Ctx, dagVM)
 	require.NoError(err)
-	require.Equal(4, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index
+	require.Equal(8, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/block")
 	require.Contains(server.endpoints, "/vtx")
@@ -510,3 +516,170 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
 	require.Empty(idxr.blockIndices)
 }
+
+func TestBackfillIncompleteIndex(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	// Create an indexer with indexing disabled and register a chain so that
+	// its index is incomplete.
+	baseDB := memdb.New()
+	config := Config{
+		IndexingEnabled:      false,
+		AllowIncompleteIndex: false,
+		Log:                  logging.NoLog{},
+		DB:                   versiondb.New(baseDB),
+		BlockAcceptorGroup:   snow.NewAcceptorGroup(logging.NoLog{}),
+		TxAcceptorGroup:      snow.NewAcceptorGroup(logging.NoLog{}),
+		VertexAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:            &apiServerMock{},
+		ShutdownF:            func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+
+	snow1Ctx := snowtest.Context(t, snowtest.CChainID)
+	chain1Ctx := snowtest.ConsensusContext(snow1Ctx)
+	chainVM := block.NewMockChainVM(ctrl)
+	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	isIncomplete, err := idxr.isIncomplete(chain1Ctx.ChainID)
+	require.NoError(err)
+	require.True(isIncomplete)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// Create the accepted history of the chain.
+	const numBlocks = backfillBatchSize + 10
+	blocks := make([]*snowman.TestBlock, numBlocks+1)
+	for height := range blocks {
+		blocks[height] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			HeightV:    uint64(height),
+			TimestampV: time.Unix(int64(height), 0),
+			BytesV:     utils.RandomBytes(32),
+		}
+	}
+	blocksByID := make(map[ids.ID]*snowman.TestBlock)
+	for _, blk := range blocks {
+		blocksByID[blk.ID()] = blk
+	}
+	chainVM.EXPECT().VerifyHeightIndex(gomock.Any()).Return(nil).AnyTimes()
+	chainVM.EXPECT().LastAccepted(gomock.Any()).Return(blocks[numBlocks].ID(), nil).AnyTimes()
+	chainVM.EXPECT().GetBlockIDAtHeight(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, height uint64) (ids.ID, error) {
+			return blocks[height].ID(), nil
+		},
+	).AnyTimes()
+	chainVM.EXPECT().GetBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			return blocksByID[blkID], nil
+		},
+	).AnyTimes()
+
+	// Re-open the indexer with indexing and backfilling enabled.
+	config.IndexingEnabled = true
+	config.BackfillIncompleteIndex = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+	now := time.Now()
+	idxr.clock.Set(now)
+
+	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	require.False(idxr.closed)
+	blkIdx := idxr.blockIndices[chain1Ctx.ChainID]
+	require.NotNil(blkIdx)
+
+	// Newly accepted blocks are indexed after the backfilled blocks.
+	blkID, blkBytes := ids.GenerateTestID(), utils.RandomBytes(32)
+	require.NoError(config.BlockAcceptorGroup.Accept(chain1Ctx, blkID, blkBytes))
+	index, err := blkIdx.GetIndex(blkID)
+	require.NoError(err)
+	require.Equal(uint64(numBlocks), index)
+
+	require.Eventually(func() bool {
+		isIncomplete, err := idxr.isIncomplete(chain1Ctx.ChainID)
+		return err == nil && !isIncomplete
+	}, 10*time.Second, 10*time.Millisecond)
+
+	start, end := blkIdx.BackfillRange()
+	require.Zero(start)
+	require.Zero(end)
+
+	// The block at height h is at index h-1.
+	for height := uint64(1); height <= numBlocks; height++ {
+		container, err := blkIdx.GetContainerByIndex(height - 1)
+		require.NoError(err)
+		require.Equal(Container{
+			ID:        blocks[height].ID(),
+			Bytes:     blocks[height].Bytes(),
+			Timestamp: blocks[height].Timestamp().UnixNano(),
+		}, container)
+	}
+
+	lastAccepted, err := blkIdx.GetLastAccepted()
+	require.NoError(err)
+	require.Equal(Container{
+		ID:        blkID,
+		Bytes:     blkBytes,
+		Timestamp: now.UnixNano(),
+	}, lastAccepted)
+	require.NoError(idxr.Close())
+}
+
+func TestBackfillIncompleteIndexUnavailableHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	baseDB := memdb.New()
+	config := Config{
+		IndexingEnabled:      false,
+		AllowIncompleteIndex: false,
+		Log:                  logging.NoLog{},
+		DB:                   versiondb.New(baseDB),
+		BlockAcceptorGroup:   snow.NewAcceptorGroup(logging.NoLog{}),
+		TxAcceptorGroup:      snow.NewAcceptorGroup(logging.NoLog{}),
+		VertexAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:            &apiServerMock{},
+		ShutdownF:            func() {},
+	}
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr := idxrIntf.(*indexer)
+
+	snow1Ctx := snowtest.Context(t, snowtest.CChainID)
+	chain1Ctx := snowtest.ConsensusContext(snow1Ctx)
+	chainVM := block.NewMockChainVM(ctrl)
+	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	require.NoError(config.DB.(*versiondb.Database).Commit())
+	require.NoError(idxr.Close())
+
+	// The chain was state synced, so the oldest blocks aren't available.
+	lastAccepted := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 100,
+	}
+	chainVM.EXPECT().VerifyHeightIndex(gomock.Any()).Return(nil)
+	chainVM.EXPECT().LastAccepted(gomock.Any()).Return(lastAccepted.ID(), nil)
+	chainVM.EXPECT().GetBlock(gomock.Any(), lastAccepted.ID()).Return(lastAccepted, nil)
+	chainVM.EXPECT().GetBlockIDAtHeight(gomock.Any(), uint64(1)).Return(ids.Empty, database.ErrNotFound)
+
+	config.IndexingEnabled = true
+	config.BackfillIncompleteIndex = true
+	config.DB = versiondb.New(baseDB)
+	idxrIntf, err = NewIndexer(config)
+	require.NoError(err)
+	idxr = idxrIntf.(*indexer)
+
+	// The index can't be backfilled and incomplete indices aren't allowed.
+	idxr.RegisterChain("chain1", chain1Ctx, chainVM)
+	require.True(idxr.closed)
+}
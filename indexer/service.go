@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 )
 
@@ -96,14 +97,63 @@ func (s *service) GetContainerRange(_ *http.Request, args *GetContainerRangeArgs
 	if err != nil {
 		return err
 	}
+	return s.formatContainers(containers, args.Encoding, reply)
+}
+
+type GetContainersByAddressArgs struct {
+	Address    string              `json:"address"`
+	StartIndex json.Uint64         `json:"startIndex"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// GetContainersByAddress returns up to [numToFetch] containers that send funds
+// or ownership to [address], in the order they were accepted, starting with the
+// first one at or after [startIndex]. Containers that only spend funds owned by
+// [address] aren't returned.
+// If [numToFetch] > [MaxFetchedByRange], returns an error.
+// If containers aren't indexed by their attributes, returns an error.
+func (s *service) GetContainersByAddress(_ *http.Request, args *GetContainersByAddressArgs, reply *GetContainerRangeResponse) error {
+	addr, err := address.ParseToID(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+	containers, err := s.index.GetContainersByAddress(addr[:], uint64(args.StartIndex), uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+	return s.formatContainers(containers, args.Encoding, reply)
+}
+
+type GetContainersByTypeArgs struct {
+	Type       string              `json:"type"`
+	StartIndex json.Uint64         `json:"startIndex"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+// GetContainersByType returns up to [numToFetch] containers that include a
+// transaction of type [type], such as "AddPermissionlessValidatorTx", in the
+// order they were accepted, starting with the first one at or after
+// [startIndex].
+// If [numToFetch] > [MaxFetchedByRange], returns an error.
+// If containers aren't indexed by their attributes, returns an error.
+func (s *service) GetContainersByType(_ *http.Request, args *GetContainersByTypeArgs, reply *GetContainerRangeResponse) error {
+	containers, err := s.index.GetContainersByType(args.Type, uint64(args.StartIndex), uint64(args.NumToFetch))
+	if err != nil {
+		return err
+	}
+	return s.formatContainers(containers, args.Encoding, reply)
+}
 
+func (s *service) formatContainers(containers []Container, enc formatting.Encoding, reply *GetContainerRangeResponse) error {
 	reply.Containers = make([]FormattedContainer, len(containers))
 	for i, container := range containers {
 		index, err := s.index.GetIndex(container.ID)
 		if err != nil {
 			return fmt.Errorf("couldn't get index: %w", err)
 		}
-		reply.Containers[i], err = newFormattedContainer(container, index, args.Encoding)
+		reply.Containers[i], err = newFormattedContainer(container, index, enc)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import "github.com/ava-labs/avalanchego/utils/compression"
+
+// CompressionConfig describes how outbound messages are compressed.
+type CompressionConfig struct {
+	// Type is the compression type of outbound messages that are compressed
+	// by default. Small messages, such as pings and handshakes, are never
+	// compressed by default.
+	Type compression.Type `json:"type"`
+
+	// OpTypes overrides the compression type of the outbound messages of the
+	// provided ops.
+	OpTypes map[Op]compression.Type `json:"opTypes"`
+
+	// ZstdDictionaries maps ops to the zstd dictionary that the outbound
+	// messages of the op are compressed with, if the messages are compressed
+	// with zstd. The dictionaries must be in the zstd dictionary format, as
+	// produced by `zstd --train`.
+	//
+	// The IDs of the dictionaries are advertised in the handshake. Messages
+	// are only compressed with a dictionary when they are sent to peers that
+	// advertised the dictionary. Inbound messages compressed with any of the
+	// dictionaries can be decompressed.
+	ZstdDictionaries map[Op][]byte `json:"-"`
+}
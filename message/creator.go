@@ -8,7 +8,6 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
@@ -29,7 +28,7 @@ func NewCreator(
 	log logging.Logger,
 	metrics prometheus.Registerer,
 	parentNamespace string,
-	compressionType compression.Type,
+	compressionConfig CompressionConfig,
 	maxMessageTimeout time.Duration,
 ) (Creator, error) {
 	namespace := metric.AppendNamespace(parentNamespace, "codec")
@@ -37,6 +36,7 @@ func NewCreator(
 		log,
 		namespace,
 		metrics,
+		compressionConfig,
 		maxMessageTimeout,
 	)
 	if err != nil {
@@ -44,7 +44,7 @@ func NewCreator(
 	}
 
 	return &creator{
-		OutboundMsgBuilder: newOutboundBuilder(compressionType, builder),
+		OutboundMsgBuilder: newOutboundBuilder(compressionConfig.Type, builder),
 		InboundMsgBuilder:  newInboundBuilder(builder),
 	}, nil
 }
@@ -25,6 +25,7 @@ func Test_newMsgBuilder(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -395,6 +396,7 @@ func TestAppError(t *testing.T) {
 		logging.NoLog{},
 		"",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		time.Second,
 	)
 	require.NoError(err)
@@ -4,8 +4,11 @@
 package message
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,7 +29,9 @@ var (
 	_ InboundMessage  = (*inboundMessage)(nil)
 	_ OutboundMessage = (*outboundMessage)(nil)
 
-	errUnknownCompressionType = errors.New("message is compressed with an unknown compression type")
+	errUnknownCompressionType      = errors.New("message is compressed with an unknown compression type")
+	errUnknownZstdDictionary       = errors.New("message is compressed with an unknown zstd dictionary")
+	errConflictingZstdDictionaries = errors.New("different zstd dictionaries with the same ID")
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -102,6 +107,14 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// ZstdDictionary returns the ID of the zstd dictionary that this message
+	// was compressed with, or 0 if this message wasn't compressed with a
+	// dictionary
+	ZstdDictionary() uint32
+	// WithoutZstdDictionary returns this message compressed without a zstd
+	// dictionary, to be sent to peers that don't support the dictionary. If
+	// this message wasn't compressed with a dictionary, it is returned as is.
+	WithoutZstdDictionary() (OutboundMessage, error)
 }
 
 type outboundMessage struct {
@@ -109,6 +122,11 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+
+	zstdDictionary uint32
+	// withoutZstdDictionary lazily compresses the message without its
+	// dictionary. Only set if [zstdDictionary] isn't 0.
+	withoutZstdDictionary func() (OutboundMessage, error)
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -127,14 +145,41 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
+func (m *outboundMessage) ZstdDictionary() uint32 {
+	return m.zstdDictionary
+}
+
+func (m *outboundMessage) WithoutZstdDictionary() (OutboundMessage, error) {
+	if m.withoutZstdDictionary == nil {
+		return m, nil
+	}
+	return m.withoutZstdDictionary()
+}
+
 // TODO: add other compression algorithms with extended interface
 type msgBuilder struct {
 	log logging.Logger
 
+	// opCompressionTypes overrides the compression type of outbound messages
+	opCompressionTypes map[Op]compression.Type
+
 	zstdCompressor            compression.Compressor
 	zstdCompressTimeMetrics   map[Op]metric.Averager
 	zstdDecompressTimeMetrics map[Op]metric.Averager
 
+	// zstdDictionaryCompressors maps dictionary IDs to the compressor that
+	// uses the dictionary
+	zstdDictionaryCompressors map[uint32]compression.Compressor
+	// zstdDictionaryIDs is the sorted list of the keys of
+	// [zstdDictionaryCompressors]
+	zstdDictionaryIDs []uint32
+	// opZstdDictionaries maps ops to the ID of the dictionary that outbound
+	// messages of the op are compressed with
+	opZstdDictionaries map[Op]uint32
+
+	compressionRatioMetrics   map[Op]metric.Averager
+	decompressionRatioMetrics map[Op]metric.Averager
+
 	maxMessageTimeout time.Duration
 }
 
@@ -142,6 +187,7 @@ func newMsgBuilder(
 	log logging.Logger,
 	namespace string,
 	metrics prometheus.Registerer,
+	compressionConfig CompressionConfig,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
 	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
@@ -152,13 +198,43 @@ func newMsgBuilder(
 	mb := &msgBuilder{
 		log: log,
 
+		opCompressionTypes: compressionConfig.OpTypes,
+
 		zstdCompressor:            zstdCompressor,
 		zstdCompressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
 		zstdDecompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
 
+		zstdDictionaryCompressors: make(map[uint32]compression.Compressor),
+		opZstdDictionaries:        make(map[Op]uint32, len(compressionConfig.ZstdDictionaries)),
+
+		compressionRatioMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
+		decompressionRatioMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
+	dictionaries := make(map[uint32][]byte)
+	for op, dictionary := range compressionConfig.ZstdDictionaries {
+		id, err := compression.ZstdDictionaryID(dictionary)
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd dictionary for %s: %w", op, err)
+		}
+		if existing, ok := dictionaries[id]; ok && !bytes.Equal(existing, dictionary) {
+			return nil, fmt.Errorf("%w: %d", errConflictingZstdDictionaries, id)
+		}
+		dictionaries[id] = dictionary
+		mb.opZstdDictionaries[op] = id
+	}
+	for id, dictionary := range dictionaries {
+		compressor, err := compression.NewZstdDictionaryCompressor(constants.DefaultMaxMessageSize, dictionary)
+		if err != nil {
+			return nil, err
+		}
+		mb.zstdDictionaryCompressors[id] = compressor
+		mb.zstdDictionaryIDs = append(mb.zstdDictionaryIDs, id)
+	}
+	slices.Sort(mb.zstdDictionaryIDs)
+
 	errs := wrappers.Errs{}
 	for _, op := range ExternalOps {
 		mb.zstdCompressTimeMetrics[op] = metric.NewAveragerWithErrs(
@@ -175,22 +251,33 @@ func newMsgBuilder(
 			metrics,
 			&errs,
 		)
+		mb.compressionRatioMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_compression_ratio", op),
+			fmt.Sprintf("ratio of the uncompressed size to the compressed size of outbound compressed %s messages", op),
+			metrics,
+			&errs,
+		)
+		mb.decompressionRatioMetrics[op] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_decompression_ratio", op),
+			fmt.Sprintf("ratio of the uncompressed size to the compressed size of inbound compressed %s messages", op),
+			metrics,
+			&errs,
+		)
 	}
 	return mb, errs.Err
 }
 
 func (mb *msgBuilder) marshal(
 	uncompressedMsg *p2p.Message,
+	op Op,
 	compressionType compression.Type,
-) ([]byte, int, Op, error) {
+	zstdDictionary uint32,
+) ([]byte, int, error) {
 	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
-	}
-
-	op, err := ToOp(uncompressedMsg)
-	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 
 	// If compression is enabled, we marshal twice:
@@ -206,11 +293,17 @@ func (mb *msgBuilder) marshal(
 	)
 	switch compressionType {
 	case compression.TypeNone:
-		return uncompressedMsgBytes, 0, op, nil
+		return uncompressedMsgBytes, 0, nil
 	case compression.TypeZstd:
-		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
+		// Messages compressed with a dictionary are still sent as zstd
+		// messages. The ID of the dictionary is included in the zstd frame.
+		compressor := mb.zstdCompressor
+		if zstdDictionary != 0 {
+			compressor = mb.zstdDictionaryCompressors[zstdDictionary]
+		}
+		compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
 		if err != nil {
-			return nil, 0, 0, err
+			return nil, 0, err
 		}
 		compressedMsg = p2p.Message{
 			Message: &p2p.Message_CompressedZstd{
@@ -219,12 +312,12 @@ func (mb *msgBuilder) marshal(
 		}
 		opToCompressTimeMetrics = mb.zstdCompressTimeMetrics
 	default:
-		return nil, 0, 0, errUnknownCompressionType
+		return nil, 0, errUnknownCompressionType
 	}
 
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, err
 	}
 	compressTook := time.Since(startTime)
 
@@ -237,9 +330,12 @@ func (mb *msgBuilder) marshal(
 			zap.Stringer("compressionType", compressionType),
 		)
 	}
+	if compressionRatioMetric, ok := mb.compressionRatioMetrics[op]; ok {
+		compressionRatioMetric.Observe(float64(len(uncompressedMsgBytes)) / float64(len(compressedMsgBytes)))
+	}
 
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
-	return compressedMsgBytes, bytesSaved, op, nil
+	return compressedMsgBytes, bytesSaved, nil
 }
 
 func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
@@ -257,8 +353,20 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	)
 	switch {
 	case len(zstdCompressed) > 0:
+		zstdDictionary, err := compression.ZstdFrameDictionaryID(zstdCompressed)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
 		opToDecompressTimeMetrics = mb.zstdDecompressTimeMetrics
 		compressor = mb.zstdCompressor
+		if zstdDictionary != 0 {
+			var ok bool
+			compressor, ok = mb.zstdDictionaryCompressors[zstdDictionary]
+			if !ok {
+				return nil, 0, 0, fmt.Errorf("%w: %d", errUnknownZstdDictionary, zstdDictionary)
+			}
+		}
 		compressedBytes = zstdCompressed
 	default:
 		// The message wasn't compressed
@@ -292,22 +400,54 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 			zap.Stringer("op", op),
 		)
 	}
+	if decompressionRatioMetric, ok := mb.decompressionRatioMetrics[op]; ok {
+		decompressionRatioMetric.Observe(float64(len(decompressed)) / float64(len(b)))
+	}
 
 	return m, bytesSavedCompression, op, nil
 }
 
 func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, op, err := mb.marshal(m, compressionType)
+	op, err := ToOp(m)
 	if err != nil {
 		return nil, err
 	}
+	if opCompressionType, ok := mb.opCompressionTypes[op]; ok {
+		compressionType = opCompressionType
+	}
 
-	return &outboundMessage{
+	var zstdDictionary uint32
+	if compressionType == compression.TypeZstd {
+		zstdDictionary = mb.opZstdDictionaries[op]
+	}
+
+	b, saved, err := mb.marshal(m, op, compressionType, zstdDictionary)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
-	}, nil
+		zstdDictionary:        zstdDictionary,
+	}
+	if zstdDictionary != 0 {
+		msg.withoutZstdDictionary = sync.OnceValues(func() (OutboundMessage, error) {
+			b, saved, err := mb.marshal(m, op, compressionType, 0)
+			if err != nil {
+				return nil, err
+			}
+			return &outboundMessage{
+				bypassThrottling:      bypassThrottling,
+				op:                    op,
+				bytes:                 b,
+				bytesSavedCompression: saved,
+			}, nil
+		})
+	}
+	return msg, nil
 }
 
 func (mb *msgBuilder) parseInbound(
@@ -6,6 +6,8 @@ package message
 import (
 	"bytes"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ func TestMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		5*time.Second,
 	)
 	require.NoError(t, err)
@@ -692,6 +695,7 @@ func TestInboundMessageToString(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -725,6 +729,7 @@ func TestEmptyInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -746,6 +751,7 @@ func TestNilInboundMessage(t *testing.T) {
 		logging.NoLog{},
 		"test",
 		prometheus.NewRegistry(),
+		CompressionConfig{},
 		5*time.Second,
 	)
 	require.NoError(err)
@@ -765,3 +771,184 @@ func TestNilInboundMessage(t *testing.T) {
 	pingMsg := parsedMsg.message.(*p2p.Ping)
 	require.NotNil(pingMsg)
 }
+
+func TestOpCompressionTypes(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		CompressionConfig{
+			OpTypes: map[Op]compression.Type{
+				PingOp:      compression.TypeZstd,
+				AppGossipOp: compression.TypeNone,
+			},
+		},
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	tests := []struct {
+		msg             *p2p.Message
+		compressionType compression.Type
+		expectedZstd    bool
+	}{
+		{
+			msg: &p2p.Message{
+				Message: &p2p.Message_Ping{
+					Ping: &p2p.Ping{
+						Uptime: 100,
+					},
+				},
+			},
+			compressionType: compression.TypeNone,
+			expectedZstd:    true,
+		},
+		{
+			msg: &p2p.Message{
+				Message: &p2p.Message_AppGossip{
+					AppGossip: &p2p.AppGossip{
+						AppBytes: bytes.Repeat([]byte{0}, 100),
+					},
+				},
+			},
+			compressionType: compression.TypeZstd,
+			expectedZstd:    false,
+		},
+		{
+			msg: &p2p.Message{
+				Message: &p2p.Message_Put{
+					Put: &p2p.Put{
+						Container: bytes.Repeat([]byte{0}, 100),
+					},
+				},
+			},
+			compressionType: compression.TypeZstd,
+			expectedZstd:    true,
+		},
+	}
+	for _, test := range tests {
+		outboundMsg, err := mb.createOutbound(test.msg, test.compressionType, false)
+		require.NoError(err)
+
+		msg := &p2p.Message{}
+		require.NoError(proto.Unmarshal(outboundMsg.Bytes(), msg))
+		require.Equal(test.expectedZstd, len(msg.GetCompressedZstd()) > 0)
+
+		inboundMsg, err := mb.parseInbound(outboundMsg.Bytes(), ids.EmptyNodeID, func() {})
+		require.NoError(err)
+		require.Equal(outboundMsg.Op(), inboundMsg.Op())
+	}
+}
+
+func TestZstdDictionary(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	dictionary, err := os.ReadFile(filepath.Join("..", "utils", "compression", "zstd_dictionary.bin"))
+	require.NoError(err)
+	dictionaryID, err := compression.ZstdDictionaryID(dictionary)
+	require.NoError(err)
+
+	dictionaryBuilder, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		CompressionConfig{
+			ZstdDictionaries: map[Op][]byte{
+				AppGossipOp: dictionary,
+			},
+		},
+		5*time.Second,
+	)
+	require.NoError(err)
+	require.Equal([]uint32{dictionaryID}, dictionaryBuilder.zstdDictionaryIDs)
+
+	plainBuilder, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		CompressionConfig{},
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	appGossip := &p2p.Message{
+		Message: &p2p.Message_AppGossip{
+			AppGossip: &p2p.AppGossip{
+				AppBytes: []byte(`{"type": "gossip", "chain": "C", "txs": [{"nonce": 42, "gasPrice": "25000000000"}]}`),
+			},
+		},
+	}
+
+	// Messages that aren't compressed with zstd don't use the dictionary.
+	outboundMsg, err := dictionaryBuilder.createOutbound(appGossip, compression.TypeNone, false)
+	require.NoError(err)
+	require.Zero(outboundMsg.ZstdDictionary())
+
+	outboundMsg, err = dictionaryBuilder.createOutbound(appGossip, compression.TypeZstd, false)
+	require.NoError(err)
+	require.Equal(dictionaryID, outboundMsg.ZstdDictionary())
+
+	inboundMsg, err := dictionaryBuilder.parseInbound(outboundMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(AppGossipOp, inboundMsg.Op())
+
+	_, err = plainBuilder.parseInbound(outboundMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.ErrorIs(err, errUnknownZstdDictionary)
+
+	fallbackMsg, err := outboundMsg.WithoutZstdDictionary()
+	require.NoError(err)
+	require.Zero(fallbackMsg.ZstdDictionary())
+	require.Equal(AppGossipOp, fallbackMsg.Op())
+
+	inboundMsg, err = plainBuilder.parseInbound(fallbackMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(AppGossipOp, inboundMsg.Op())
+
+	// The fallback is only compressed once.
+	fallbackMsg2, err := outboundMsg.WithoutZstdDictionary()
+	require.NoError(err)
+	require.Same(fallbackMsg, fallbackMsg2)
+
+	// Messages without a dictionary are their own fallback.
+	sameMsg, err := fallbackMsg.WithoutZstdDictionary()
+	require.NoError(err)
+	require.Same(fallbackMsg, sameMsg)
+}
+
+func TestZstdDictionaryInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		CompressionConfig{
+			ZstdDictionaries: map[Op][]byte{
+				AppGossipOp: []byte("not a dictionary"),
+			},
+		},
+		5*time.Second,
+	)
+	require.ErrorIs(t, err, compression.ErrInvalidZstdDictionary)
+}
+
+func TestOpFromString(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	for _, op := range ExternalOps {
+		parsedOp, err := OpFromString(op.String())
+		require.NoError(err)
+		require.Equal(op, parsedOp)
+	}
+
+	_, err := OpFromString(TimeoutOp.String())
+	require.ErrorIs(err, errUnknownOp)
+}
@@ -93,3 +93,32 @@ func (mr *MockOutboundMessageMockRecorder) Op() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Op", reflect.TypeOf((*MockOutboundMessage)(nil).Op))
 }
+
+// WithoutZstdDictionary mocks base method.
+func (m *MockOutboundMessage) WithoutZstdDictionary() (OutboundMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithoutZstdDictionary")
+	ret0, _ := ret[0].(OutboundMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithoutZstdDictionary indicates an expected call of WithoutZstdDictionary.
+func (mr *MockOutboundMessageMockRecorder) WithoutZstdDictionary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithoutZstdDictionary", reflect.TypeOf((*MockOutboundMessage)(nil).WithoutZstdDictionary))
+}
+
+// ZstdDictionary mocks base method.
+func (m *MockOutboundMessage) ZstdDictionary() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ZstdDictionary")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// ZstdDictionary indicates an expected call of ZstdDictionary.
+func (mr *MockOutboundMessageMockRecorder) ZstdDictionary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ZstdDictionary", reflect.TypeOf((*MockOutboundMessage)(nil).ZstdDictionary))
+}
@@ -200,6 +200,7 @@ var (
 	)
 
 	errUnknownMessageType = errors.New("unknown message type")
+	errUnknownOp          = errors.New("unknown op")
 )
 
 func (op Op) String() string {
@@ -296,6 +297,17 @@ func (op Op) String() string {
 	}
 }
 
+// OpFromString returns the op of the messages sent between nodes that is named
+// [s].
+func OpFromString(s string) (Op, error) {
+	for _, op := range ExternalOps {
+		if op.String() == s {
+			return op, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownOp, s)
+}
+
 func Unwrap(m *p2p.Message) (fmt.Stringer, error) {
 	switch msg := m.GetMessage().(type) {
 	// Handshake:
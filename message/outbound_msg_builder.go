@@ -276,7 +276,8 @@ func (b *outMsgBuilder) Handshake(
 						Filter: knownPeersFilter,
 						Salt:   knownPeersSalt,
 					},
-					IpBlsSig: ipBLSSig,
+					IpBlsSig:         ipBLSSig,
+					ZstdDictionaries: b.builder.zstdDictionaryIDs,
 				},
 			},
 		},
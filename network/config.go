@@ -8,9 +8,13 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -92,6 +96,7 @@ type ThrottlerConfig struct {
 	InboundConnUpgradeThrottlerConfig throttling.InboundConnUpgradeThrottlerConfig `json:"inboundConnUpgradeThrottlerConfig"`
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
 	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
+	OutboundMsgQueueConfig            peer.MessageQueueConfig                      `json:"outboundMsgQueueConfig"`
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
 }
 
@@ -100,7 +105,8 @@ type Config struct {
 	PeerListGossipConfig `json:"peerListGossipConfig"`
 	TimeoutConfig        `json:"timeoutConfigs"`
 	DelayConfig          `json:"delayConfig"`
-	ThrottlerConfig      ThrottlerConfig `json:"throttlerConfig"`
+	ThrottlerConfig      ThrottlerConfig         `json:"throttlerConfig"`
+	PeerScoreConfig      tracker.PeerScoreConfig `json:"peerScoreConfig"`
 
 	ProxyEnabled           bool          `json:"proxyEnabled"`
 	ProxyReadHeaderTimeout time.Duration `json:"proxyReadHeaderTimeout"`
@@ -125,6 +131,19 @@ type Config struct {
 	// Assumes all peers support this compression type.
 	CompressionType compression.Type `json:"compressionType"`
 
+	// OpCompressionTypes overrides the compression type of the outbound
+	// messages of the provided ops.
+	OpCompressionTypes map[message.Op]compression.Type `json:"opCompressionTypes"`
+
+	// ZstdDictionaries maps ops to the zstd dictionary that the outbound
+	// messages of the op are compressed with, when they are sent to peers that
+	// support the dictionary.
+	ZstdDictionaries map[message.Op][]byte `json:"-"`
+
+	// Transport is the transport used to connect to peers.
+	// Assumes all peers use this transport.
+	Transport transport.Type `json:"transport"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 	// BLSKey is this node's BLS key that is used to sign IPs.
@@ -176,4 +195,12 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// Scores peers based on their observed behavior. Peers with higher scores
+	// are preferred when gossiping and reconnecting.
+	PeerScorer tracker.PeerScorer `json:"-"`
+
+	// ManagedPeersDB persists the peers that were banned or pinned by the
+	// operator. If nil, bans and pins are only kept in memory.
+	ManagedPeersDB database.Database `json:"-"`
 }
@@ -120,6 +120,22 @@ func (i *ipTracker) ManuallyTrack(nodeID ids.NodeID) {
 	i.manuallyTracked.Add(nodeID)
 }
 
+// StopManuallyTracking undoes [ManuallyTrack]. If [isValidator] is false,
+// [nodeID] is no longer treated as a validator.
+func (i *ipTracker) StopManuallyTracking(nodeID ids.NodeID, isValidator bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if !i.manuallyTracked.Contains(nodeID) {
+		return
+	}
+
+	i.manuallyTracked.Remove(nodeID)
+	if !isValidator {
+		i.onValidatorRemoved(nodeID)
+	}
+}
+
 func (i *ipTracker) WantsConnection(nodeID ids.NodeID) bool {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
@@ -245,6 +261,10 @@ func (i *ipTracker) OnValidatorRemoved(nodeID ids.NodeID, _ uint64) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
+	i.onValidatorRemoved(nodeID)
+}
+
+func (i *ipTracker) onValidatorRemoved(nodeID ids.NodeID) {
 	if i.manuallyTracked.Contains(nodeID) {
 		return
 	}
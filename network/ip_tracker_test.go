@@ -133,6 +133,69 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 	}
 }
 
+func TestIPTracker_StopManuallyTracking(t *testing.T) {
+	tests := []struct {
+		name          string
+		initialState  *ipTracker
+		nodeID        ids.NodeID
+		isValidator   bool
+		expectedState *ipTracker
+	}{
+		{
+			name:          "not manually tracked",
+			initialState:  newTestIPTracker(t),
+			nodeID:        ip.NodeID,
+			expectedState: newTestIPTracker(t),
+		},
+		{
+			name: "connected non-validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+			nodeID: ip.NodeID,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				delete(tracker.mostRecentValidatorIPs, ip.NodeID)
+				tracker.validators.Remove(ip.NodeID)
+				delete(tracker.gossipableIndicies, ip.NodeID)
+				tracker.gossipableIPs = tracker.gossipableIPs[:0]
+				return tracker
+			}(),
+		},
+		{
+			name: "connected validator",
+			initialState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				return tracker
+			}(),
+			nodeID:      ip.NodeID,
+			isValidator: true,
+			expectedState: func() *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				tracker.Connected(ip)
+				tracker.manuallyTracked.Remove(ip.NodeID)
+				return tracker
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.initialState.StopManuallyTracking(test.nodeID, test.isValidator)
+			requireEqual(t, test.expectedState, test.initialState)
+			requireMetricsConsistent(t, test.initialState)
+		})
+	}
+}
+
 func TestIPTracker_AddIP(t *testing.T) {
 	newerIP := newerTestIP(ip)
 	tests := []struct {
@@ -0,0 +1,272 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"maps"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+const (
+	nodeIDBanPrefix byte = iota
+	ipRangeBanPrefix
+	pinPrefix
+)
+
+// Ban is a NodeID, or an IP range, that the network refuses to be connected to
+// until Expiry.
+type Ban struct {
+	// NodeID is the banned node. It is empty if this is an IP range ban.
+	NodeID ids.NodeID
+	// IPRange is the banned IP range. It is nil if this is a NodeID ban.
+	IPRange *net.IPNet
+	Expiry  time.Time
+}
+
+// managedPeers tracks the peers that were banned or pinned by the operator.
+// All modifications are persisted to [db] so that they survive restarts.
+type managedPeers struct {
+	db database.Database
+
+	lock           sync.RWMutex
+	bannedNodeIDs  map[ids.NodeID]time.Time
+	bannedIPRanges map[string]Ban // CIDR -> ban
+	pinned         map[ids.NodeID]ips.IPPort
+}
+
+// newManagedPeers loads the bans and pins from [db]. Bans that expired before
+// [now] are removed.
+func newManagedPeers(db database.Database, now time.Time) (*managedPeers, error) {
+	m := &managedPeers{
+		db:             db,
+		bannedNodeIDs:  make(map[ids.NodeID]time.Time),
+		bannedIPRanges: make(map[string]Ban),
+		pinned:         make(map[ids.NodeID]ips.IPPort),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) == 0 {
+			continue
+		}
+
+		switch prefix, suffix := key[0], key[1:]; prefix {
+		case nodeIDBanPrefix:
+			nodeID, err := ids.ToNodeID(suffix)
+			if err != nil {
+				return nil, err
+			}
+			expiry, err := database.ParseTimestamp(it.Value())
+			if err != nil {
+				return nil, err
+			}
+			m.bannedNodeIDs[nodeID] = expiry
+		case ipRangeBanPrefix:
+			_, ipRange, err := net.ParseCIDR(string(suffix))
+			if err != nil {
+				return nil, err
+			}
+			expiry, err := database.ParseTimestamp(it.Value())
+			if err != nil {
+				return nil, err
+			}
+			m.bannedIPRanges[ipRange.String()] = Ban{
+				IPRange: ipRange,
+				Expiry:  expiry,
+			}
+		case pinPrefix:
+			nodeID, err := ids.ToNodeID(suffix)
+			if err != nil {
+				return nil, err
+			}
+			ip, err := ips.ToIPPort(string(it.Value()))
+			if err != nil {
+				return nil, err
+			}
+			m.pinned[nodeID] = ip
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return m, m.removeExpired(now)
+}
+
+func (m *managedPeers) banNodeID(nodeID ids.NodeID, expiry time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := database.PutTimestamp(m.db, nodeIDBanKey(nodeID), expiry); err != nil {
+		return err
+	}
+	m.bannedNodeIDs[nodeID] = expiry
+	return nil
+}
+
+func (m *managedPeers) banIPRange(ipRange *net.IPNet, expiry time.Time) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cidr := ipRange.String()
+	if err := database.PutTimestamp(m.db, ipRangeBanKey(cidr), expiry); err != nil {
+		return err
+	}
+	m.bannedIPRanges[cidr] = Ban{
+		IPRange: ipRange,
+		Expiry:  expiry,
+	}
+	return nil
+}
+
+// unbanNodeID returns false if [nodeID] wasn't banned.
+func (m *managedPeers) unbanNodeID(nodeID ids.NodeID) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.bannedNodeIDs[nodeID]; !ok {
+		return false, nil
+	}
+	if err := m.db.Delete(nodeIDBanKey(nodeID)); err != nil {
+		return false, err
+	}
+	delete(m.bannedNodeIDs, nodeID)
+	return true, nil
+}
+
+// unbanIPRange returns false if [ipRange] wasn't banned.
+func (m *managedPeers) unbanIPRange(ipRange *net.IPNet) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cidr := ipRange.String()
+	if _, ok := m.bannedIPRanges[cidr]; !ok {
+		return false, nil
+	}
+	if err := m.db.Delete(ipRangeBanKey(cidr)); err != nil {
+		return false, err
+	}
+	delete(m.bannedIPRanges, cidr)
+	return true, nil
+}
+
+func (m *managedPeers) isNodeIDBanned(nodeID ids.NodeID, now time.Time) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	expiry, ok := m.bannedNodeIDs[nodeID]
+	return ok && now.Before(expiry)
+}
+
+func (m *managedPeers) isIPBanned(ip net.IP, now time.Time) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, ban := range m.bannedIPRanges {
+		if now.Before(ban.Expiry) && ban.IPRange.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// bans returns the bans that haven't expired by [now].
+func (m *managedPeers) bans(now time.Time) ([]Ban, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.removeExpired(now); err != nil {
+		return nil, err
+	}
+
+	bans := make([]Ban, 0, len(m.bannedNodeIDs)+len(m.bannedIPRanges))
+	for nodeID, expiry := range m.bannedNodeIDs {
+		bans = append(bans, Ban{
+			NodeID: nodeID,
+			Expiry: expiry,
+		})
+	}
+	for _, ban := range m.bannedIPRanges {
+		bans = append(bans, ban)
+	}
+	return bans, nil
+}
+
+func (m *managedPeers) pin(nodeID ids.NodeID, ip ips.IPPort) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.db.Put(pinKey(nodeID), []byte(ip.String())); err != nil {
+		return err
+	}
+	m.pinned[nodeID] = ip
+	return nil
+}
+
+// unpin returns false if [nodeID] wasn't pinned.
+func (m *managedPeers) unpin(nodeID ids.NodeID) (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.pinned[nodeID]; !ok {
+		return false, nil
+	}
+	if err := m.db.Delete(pinKey(nodeID)); err != nil {
+		return false, err
+	}
+	delete(m.pinned, nodeID)
+	return true, nil
+}
+
+func (m *managedPeers) pins() map[ids.NodeID]ips.IPPort {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return maps.Clone(m.pinned)
+}
+
+// removeExpired removes the bans that expired before [now].
+//
+// Assumes [m.lock] is held, or that [m] is not yet shared.
+func (m *managedPeers) removeExpired(now time.Time) error {
+	for nodeID, expiry := range m.bannedNodeIDs {
+		if now.Before(expiry) {
+			continue
+		}
+		if err := m.db.Delete(nodeIDBanKey(nodeID)); err != nil {
+			return err
+		}
+		delete(m.bannedNodeIDs, nodeID)
+	}
+	for cidr, ban := range m.bannedIPRanges {
+		if now.Before(ban.Expiry) {
+			continue
+		}
+		if err := m.db.Delete(ipRangeBanKey(cidr)); err != nil {
+			return err
+		}
+		delete(m.bannedIPRanges, cidr)
+	}
+	return nil
+}
+
+func nodeIDBanKey(nodeID ids.NodeID) []byte {
+	return append([]byte{nodeIDBanPrefix}, nodeID.Bytes()...)
+}
+
+func ipRangeBanKey(cidr string) []byte {
+	return append([]byte{ipRangeBanPrefix}, cidr...)
+}
+
+func pinKey(nodeID ids.NodeID) []byte {
+	return append([]byte{pinPrefix}, nodeID.Bytes()...)
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+func TestManagedPeersBans(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000, 0)
+	m, err := newManagedPeers(memdb.New(), now)
+	require.NoError(err)
+
+	_, ipRange, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(m.banNodeID(nodeID, now.Add(time.Minute)))
+	require.NoError(m.banIPRange(ipRange, now.Add(time.Hour)))
+
+	require.True(m.isNodeIDBanned(nodeID, now))
+	require.False(m.isNodeIDBanned(ids.GenerateTestNodeID(), now))
+	require.True(m.isIPBanned(net.IPv4(10, 1, 2, 3), now))
+	require.False(m.isIPBanned(net.IPv4(11, 1, 2, 3), now))
+
+	// The node ban expires before the IP range ban.
+	later := now.Add(2 * time.Minute)
+	require.False(m.isNodeIDBanned(nodeID, later))
+	require.True(m.isIPBanned(net.IPv4(10, 1, 2, 3), later))
+
+	bans, err := m.bans(later)
+	require.NoError(err)
+	require.Equal(
+		[]Ban{
+			{
+				IPRange: ipRange,
+				Expiry:  now.Add(time.Hour),
+			},
+		},
+		bans,
+	)
+
+	unbanned, err := m.unbanIPRange(ipRange)
+	require.NoError(err)
+	require.True(unbanned)
+	require.False(m.isIPBanned(net.IPv4(10, 1, 2, 3), later))
+
+	unbanned, err = m.unbanIPRange(ipRange)
+	require.NoError(err)
+	require.False(unbanned)
+}
+
+func TestManagedPeersPersistence(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db         = memdb.New()
+		now        = time.Unix(1_000, 0)
+		nodeID     = ids.GenerateTestNodeID()
+		expiredID  = ids.GenerateTestNodeID()
+		pinnedID   = ids.GenerateTestNodeID()
+		unpinnedID = ids.GenerateTestNodeID()
+		pinnedIP   = ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	)
+	_, ipRange, err := net.ParseCIDR("2001:db8::/32")
+	require.NoError(err)
+
+	m, err := newManagedPeers(db, now)
+	require.NoError(err)
+	require.NoError(m.banNodeID(nodeID, now.Add(time.Hour)))
+	require.NoError(m.banNodeID(expiredID, now.Add(time.Minute)))
+	require.NoError(m.banIPRange(ipRange, now.Add(time.Hour)))
+	require.NoError(m.pin(pinnedID, pinnedIP))
+	require.NoError(m.pin(unpinnedID, pinnedIP))
+
+	unpinned, err := m.unpin(unpinnedID)
+	require.NoError(err)
+	require.True(unpinned)
+
+	// Reload the bans and pins after [expiredID]'s ban has expired.
+	later := now.Add(2 * time.Minute)
+	m, err = newManagedPeers(db, later)
+	require.NoError(err)
+
+	require.True(m.isNodeIDBanned(nodeID, later))
+	require.False(m.isNodeIDBanned(expiredID, later))
+	require.True(m.isIPBanned(net.ParseIP("2001:db8::1"), later))
+	require.Equal(
+		map[ids.NodeID]ips.IPPort{
+			pinnedID: pinnedIP,
+		},
+		m.pins(),
+	)
+
+	has, err := db.Has(nodeIDBanKey(expiredID))
+	require.NoError(err)
+	require.False(has)
+}
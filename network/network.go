@@ -4,11 +4,14 @@
 package network
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"net"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -19,16 +22,19 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 	"github.com/ava-labs/avalanchego/subnets"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -45,6 +51,10 @@ const (
 	TimeSinceLastMsgReceivedKey = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey     = "timeSinceLastMsgSent"
 	SendFailRateKey             = "sendFailRate"
+
+	// peerScoresRefreshFreq is how often the scores that are used to sample
+	// peers are refreshed.
+	peerScoresRefreshFreq = time.Second
 )
 
 var (
@@ -52,6 +62,11 @@ var (
 
 	errNotValidator        = errors.New("node is not a validator")
 	errNotTracked          = errors.New("subnet is not tracked")
+	errNotConnected        = errors.New("peer is not connected")
+	errNotBanned           = errors.New("peer is not banned")
+	errNotPinned           = errors.New("peer is not pinned")
+	errCantManageSelf      = errors.New("can't ban or pin this node")
+	errInvalidBanDuration  = errors.New("ban duration must be positive")
 	errExpectedProxy       = errors.New("expected proxy")
 	errExpectedTCPProtocol = errors.New("expected TCP protocol")
 )
@@ -87,6 +102,46 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// TrackSubnet starts tracking [subnetID]. The connections to the peers that
+	// may be affected by the change are restarted, so that the new set of
+	// tracked subnets is exchanged during their handshake.
+	TrackSubnet(subnetID ids.ID)
+
+	// UntrackSubnet stops tracking [subnetID]. The connections to the peers
+	// that may be affected by the change are restarted, so that the new set of
+	// tracked subnets is exchanged during their handshake.
+	UntrackSubnet(subnetID ids.ID)
+
+	// Disconnect closes the connection to [nodeID]. The peer may reconnect
+	// unless it is banned.
+	Disconnect(nodeID ids.NodeID) error
+
+	// BanNode closes the connection to [nodeID] and refuses all connections
+	// with it for [duration].
+	BanNode(nodeID ids.NodeID, duration time.Duration) error
+
+	// BanIPRange closes the connections to the peers in [ipRange] and refuses
+	// all connections with IPs in [ipRange] for [duration].
+	BanIPRange(ipRange *net.IPNet, duration time.Duration) error
+
+	// UnbanNode removes the ban of [nodeID].
+	UnbanNode(nodeID ids.NodeID) error
+
+	// UnbanIPRange removes the ban of [ipRange].
+	UnbanIPRange(ipRange *net.IPNet) error
+
+	// Bans returns the bans that haven't expired yet.
+	Bans() ([]Ban, error)
+
+	// PinPeer attempts to stay connected to [nodeID], which is initially
+	// dialed at [ip], as if it were a validator. Bans take precedence over
+	// pins.
+	PinPeer(nodeID ids.NodeID, ip ips.IPPort) error
+
+	// UnpinPeer stops treating [nodeID] as a validator, unless it is one or it
+	// was also manually tracked.
+	UnpinPeer(nodeID ids.NodeID) error
 }
 
 type UptimeResult struct {
@@ -110,6 +165,7 @@ type UptimeResult struct {
 //
 // 1. peersLock
 // 2. manuallyTrackedIDsLock
+// 3. managedPeers.lock
 //
 // If a higher lock (e.g. manuallyTrackedIDsLock) is held when trying to grab a
 // lower lock (e.g. peersLock) a deadlock could occur.
@@ -126,9 +182,9 @@ type network struct {
 	listener net.Listener
 	// Makes new outbound connections
 	dialer dialer.Dialer
-	// Does TLS handshakes for inbound connections
+	// Authenticates inbound connections
 	serverUpgrader peer.Upgrader
-	// Does TLS handshakes for outbound connections
+	// Authenticates outbound connections
 	clientUpgrader peer.Upgrader
 
 	// ensures the close of the network only happens once.
@@ -140,6 +196,17 @@ type network struct {
 
 	sendFailRateCalculator safemath.Averager
 
+	// Serializes changes to the subnets this node tracks.
+	trackedSubnetsLock sync.Mutex
+
+	// manuallyTrackedIDs are the nodes that were passed to [ManuallyTrack],
+	// which must keep being tracked when they are unpinned.
+	manuallyTrackedIDsLock sync.Mutex
+	manuallyTrackedIDs     set.Set[ids.NodeID]
+	// managedPeers contains the peers that were banned or pinned by the
+	// operator.
+	managedPeers *managedPeers
+
 	// Tracks which peers know about which peers
 	ipTracker *ipTracker
 	peersLock sync.RWMutex
@@ -152,6 +219,12 @@ type network struct {
 	connectedPeers  peer.Set
 	closing         bool
 
+	// peerScores is a snapshot of the scores of the connected peers. It is
+	// refreshed periodically so that sampling peers doesn't score them while
+	// holding [peersLock]. Peers that connected after the last refresh are
+	// treated as having a score of 1.
+	peerScores utils.Atomic[map[ids.NodeID]float64]
+
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
 	//
@@ -174,10 +247,10 @@ func NewNetwork(
 	msgCreator message.Creator,
 	metricsRegisterer prometheus.Registerer,
 	log logging.Logger,
-	listener net.Listener,
-	dialer dialer.Dialer,
+	transport transport.Transport,
 	router router.ExternalHandler,
 ) (Network, error) {
+	listener := transport.Listener()
 	if config.ProxyEnabled {
 		// Wrap the listener to process the proxy header.
 		listener = &proxyproto.Listener{
@@ -243,8 +316,19 @@ func NewNetwork(
 
 	// Track all default bootstrappers to ensure their current IPs are gossiped
 	// like validator IPs.
+	var manuallyTrackedIDs set.Set[ids.NodeID]
 	for _, bootstrapper := range genesis.GetBootstrappers(config.NetworkID) {
 		ipTracker.ManuallyTrack(bootstrapper.ID)
+		manuallyTrackedIDs.Add(bootstrapper.ID)
+	}
+
+	managedPeersDB := config.ManagedPeersDB
+	if managedPeersDB == nil {
+		managedPeersDB = memdb.New()
+	}
+	managedPeers, err := newManagedPeers(managedPeersDB, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("initializing managed peers failed with: %w", err)
 	}
 
 	peerConfig := &peer.Config{
@@ -258,7 +342,7 @@ func NewNetwork(
 		Network:              nil, // This is set below.
 		Router:               router,
 		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.TrackedSubnets,
+		MySubnets:            utils.NewAtomic(set.Of(config.TrackedSubnets.List()...)),
 		Beacons:              config.Beacons,
 		Validators:           config.Validators,
 		NetworkID:            config.NetworkID,
@@ -268,6 +352,7 @@ func NewNetwork(
 		SupportedACPs:        config.SupportedACPs.List(),
 		ObjectedACPs:         config.ObjectedACPs.List(),
 		ResourceTracker:      config.ResourceTracker,
+		PeerScorer:           config.PeerScorer,
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 	}
@@ -281,9 +366,9 @@ func NewNetwork(
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
-		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		dialer:                      transport.Dialer(),
+		serverUpgrader:              transport.ServerUpgrader(),
+		clientUpgrader:              transport.ClientUpgrader(),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -294,6 +379,9 @@ func NewNetwork(
 			time.Now(),
 		)),
 
+		manuallyTrackedIDs: manuallyTrackedIDs,
+		managedPeers:       managedPeers,
+
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
 		ipTracker:       ipTracker,
 		connectingPeers: peer.NewSet(),
@@ -301,6 +389,11 @@ func NewNetwork(
 		router:          router,
 	}
 	n.peerConfig.Network = n
+
+	// Pinned peers are dialed like any other manually tracked peer.
+	for nodeID, ip := range managedPeers.pins() {
+		n.manuallyTrack(nodeID, ip)
+	}
 	return n, nil
 }
 
@@ -551,6 +644,15 @@ func (n *network) Dispatch() error {
 				return
 			}
 
+			if n.managedPeers.isIPBanned(ip.IP, n.peerConfig.Clock.Time()) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "ip is banned"),
+					zap.Stringer("peerIP", ip),
+				)
+				_ = conn.Close()
+				return
+			}
+
 			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 				n.peerConfig.Log.Debug("failed to upgrade connection",
 					zap.String("reason", "rate-limiting"),
@@ -591,6 +693,14 @@ func (n *network) Dispatch() error {
 }
 
 func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
+	n.manuallyTrackedIDsLock.Lock()
+	n.manuallyTrackedIDs.Add(nodeID)
+	n.manuallyTrackedIDsLock.Unlock()
+
+	n.manuallyTrack(nodeID, ip)
+}
+
+func (n *network) manuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	n.ipTracker.ManuallyTrack(nodeID)
 
 	n.peersLock.Lock()
@@ -709,6 +819,9 @@ func (n *network) getPeers(
 // samplePeers samples connected peers attempting to align with the number of
 // requested validators, non-validators, and peers. This function will
 // explicitly ignore nodeIDs already included in the send config.
+//
+// Peers are preferred based on their score. Peers that are skipped due to
+// their score are only sampled if there aren't enough other peers to sample.
 func (n *network) samplePeers(
 	config common.SendConfig,
 	subnetID ids.ID,
@@ -718,12 +831,36 @@ func (n *network) samplePeers(
 	// [numValidatorsToSample], only attempt to sample [numValidatorsToSample]
 	// validators to potentially avoid iterating over the entire peer set.
 	numValidatorsToSample := min(config.Validators, n.config.Validators.Count(subnetID))
+	numToSample := numValidatorsToSample + config.NonValidators + config.Peers
+	if numToSample <= 0 {
+		return nil
+	}
+
+	peerScores := n.peerScores.Get()
 
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
-	return n.connectedPeers.Sample(
-		numValidatorsToSample+config.NonValidators+config.Peers,
+	// include returns true if [p] should be included in the sample, updating
+	// the number of peers left to sample.
+	include := func(p peer.Peer, isValidator bool) bool {
+		if config.Peers > 0 {
+			config.Peers--
+			return true
+		}
+
+		if isValidator {
+			numValidatorsToSample--
+			return numValidatorsToSample >= 0
+		}
+
+		config.NonValidators--
+		return config.NonValidators >= 0
+	}
+
+	var skipped []scoredPeer
+	peers := n.connectedPeers.Sample(
+		numToSample,
 		func(p peer.Peer) bool {
 			// Only return peers that are tracking [subnetID]
 			trackedSubnets := p.TrackedSubnets()
@@ -744,20 +881,63 @@ func (n *network) samplePeers(
 				return false
 			}
 
-			if config.Peers > 0 {
-				config.Peers--
-				return true
+			// Peers are skipped with a probability of one minus their score.
+			// This doesn't require cryptographically secure random number
+			// generation.
+			score, ok := peerScores[peerID]
+			if !ok {
+				score = 1
 			}
-
-			if isValidator {
-				numValidatorsToSample--
-				return numValidatorsToSample >= 0
+			if rand.Float64() >= score { // #nosec G404
+				skipped = append(skipped, scoredPeer{
+					peer:        p,
+					score:       score,
+					isValidator: isValidator,
+				})
+				return false
 			}
 
-			config.NonValidators--
-			return config.NonValidators >= 0
+			return include(p, isValidator)
 		},
 	)
+
+	// Fill the remainder of the sample with the best skipped peers.
+	slices.SortStableFunc(skipped, func(a, b scoredPeer) int {
+		return cmp.Compare(b.score, a.score)
+	})
+	for _, p := range skipped {
+		if len(peers) >= numToSample {
+			break
+		}
+		if include(p.peer, p.isValidator) {
+			peers = append(peers, p.peer)
+		}
+	}
+	return peers
+}
+
+type scoredPeer struct {
+	peer        peer.Peer
+	score       float64
+	isValidator bool
+}
+
+// refreshPeerScores replaces the snapshot of the scores of the connected
+// peers. The peers are scored without holding [peersLock].
+func (n *network) refreshPeerScores() {
+	n.peersLock.RLock()
+	nodeIDs := make([]ids.NodeID, n.connectedPeers.Len())
+	for i := range nodeIDs {
+		p, _ := n.connectedPeers.GetByIndex(i)
+		nodeIDs[i] = p.ID()
+	}
+	n.peersLock.RUnlock()
+
+	peerScores := make(map[ids.NodeID]float64, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		peerScores[nodeID] = n.config.PeerScorer.Score(nodeID)
+	}
+	n.peerScores.Set(peerScores)
 }
 
 func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
@@ -785,6 +965,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	n.ipTracker.Disconnected(nodeID)
 	n.router.Disconnected(nodeID)
+	score := n.config.PeerScorer.Score(nodeID)
 
 	n.peersLock.Lock()
 	defer n.peersLock.Unlock()
@@ -794,6 +975,9 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	// The peer that is disconnecting from us finished the handshake
 	if ip, wantsConnection := n.ipTracker.GetIP(nodeID); wantsConnection {
 		tracked := newTrackedIP(ip.IPPort)
+		// Peers with lower scores are redialed after a longer delay, so that
+		// better peers are reconnected to first.
+		tracked.delay = time.Duration((1 - score) * float64(n.config.InitialReconnectDelay))
 		n.trackedIPs[nodeID] = tracked
 		n.dial(nodeID, tracked)
 	}
@@ -903,6 +1087,19 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Banned peers are skipped, rather than no longer tracked, so that
+			// the connection is re-attempted once the ban expires or is
+			// removed.
+			if n.isBanned(nodeID, ip.ip.IP) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "peer is banned"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -955,6 +1152,9 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 
 	nodeID, tlsConn, cert, err := upgrader.Upgrade(conn)
 	if err != nil {
+		if errors.Is(err, peer.ErrInvalidCert) {
+			n.metrics.tlsConnRejected.Inc()
+		}
 		_ = conn.Close()
 		n.peerConfig.Log.Verbo("failed to upgrade connection",
 			zap.Error(err),
@@ -979,6 +1179,16 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if n.managedPeers.isNodeIDBanned(nodeID, n.peerConfig.Clock.Time()) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping connection",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return nil
+	}
+
 	if !n.AllowConnection(nodeID) {
 		_ = tlsConn.Close()
 		n.peerConfig.Log.Verbo(
@@ -1042,6 +1252,7 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 			nodeID,
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
+			n.config.ThrottlerConfig.OutboundMsgQueueConfig,
 		),
 	)
 	n.connectingPeers.Add(peer)
@@ -1059,6 +1270,238 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) TrackSubnet(subnetID ids.ID) {
+	n.trackedSubnetsLock.Lock()
+	defer n.trackedSubnetsLock.Unlock()
+
+	trackedSubnets := n.peerConfig.MySubnets.Get()
+	if trackedSubnets.Contains(subnetID) {
+		return
+	}
+
+	// The set is replaced, rather than modified, because peers read it without
+	// holding any locks.
+	newTrackedSubnets := set.NewSet[ids.ID](trackedSubnets.Len() + 1)
+	newTrackedSubnets.Union(trackedSubnets)
+	newTrackedSubnets.Add(subnetID)
+	n.peerConfig.MySubnets.Set(newTrackedSubnets)
+
+	n.peerConfig.Log.Info("started tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+	n.restartSubnetConnections(subnetID)
+}
+
+func (n *network) UntrackSubnet(subnetID ids.ID) {
+	n.trackedSubnetsLock.Lock()
+	defer n.trackedSubnetsLock.Unlock()
+
+	trackedSubnets := n.peerConfig.MySubnets.Get()
+	if !trackedSubnets.Contains(subnetID) {
+		return
+	}
+
+	newTrackedSubnets := set.NewSet[ids.ID](trackedSubnets.Len())
+	newTrackedSubnets.Union(trackedSubnets)
+	newTrackedSubnets.Remove(subnetID)
+	n.peerConfig.MySubnets.Set(newTrackedSubnets)
+
+	n.peerConfig.Log.Info("stopped tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+	n.restartSubnetConnections(subnetID)
+}
+
+// restartSubnetConnections closes the connections to the validators of
+// [subnetID] and to the peers that we previously agreed to track [subnetID]
+// with. Peers that we want to be connected to are re-dialed after their
+// connection is closed.
+func (n *network) restartSubnetConnections(subnetID ids.ID) {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+		_, isValidator := n.config.Validators.GetValidator(subnetID, peer.ID())
+		trackedSubnets := peer.TrackedSubnets()
+		if isValidator || trackedSubnets.Contains(subnetID) {
+			peer.StartClose()
+		}
+	}
+}
+
+func (n *network) Disconnect(nodeID ids.NodeID) error {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	peer, ok := n.connectedPeers.GetByID(nodeID)
+	if !ok {
+		peer, ok = n.connectingPeers.GetByID(nodeID)
+	}
+	if !ok {
+		return errNotConnected
+	}
+
+	n.peerConfig.Log.Info("disconnecting from peer",
+		zap.Stringer("nodeID", nodeID),
+	)
+	peer.StartClose()
+	return nil
+}
+
+func (n *network) BanNode(nodeID ids.NodeID, duration time.Duration) error {
+	if nodeID == n.config.MyNodeID {
+		return errCantManageSelf
+	}
+	if duration <= 0 {
+		return errInvalidBanDuration
+	}
+
+	expiry := n.peerConfig.Clock.Time().Add(duration)
+	if err := n.managedPeers.banNodeID(nodeID, expiry); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Time("expiry", expiry),
+	)
+	n.closeBannedPeers()
+	return nil
+}
+
+func (n *network) BanIPRange(ipRange *net.IPNet, duration time.Duration) error {
+	if duration <= 0 {
+		return errInvalidBanDuration
+	}
+
+	expiry := n.peerConfig.Clock.Time().Add(duration)
+	if err := n.managedPeers.banIPRange(ipRange, expiry); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned ip range",
+		zap.Stringer("ipRange", ipRange),
+		zap.Time("expiry", expiry),
+	)
+	n.closeBannedPeers()
+	return nil
+}
+
+func (n *network) UnbanNode(nodeID ids.NodeID) error {
+	unbanned, err := n.managedPeers.unbanNodeID(nodeID)
+	if err != nil {
+		return err
+	}
+	if !unbanned {
+		return errNotBanned
+	}
+
+	n.peerConfig.Log.Info("unbanned peer",
+		zap.Stringer("nodeID", nodeID),
+	)
+	return nil
+}
+
+func (n *network) UnbanIPRange(ipRange *net.IPNet) error {
+	unbanned, err := n.managedPeers.unbanIPRange(ipRange)
+	if err != nil {
+		return err
+	}
+	if !unbanned {
+		return errNotBanned
+	}
+
+	n.peerConfig.Log.Info("unbanned ip range",
+		zap.Stringer("ipRange", ipRange),
+	)
+	return nil
+}
+
+func (n *network) Bans() ([]Ban, error) {
+	return n.managedPeers.bans(n.peerConfig.Clock.Time())
+}
+
+func (n *network) PinPeer(nodeID ids.NodeID, ip ips.IPPort) error {
+	if nodeID == n.config.MyNodeID {
+		return errCantManageSelf
+	}
+	if err := n.managedPeers.pin(nodeID, ip); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("pinned peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("peerIP", ip),
+	)
+	n.manuallyTrack(nodeID, ip)
+	return nil
+}
+
+func (n *network) UnpinPeer(nodeID ids.NodeID) error {
+	unpinned, err := n.managedPeers.unpin(nodeID)
+	if err != nil {
+		return err
+	}
+	if !unpinned {
+		return errNotPinned
+	}
+
+	n.peerConfig.Log.Info("unpinned peer",
+		zap.Stringer("nodeID", nodeID),
+	)
+
+	n.manuallyTrackedIDsLock.Lock()
+	manuallyTracked := n.manuallyTrackedIDs.Contains(nodeID)
+	n.manuallyTrackedIDsLock.Unlock()
+	if manuallyTracked {
+		return nil
+	}
+
+	// If the peer is no longer wanted, the connection attempts to it will
+	// stop. An existing connection is left open.
+	_, isValidator := n.config.Validators.GetValidator(constants.PrimaryNetworkID, nodeID)
+	n.ipTracker.StopManuallyTracking(nodeID, isValidator)
+	return nil
+}
+
+// isBanned returns true if either [nodeID] or [ip] is banned.
+func (n *network) isBanned(nodeID ids.NodeID, ip net.IP) bool {
+	now := n.peerConfig.Clock.Time()
+	return n.managedPeers.isNodeIDBanned(nodeID, now) || n.managedPeers.isIPBanned(ip, now)
+}
+
+// closeBannedPeers closes the connections to all the peers that are banned.
+// Connected peers are matched by their NodeID and by the IP of their
+// connection. Connecting peers are only matched by their NodeID, because their
+// info can't be read until the handshake has finished.
+func (n *network) closeBannedPeers() {
+	now := n.peerConfig.Clock.Time()
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	for i := 0; i < n.connectingPeers.Len(); i++ {
+		peer, _ := n.connectingPeers.GetByIndex(i)
+		if n.managedPeers.isNodeIDBanned(peer.ID(), now) {
+			peer.StartClose()
+		}
+	}
+
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+		if n.managedPeers.isNodeIDBanned(peer.ID(), now) {
+			peer.StartClose()
+			continue
+		}
+
+		ip, err := ips.ToIPPort(peer.Info().IP)
+		if err == nil && n.managedPeers.isIPBanned(ip.IP, now) {
+			peer.StartClose()
+		}
+	}
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1093,7 +1536,8 @@ func (n *network) StartClose() {
 }
 
 func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
-	if subnetID != constants.PrimaryNetworkID && !n.config.TrackedSubnets.Contains(subnetID) {
+	trackedSubnets := n.peerConfig.MySubnets.Get()
+	if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
 		return UptimeResult{}, errNotTracked
 	}
 
@@ -1151,9 +1595,11 @@ func (n *network) runTimers() {
 	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
 	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
 	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	refreshPeerScores := time.NewTicker(peerScoresRefreshFreq)
 	defer func() {
 		resetPeerListBloom.Stop()
 		updateUptimes.Stop()
+		refreshPeerScores.Stop()
 	}()
 
 	for {
@@ -1162,6 +1608,8 @@ func (n *network) runTimers() {
 			return
 		case <-pullGossipPeerlists.C:
 			n.pullGossipPeerLists()
+		case <-refreshPeerScores.C:
+			n.refreshPeerScores()
 		case <-resetPeerListBloom.C:
 			if err := n.ipTracker.ResetBloom(); err != nil {
 				n.peerConfig.Log.Error("failed to reset ip tracker bloom filter",
@@ -1180,7 +1628,7 @@ func (n *network) runTimers() {
 			n.metrics.nodeUptimeWeightedAverage.Set(primaryUptime.WeightedAveragePercentage)
 			n.metrics.nodeUptimeRewardingStake.Set(primaryUptime.RewardingStakePercentage)
 
-			for subnetID := range n.config.TrackedSubnets {
+			for subnetID := range n.peerConfig.MySubnets.Get() {
 				result, err := n.NodeUptime(subnetID)
 				if err != nil {
 					n.peerConfig.Log.Debug("failed to get subnet uptime",
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -89,7 +90,8 @@ var (
 			AtLargeAllocSize:    1 * units.GiB,
 			NodeMaxAtLargeBytes: constants.DefaultMaxMessageSize,
 		},
-		MaxInboundConnsPerSec: 100,
+		OutboundMsgQueueConfig: peer.DefaultMessageQueueConfig,
+		MaxInboundConnsPerSec:  100,
 	}
 	defaultDialerConfig = dialer.Config{
 		ThrottleRps:       100,
@@ -123,6 +125,7 @@ var (
 		ResourceTracker:              newDefaultResourceTracker(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
+		PeerScorer:                   tracker.NewNoPeerScorer(),
 	}
 )
 
@@ -192,7 +195,9 @@ func newMessageCreator(t *testing.T) message.Creator {
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		"",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -229,6 +234,9 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 
 		config.Beacons = beacons
 		config.Validators = vdrs
+		config.PeerScorer = &testPeerScorer{
+			PeerScorer: tracker.NewNoPeerScorer(),
+		}
 
 		var connected set.Set[ids.NodeID]
 		net, err := NewNetwork(
@@ -236,11 +244,16 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 			msgCreator,
 			registry,
 			logging.NoLog{},
-			listeners[i],
-			dialer,
+			transport.NewTLS(listeners[i], dialer, config.TLSConfig),
 			&testHandler{
 				InboundHandler: handlers[i],
-				ConnectedF: func(nodeID ids.NodeID, _ *version.Application, _ ids.ID) {
+				ConnectedF: func(nodeID ids.NodeID, _ *version.Application, subnetID ids.ID) {
+					// Connections are only counted once, when the peer
+					// connects on the primary network.
+					if subnetID != constants.PrimaryNetworkID {
+						return
+					}
+
 					t.Logf("%s connected to %s", config.MyNodeID, nodeID)
 
 					globalLock.Lock()
@@ -393,6 +406,117 @@ func TestSendWithFilter(t *testing.T) {
 	wg.Wait()
 }
 
+// testPeerScorer scores peers with 1 unless their score was set.
+type testPeerScorer struct {
+	tracker.PeerScorer
+
+	lock   sync.Mutex
+	scores map[ids.NodeID]float64
+}
+
+func (s *testPeerScorer) Score(nodeID ids.NodeID) float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	score, ok := s.scores[nodeID]
+	if !ok {
+		return 1
+	}
+	return score
+}
+
+func (s *testPeerScorer) setScores(scores map[ids.NodeID]float64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.scores = scores
+}
+
+func TestSamplePeersPrefersScoredPeers(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0]
+	net0.config.PeerScorer.(*testPeerScorer).setScores(map[ids.NodeID]float64{
+		nodeIDs[1]: 1,
+		nodeIDs[2]: 0,
+	})
+	net0.refreshPeerScores()
+
+	for i := 0; i < 10; i++ {
+		peers := net0.samplePeers(
+			common.SendConfig{
+				Peers: 1,
+			},
+			constants.PrimaryNetworkID,
+			subnets.NoOpAllower,
+		)
+		require.Len(peers, 1)
+		require.Equal(nodeIDs[1], peers[0].ID())
+	}
+
+	// Peers with low scores are sampled if there aren't enough other peers.
+	peers := net0.samplePeers(
+		common.SendConfig{
+			Peers: 2,
+		},
+		constants.PrimaryNetworkID,
+		subnets.NoOpAllower,
+	)
+	require.Len(peers, 2)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestReconnectDelayScaledByScore(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	// Neither node should reconnect before the delays are checked.
+	net0 := networks[0]
+	net0.config.InitialReconnectDelay = time.Hour
+	net0.config.PeerScorer.(*testPeerScorer).setScores(map[ids.NodeID]float64{
+		nodeIDs[1]: 0,
+	})
+
+	// net1 manually tracks net0, so it redials net0 after disconnecting.
+	net1 := networks[1]
+	net1.config.InitialReconnectDelay = time.Hour
+	net1.config.PeerScorer.(*testPeerScorer).setScores(map[ids.NodeID]float64{
+		nodeIDs[0]: .25,
+	})
+
+	net1.peersLock.RLock()
+	p, ok := net1.connectedPeers.GetByID(nodeIDs[0])
+	net1.peersLock.RUnlock()
+	require.True(ok)
+	p.StartClose()
+
+	var tracked *trackedIP
+	require.Eventually(
+		func() bool {
+			net1.peersLock.RLock()
+			defer net1.peersLock.RUnlock()
+
+			tracked, ok = net1.trackedIPs[nodeIDs[0]]
+			return ok
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+	require.Equal(45*time.Minute, tracked.getDelay())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestTrackVerifiesSignatures(t *testing.T) {
 	require := require.New(t)
 
@@ -458,8 +582,7 @@ func TestTrackDoesNotDialPrivateIPs(t *testing.T) {
 			msgCreator,
 			registry,
 			logging.NoLog{},
-			listeners[i],
-			dialer,
+			transport.NewTLS(listeners[i], dialer, config.TLSConfig),
 			&testHandler{
 				InboundHandler: nil,
 				ConnectedF: func(ids.NodeID, *version.Application, ids.ID) {
@@ -537,8 +660,7 @@ func TestDialDeletesNonValidators(t *testing.T) {
 			msgCreator,
 			registry,
 			logging.NoLog{},
-			listeners[i],
-			dialer,
+			transport.NewTLS(listeners[i], dialer, config.TLSConfig),
 			&testHandler{
 				InboundHandler: nil,
 				ConnectedF: func(ids.NodeID, *version.Application, ids.ID) {
@@ -691,8 +813,7 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 			msgCreator,
 			registry,
 			logging.NoLog{},
-			listeners[i],
-			dialer,
+			transport.NewTLS(listeners[i], dialer, config.TLSConfig),
 			&testHandler{
 				InboundHandler: nil,
 				ConnectedF:     nil,
@@ -737,3 +858,100 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestTrackSubnet(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			nil,
+			nil,
+		},
+	)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		net0     = networks[0]
+		net1     = networks[1]
+	)
+	require.NoError(net0.config.Validators.AddStaker(subnetID, nodeIDs[1], nil, ids.GenerateTestID(), 1))
+
+	peerTracksSubnet := func() bool {
+		peers := net0.PeerInfo([]ids.NodeID{nodeIDs[1]})
+		return len(peers) == 1 && peers[0].TrackedSubnets.Contains(subnetID)
+	}
+	peerDoesNotTrackSubnet := func() bool {
+		peers := net0.PeerInfo([]ids.NodeID{nodeIDs[1]})
+		return len(peers) == 1 && !peers[0].TrackedSubnets.Contains(subnetID)
+	}
+
+	// The subnet is only reported as tracked once both peers have reconnected
+	// after tracking it.
+	net1.TrackSubnet(subnetID)
+	net0.TrackSubnet(subnetID)
+	mySubnets := net0.peerConfig.MySubnets.Get()
+	require.True(mySubnets.Contains(subnetID))
+	require.Eventually(peerTracksSubnet, 10*time.Second, 50*time.Millisecond)
+
+	_, err := net0.NodeUptime(subnetID)
+	require.NotErrorIs(err, errNotTracked)
+
+	net0.UntrackSubnet(subnetID)
+	mySubnets = net0.peerConfig.MySubnets.Get()
+	require.False(mySubnets.Contains(subnetID))
+	require.Eventually(peerDoesNotTrackSubnet, 10*time.Second, 50*time.Millisecond)
+
+	_, err = net0.NodeUptime(subnetID)
+	require.ErrorIs(err, errNotTracked)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestBanNode(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			nil,
+			nil,
+		},
+	)
+
+	var (
+		net0   = networks[0]
+		peerID = nodeIDs[1]
+	)
+
+	require.ErrorIs(net0.BanNode(net0.config.MyNodeID, time.Hour), errCantManageSelf)
+	require.ErrorIs(net0.BanNode(peerID, 0), errInvalidBanDuration)
+	require.ErrorIs(net0.UnbanNode(peerID), errNotBanned)
+
+	require.NoError(net0.BanNode(peerID, time.Hour))
+	require.Eventually(
+		func() bool {
+			return len(net0.PeerInfo([]ids.NodeID{peerID})) == 0
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+
+	bans, err := net0.Bans()
+	require.NoError(err)
+	require.Len(bans, 1)
+	require.Equal(peerID, bans[0].NodeID)
+
+	require.NoError(net0.UnbanNode(peerID))
+	bans, err = net0.Bans()
+	require.NoError(err)
+	require.Empty(bans)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -7,12 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
+// numScoredCandidates is the number of nodes sampled by Client.AppRequestAny
+// to select the best scoring node from, if peer scores are provided.
+const numScoredCandidates = 3
+
 var (
 	ErrRequestPending = errors.New("request pending")
 	ErrNoPeers        = errors.New("no peers")
@@ -45,6 +50,21 @@ type Client struct {
 	router        *router
 	sender        common.AppSender
 	options       *clientOptions
+
+	// Recent response latencies, only tracked if requests are hedged
+	latencies *latencyWindow
+
+	lock sync.Mutex
+	// Callbacks of the requests that are in flight, only tracked if requests
+	// are deduplicated
+	inflight map[inflightKey][]AppResponseCallback
+}
+
+// inflightKey identifies identical requests. Requests issued by
+// AppRequestAny are identified by an empty nodeID.
+type inflightKey struct {
+	nodeID  ids.NodeID
+	request string
 }
 
 // AppRequestAny issues an AppRequest to an arbitrary node decided by Client.
@@ -55,13 +75,64 @@ func (c *Client) AppRequestAny(
 	appRequestBytes []byte,
 	onResponse AppResponseCallback,
 ) error {
-	sampled := c.options.nodeSampler.Sample(ctx, 1)
-	if len(sampled) != 1 {
+	key := inflightKey{
+		request: string(appRequestBytes),
+	}
+	onResponse, ok := c.deduplicate(key, onResponse)
+	if !ok {
+		return nil
+	}
+
+	err := c.appRequestAny(ctx, appRequestBytes, onResponse)
+	if err != nil {
+		c.abort(ctx, key, err)
+	}
+	return err
+}
+
+func (c *Client) appRequestAny(
+	ctx context.Context,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
+) error {
+	nodeID, ok := c.sample(ctx)
+	if !ok {
 		return ErrNoPeers
 	}
 
-	nodeIDs := set.Of(sampled...)
-	return c.AppRequest(ctx, nodeIDs, appRequestBytes, onResponse)
+	if c.options.hedge != nil {
+		return c.hedgedAppRequest(ctx, nodeID, appRequestBytes, onResponse)
+	}
+	return c.appRequest(ctx, nodeID, appRequestBytes, onResponse)
+}
+
+// sample returns the node to route a request issued by AppRequestAny to.
+func (c *Client) sample(ctx context.Context) (ids.NodeID, bool) {
+	peerScores := c.options.peerScores
+	if peerScores == nil {
+		sampled := c.options.nodeSampler.Sample(ctx, 1)
+		if len(sampled) != 1 {
+			return ids.EmptyNodeID, false
+		}
+		return sampled[0], true
+	}
+
+	sampled := c.options.nodeSampler.Sample(ctx, numScoredCandidates)
+	if len(sampled) == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	var (
+		bestNodeID = sampled[0]
+		bestScore  = peerScores.Score(bestNodeID)
+	)
+	for _, nodeID := range sampled[1:] {
+		if score := peerScores.Score(nodeID); score > bestScore {
+			bestNodeID = nodeID
+			bestScore = score
+		}
+	}
+	return bestNodeID, true
 }
 
 // AppRequest issues an arbitrary request to a node.
@@ -72,39 +143,111 @@ func (c *Client) AppRequest(
 	appRequestBytes []byte,
 	onResponse AppResponseCallback,
 ) error {
-	c.router.lock.Lock()
-	defer c.router.lock.Unlock()
-
-	appRequestBytes = PrefixMessage(c.handlerPrefix, appRequestBytes)
 	for nodeID := range nodeIDs {
-		requestID := c.router.requestID
-		if _, ok := c.router.pendingAppRequests[requestID]; ok {
-			return fmt.Errorf(
-				"failed to issue request with request id %d: %w",
-				requestID,
-				ErrRequestPending,
-			)
+		key := inflightKey{
+			nodeID:  nodeID,
+			request: string(appRequestBytes),
+		}
+		onNodeResponse, ok := c.deduplicate(key, onResponse)
+		if !ok {
+			continue
 		}
 
-		if err := c.sender.SendAppRequest(
-			ctx,
-			set.Of(nodeID),
-			requestID,
-			appRequestBytes,
-		); err != nil {
+		if err := c.appRequest(ctx, nodeID, appRequestBytes, onNodeResponse); err != nil {
+			c.abort(ctx, key, err)
 			return err
 		}
+	}
+	return nil
+}
 
-		c.router.pendingAppRequests[requestID] = pendingAppRequest{
-			handlerID: c.handlerIDStr,
-			callback:  onResponse,
-		}
-		c.router.requestID += 2
+func (c *Client) appRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
+) error {
+	c.router.lock.Lock()
+	defer c.router.lock.Unlock()
+
+	requestID := c.router.requestID
+	if _, ok := c.router.pendingAppRequests[requestID]; ok {
+		return fmt.Errorf(
+			"failed to issue request with request id %d: %w",
+			requestID,
+			ErrRequestPending,
+		)
 	}
 
+	if err := c.sender.SendAppRequest(
+		ctx,
+		set.Of(nodeID),
+		requestID,
+		PrefixMessage(c.handlerPrefix, appRequestBytes),
+	); err != nil {
+		return err
+	}
+
+	c.router.pendingAppRequests[requestID] = pendingAppRequest{
+		handlerID: c.handlerIDStr,
+		callback:  onResponse,
+	}
+	c.router.requestID += 2
 	return nil
 }
 
+// deduplicate returns false if requests are deduplicated and an identical
+// request is already in flight. In that case, [onResponse] will be called with
+// the outcome of the in-flight request.
+//
+// Otherwise, returns the callback that the request should be issued with.
+func (c *Client) deduplicate(key inflightKey, onResponse AppResponseCallback) (AppResponseCallback, bool) {
+	if !c.options.deduplicate {
+		return onResponse, true
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	callbacks, ok := c.inflight[key]
+	c.inflight[key] = append(callbacks, onResponse)
+	if ok {
+		return nil, false
+	}
+
+	return func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		for _, callback := range c.clearInflight(key) {
+			callback(ctx, nodeID, responseBytes, err)
+		}
+	}, true
+}
+
+// abort notifies the requests that were deduplicated into a request that
+// failed to be issued. The error is returned to the caller that issued the
+// request, so its callback is not called.
+func (c *Client) abort(ctx context.Context, key inflightKey, err error) {
+	if !c.options.deduplicate {
+		return
+	}
+
+	callbacks := c.clearInflight(key)
+	if len(callbacks) == 0 {
+		return
+	}
+	for _, callback := range callbacks[1:] {
+		callback(ctx, key.nodeID, nil, err)
+	}
+}
+
+func (c *Client) clearInflight(key inflightKey) []AppResponseCallback {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	callbacks := c.inflight[key]
+	delete(c.inflight, key)
+	return callbacks
+}
+
 // AppGossip sends a gossip message to a random set of peers.
 func (c *Client) AppGossip(
 	ctx context.Context,
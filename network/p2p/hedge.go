@@ -0,0 +1,299 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+const (
+	// The number of recent response latencies that the hedging delay is
+	// calculated from.
+	hedgeLatencyWindowSize = 128
+	// The number of response latencies that must be observed before the
+	// hedging delay is calculated from them.
+	minHedgeLatencySamples = 16
+	// The number of times the peer tracker is queried for a peer that wasn't
+	// already sent the request.
+	maxHedgePeerSelections = 3
+
+	// Added to response latencies to avoid dividing by zero when calculating
+	// the bandwidth of a response.
+	epsilon = 1e-6
+)
+
+var (
+	ErrNilPeerTracker         = errors.New("nil peer tracker")
+	ErrInvalidHedgePercentile = errors.New("hedge percentile must be in (0, 1]")
+)
+
+// HedgeConfig configures when requests are hedged.
+type HedgeConfig struct {
+	// Percentile of recent response latencies after which a request is
+	// hedged. Must be in (0, 1].
+	Percentile float64 `json:"percentile"`
+	// InitialDelay is the delay after which a request is hedged until enough
+	// responses have been observed to calculate [Percentile].
+	InitialDelay time.Duration `json:"initialDelay"`
+	// MinDelay is the minimum delay after which a request is hedged.
+	MinDelay time.Duration `json:"minDelay"`
+}
+
+// Verify returns an error if [c] is invalid.
+func (c HedgeConfig) Verify() error {
+	if c.Percentile <= 0 || c.Percentile > 1 {
+		return fmt.Errorf("%w: %f", ErrInvalidHedgePercentile, c.Percentile)
+	}
+	return nil
+}
+
+type hedgeOptions struct {
+	peerTracker *PeerTracker
+	config      HedgeConfig
+}
+
+// latencyWindow tracks the most recent response latencies.
+type latencyWindow struct {
+	lock      sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newLatencyWindow(size int) *latencyWindow {
+	return &latencyWindow{
+		latencies: make([]time.Duration, 0, size),
+	}
+}
+
+func (w *latencyWindow) Observe(latency time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.latencies) < cap(w.latencies) {
+		w.latencies = append(w.latencies, latency)
+		return
+	}
+	w.latencies[w.next] = latency
+	w.next = (w.next + 1) % len(w.latencies)
+}
+
+// Percentile returns the [percentile] of the observed latencies. Returns false
+// if fewer than [minHedgeLatencySamples] latencies have been observed.
+func (w *latencyWindow) Percentile(percentile float64) (time.Duration, bool) {
+	w.lock.Lock()
+	latencies := slices.Clone(w.latencies)
+	w.lock.Unlock()
+
+	if len(latencies) < minHedgeLatencySamples {
+		return 0, false
+	}
+
+	slices.Sort(latencies)
+	index := int(math.Ceil(percentile*float64(len(latencies)))) - 1
+	index = max(0, min(index, len(latencies)-1))
+	return latencies[index], true
+}
+
+// hedgedRequest is a request that is sent to a second node if the first node
+// doesn't respond in time. Only the first response is passed to [onResponse],
+// later responses are dropped.
+type hedgedRequest struct {
+	client     *Client
+	ctx        context.Context
+	request    []byte
+	onResponse AppResponseCallback
+
+	lock sync.Mutex
+	// Time that the request was sent to each node
+	sent map[ids.NodeID]time.Time
+	// Nodes that were selected by the peer tracker
+	hedged      set.Set[ids.NodeID]
+	outstanding int
+	// The most recent failure that wasn't reported because other requests
+	// were outstanding
+	failure *hedgeFailure
+	done    bool
+	timer   *time.Timer
+}
+
+type hedgeFailure struct {
+	ctx    context.Context
+	nodeID ids.NodeID
+	err    error
+}
+
+func (c *Client) hedgedAppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
+) error {
+	h := &hedgedRequest{
+		client:     c,
+		ctx:        ctx,
+		request:    appRequestBytes,
+		onResponse: onResponse,
+		sent: map[ids.NodeID]time.Time{
+			nodeID: time.Now(),
+		},
+		outstanding: 1,
+	}
+	if err := h.send(nodeID); err != nil {
+		return err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	// The response may have already been received.
+	if !h.done {
+		h.timer = time.AfterFunc(c.hedgeDelay(), h.hedge)
+	}
+	return nil
+}
+
+// hedgeDelay returns the delay after which a request should be hedged.
+func (c *Client) hedgeDelay() time.Duration {
+	config := c.options.hedge.config
+	delay, ok := c.latencies.Percentile(config.Percentile)
+	if !ok {
+		delay = config.InitialDelay
+	}
+	return max(delay, config.MinDelay)
+}
+
+// send sends the request to [nodeID], which must already be recorded in
+// [h.sent] and [h.outstanding].
+//
+// Assumes [h.lock] isn't held, as the request may fail synchronously.
+func (h *hedgedRequest) send(nodeID ids.NodeID) error {
+	err := h.client.appRequest(h.ctx, nodeID, h.request, h.handleResponse)
+	if err == nil {
+		return nil
+	}
+
+	h.lock.Lock()
+	delete(h.sent, nodeID)
+	h.hedged.Remove(nodeID)
+	h.outstanding--
+
+	// If the failure of another request wasn't reported because this request
+	// was outstanding, it must be reported now.
+	failure := h.failure
+	if h.done || h.outstanding > 0 || failure == nil {
+		h.lock.Unlock()
+		return err
+	}
+	h.done = true
+	h.lock.Unlock()
+
+	h.onResponse(failure.ctx, failure.nodeID, nil, failure.err)
+	return err
+}
+
+// hedge sends the request to a node selected by the peer tracker, if no
+// response has been received yet.
+func (h *hedgedRequest) hedge() {
+	nodeID, ok := h.selectHedgeNode()
+	if !ok {
+		return
+	}
+
+	peerTracker := h.client.options.hedge.peerTracker
+	peerTracker.RegisterRequest(nodeID)
+	if err := h.send(nodeID); err != nil {
+		h.client.router.log.Debug("failed to send hedged request",
+			zap.Uint64("handlerID", h.client.handlerID),
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		peerTracker.RegisterFailure(nodeID)
+	}
+}
+
+// selectHedgeNode returns a node, selected by the peer tracker, that wasn't
+// sent the request yet. The node is recorded as having been sent the request.
+// Returns false if a response was already received or if no node was found.
+func (h *hedgedRequest) selectHedgeNode() (ids.NodeID, bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.done {
+		return ids.EmptyNodeID, false
+	}
+
+	peerTracker := h.client.options.hedge.peerTracker
+	for i := 0; i < maxHedgePeerSelections; i++ {
+		nodeID, ok := peerTracker.SelectPeer()
+		if !ok {
+			return ids.EmptyNodeID, false
+		}
+		if _, ok := h.sent[nodeID]; ok {
+			continue
+		}
+
+		h.sent[nodeID] = time.Now()
+		h.hedged.Add(nodeID)
+		h.outstanding++
+		return nodeID, true
+	}
+	return ids.EmptyNodeID, false
+}
+
+func (h *hedgedRequest) handleResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	h.lock.Lock()
+	h.outstanding--
+	latency := time.Since(h.sent[nodeID])
+	if h.hedged.Contains(nodeID) {
+		peerTracker := h.client.options.hedge.peerTracker
+		if err != nil {
+			peerTracker.RegisterFailure(nodeID)
+		} else {
+			bandwidth := float64(len(responseBytes)) / (latency.Seconds() + epsilon)
+			peerTracker.RegisterResponse(nodeID, bandwidth)
+		}
+	}
+	if err == nil {
+		h.client.latencies.Observe(latency)
+	}
+
+	if h.done {
+		h.lock.Unlock()
+		return
+	}
+	// Failures are only reported once there are no other outstanding requests
+	// that may still succeed.
+	if err != nil && h.outstanding > 0 {
+		h.failure = &hedgeFailure{
+			ctx:    ctx,
+			nodeID: nodeID,
+			err:    err,
+		}
+		h.lock.Unlock()
+		return
+	}
+	h.done = true
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+	h.lock.Unlock()
+
+	h.onResponse(ctx, nodeID, responseBytes, err)
+}
@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+type sentAppRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+type response struct {
+	nodeID        ids.NodeID
+	responseBytes []byte
+	err           error
+}
+
+// newHedgingTestClient returns a client that sends its requests to [nodeID]
+// and hedges them to [hedgeNodeID] after [initialDelay]. Sending a request to
+// [hedgeNodeID] fails with [hedgeSendErr] after it is passed to the returned
+// channel.
+func newHedgingTestClient(
+	t *testing.T,
+	nodeID ids.NodeID,
+	hedgeNodeID ids.NodeID,
+	initialDelay time.Duration,
+	hedgeSendErr error,
+) (*Network, *Client, chan sentAppRequest) {
+	require := require.New(t)
+
+	sent := make(chan sentAppRequest, 2)
+	sender := &common.SenderTest{
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+			for nodeID := range nodeIDs {
+				sent <- sentAppRequest{
+					nodeID:    nodeID,
+					requestID: requestID,
+				}
+				if nodeID == hedgeNodeID {
+					return hedgeSendErr
+				}
+			}
+			return nil
+		},
+	}
+
+	n, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(n.Connected(context.Background(), nodeID, &version.Application{}))
+
+	peerTracker, err := NewPeerTracker(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil)
+	require.NoError(err)
+	peerTracker.Connected(hedgeNodeID, &version.Application{})
+
+	hedging, err := WithHedging(peerTracker, HedgeConfig{
+		Percentile:   .9,
+		InitialDelay: initialDelay,
+	})
+	require.NoError(err)
+	return n, n.NewClient(handlerID, hedging), sent
+}
+
+func TestWithHedgingInvalid(t *testing.T) {
+	peerTracker, err := NewPeerTracker(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		peerTracker *PeerTracker
+		percentile  float64
+		expectedErr error
+	}{
+		{
+			name:        "nil peer tracker",
+			percentile:  .9,
+			expectedErr: ErrNilPeerTracker,
+		},
+		{
+			name:        "zero percentile",
+			peerTracker: peerTracker,
+			expectedErr: ErrInvalidHedgePercentile,
+		},
+		{
+			name:        "percentile above 1",
+			peerTracker: peerTracker,
+			percentile:  1.5,
+			expectedErr: ErrInvalidHedgePercentile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := WithHedging(tt.peerTracker, HedgeConfig{
+				Percentile: tt.percentile,
+			})
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestLatencyWindowPercentile(t *testing.T) {
+	require := require.New(t)
+
+	w := newLatencyWindow(hedgeLatencyWindowSize)
+	for i := 1; i < minHedgeLatencySamples; i++ {
+		w.Observe(time.Duration(i) * time.Millisecond)
+	}
+	_, ok := w.Percentile(.5)
+	require.False(ok)
+
+	for i := minHedgeLatencySamples; i <= 100; i++ {
+		w.Observe(time.Duration(i) * time.Millisecond)
+	}
+	latency, ok := w.Percentile(.95)
+	require.True(ok)
+	require.Equal(95*time.Millisecond, latency)
+
+	latency, ok = w.Percentile(1)
+	require.True(ok)
+	require.Equal(100*time.Millisecond, latency)
+
+	// Older latencies are replaced once the window is full.
+	for i := 0; i < hedgeLatencyWindowSize; i++ {
+		w.Observe(time.Second)
+	}
+	latency, ok = w.Percentile(.01)
+	require.True(ok)
+	require.Equal(time.Second, latency)
+}
+
+func TestHedgedAppRequest(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	hedgeNodeID := ids.GenerateTestNodeID()
+	n, client, sent := newHedgingTestClient(t, nodeID, hedgeNodeID, time.Millisecond, nil)
+
+	responses := make(chan response, 2)
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- response{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	require.NoError(client.AppRequestAny(context.Background(), []byte("request"), onResponse))
+
+	request := <-sent
+	require.Equal(nodeID, request.nodeID)
+
+	// The request is hedged once the initial delay passes.
+	hedgedRequest := <-sent
+	require.Equal(hedgeNodeID, hedgedRequest.nodeID)
+
+	require.NoError(n.AppResponse(context.Background(), hedgeNodeID, hedgedRequest.requestID, []byte("hedged")))
+	require.Equal(
+		response{
+			nodeID:        hedgeNodeID,
+			responseBytes: []byte("hedged"),
+		},
+		<-responses,
+	)
+
+	// The later response is dropped.
+	require.NoError(n.AppResponse(context.Background(), nodeID, request.requestID, []byte("response")))
+	require.Empty(responses)
+}
+
+func TestHedgedAppRequestRespondsBeforeHedging(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	hedgeNodeID := ids.GenerateTestNodeID()
+	n, client, sent := newHedgingTestClient(t, nodeID, hedgeNodeID, time.Hour, nil)
+
+	responses := make(chan response, 1)
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- response{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	require.NoError(client.AppRequestAny(context.Background(), []byte("request"), onResponse))
+
+	request := <-sent
+	require.Equal(nodeID, request.nodeID)
+
+	require.NoError(n.AppResponse(context.Background(), nodeID, request.requestID, []byte("response")))
+	require.Equal(
+		response{
+			nodeID:        nodeID,
+			responseBytes: []byte("response"),
+		},
+		<-responses,
+	)
+	require.Empty(sent)
+}
+
+func TestHedgedAppRequestFailed(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	hedgeNodeID := ids.GenerateTestNodeID()
+	n, client, sent := newHedgingTestClient(t, nodeID, hedgeNodeID, time.Millisecond, nil)
+
+	responses := make(chan response, 2)
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- response{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	require.NoError(client.AppRequestAny(context.Background(), []byte("request"), onResponse))
+
+	request := <-sent
+	hedgedRequest := <-sent
+
+	// The failure isn't reported while the hedged request may still succeed.
+	require.NoError(n.AppRequestFailed(context.Background(), nodeID, request.requestID, errFoo))
+	require.Empty(responses)
+
+	require.NoError(n.AppRequestFailed(context.Background(), hedgeNodeID, hedgedRequest.requestID, errFoo))
+	result := <-responses
+	require.Equal(hedgeNodeID, result.nodeID)
+	require.ErrorIs(result.err, errFoo)
+}
+
+func TestHedgedAppRequestHedgeSendFailed(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	hedgeNodeID := ids.GenerateTestNodeID()
+	n, client, sent := newHedgingTestClient(t, nodeID, hedgeNodeID, time.Millisecond, errFoo)
+
+	responses := make(chan response, 1)
+	onResponse := func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- response{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	require.NoError(client.AppRequestAny(context.Background(), []byte("request"), onResponse))
+
+	request := <-sent
+	require.Equal(nodeID, request.nodeID)
+	hedgedRequest := <-sent
+	require.Equal(hedgeNodeID, hedgedRequest.nodeID)
+
+	// The failure is reported regardless of whether it is received before the
+	// hedged request failed to send.
+	require.NoError(n.AppRequestFailed(context.Background(), nodeID, request.requestID, errFoo))
+	result := <-responses
+	require.Equal(nodeID, result.nodeID)
+	require.ErrorIs(result.err, errFoo)
+}
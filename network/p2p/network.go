@@ -14,6 +14,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -48,10 +49,56 @@ func WithValidatorSampling(validators *Validators) ClientOption {
 	})
 }
 
+// WithPeerScores configures Client.AppRequestAny to prefer nodes with higher
+// scores. If [scores] is nil, nodes are selected regardless of their scores.
+func WithPeerScores(scores tracker.PeerScores) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.peerScores = scores
+	})
+}
+
+// WithHedging configures Client.AppRequestAny to send the request to a second
+// node, selected by [peerTracker], if no response is received within the delay
+// described by [config]. Only the first response is passed to the callback of
+// the request.
+//
+// The request that doesn't win the race isn't cancelled. Its response is
+// dropped once it is received, but is still registered with [peerTracker].
+func WithHedging(peerTracker *PeerTracker, config HedgeConfig) (ClientOption, error) {
+	if peerTracker == nil {
+		return nil, ErrNilPeerTracker
+	}
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	return clientOptionFunc(func(options *clientOptions) {
+		options.hedge = &hedgeOptions{
+			peerTracker: peerTracker,
+			config:      config,
+		}
+	}), nil
+}
+
+// WithDeduplication configures Client to not send requests that are identical
+// to a request that is already in flight. The callbacks of the deduplicated
+// requests are called with the outcome of the in-flight request.
+func WithDeduplication() ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.deduplicate = true
+	})
+}
+
 // clientOptions holds client-configurable values
 type clientOptions struct {
 	// nodeSampler is used to select nodes to route Client.AppRequestAny to
 	nodeSampler NodeSampler
+	// peerScores, if non-nil, is used to select the best of the nodes sampled
+	// by [nodeSampler]
+	peerScores tracker.PeerScores
+	// hedge, if non-nil, configures Client.AppRequestAny to hedge requests
+	hedge *hedgeOptions
+	// deduplicate configures Client to deduplicate in-flight requests
+	deduplicate bool
 }
 
 // NewNetwork returns an instance of Network
@@ -225,12 +272,17 @@ func (n *Network) NewClient(handlerID uint64, options ...ClientOption) *Client {
 				peers: n.Peers,
 			},
 		},
+		inflight: make(map[inflightKey][]AppResponseCallback),
 	}
 
 	for _, option := range options {
 		option.apply(client.options)
 	}
 
+	if client.options.hedge != nil {
+		client.latencies = newLatencyWindow(hedgeLatencyWindowSize)
+	}
+
 	return client
 }
 
@@ -400,6 +400,66 @@ func TestAppRequestDuplicateRequestIDs(t *testing.T) {
 	require.ErrorIs(err, ErrRequestPending)
 }
 
+// Identical requests should only be sent once while a request is in-flight
+func TestAppRequestDeduplication(t *testing.T) {
+	tests := []struct {
+		name       string
+		appRequest func(*Client, AppResponseCallback) error
+	}{
+		{
+			name: "AppRequest",
+			appRequest: func(client *Client, onResponse AppResponseCallback) error {
+				return client.AppRequest(context.Background(), set.Of(ids.EmptyNodeID), []byte("request"), onResponse)
+			},
+		},
+		{
+			name: "AppRequestAny",
+			appRequest: func(client *Client, onResponse AppResponseCallback) error {
+				return client.AppRequestAny(context.Background(), []byte("request"), onResponse)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			requestIDs := make(chan uint32, 2)
+			sender := &common.SenderTest{
+				SendAppRequestF: func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+					requestIDs <- requestID
+					return nil
+				},
+			}
+
+			network, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			require.NoError(network.Connected(ctx, ids.EmptyNodeID, nil))
+			client := network.NewClient(handlerID, WithDeduplication())
+
+			responses := make(chan []byte, 2)
+			onResponse := func(_ context.Context, _ ids.NodeID, responseBytes []byte, err error) {
+				require.NoError(err)
+				responses <- responseBytes
+			}
+			require.NoError(tt.appRequest(client, onResponse))
+			require.NoError(tt.appRequest(client, onResponse))
+
+			requestID := <-requestIDs
+			require.Empty(requestIDs)
+
+			require.NoError(network.AppResponse(ctx, ids.EmptyNodeID, requestID, []byte("response")))
+			require.Equal([]byte("response"), <-responses)
+			require.Equal([]byte("response"), <-responses)
+
+			// Requests are sent again once the in-flight request completes
+			require.NoError(tt.appRequest(client, onResponse))
+			<-requestIDs
+		})
+	}
+}
+
 // Sample should always return up to [limit] peers, and less if fewer than
 // [limit] peers are available.
 func TestPeersSample(t *testing.T) {
@@ -531,6 +591,12 @@ func TestAppRequestAnyNodeSelection(t *testing.T) {
 	}
 }
 
+type testPeerScores map[ids.NodeID]float64
+
+func (s testPeerScores) Score(nodeID ids.NodeID) float64 {
+	return s[nodeID]
+}
+
 func TestNodeSamplerClientOption(t *testing.T) {
 	nodeID0 := ids.GenerateTestNodeID()
 	nodeID1 := ids.GenerateTestNodeID()
@@ -574,6 +640,18 @@ func TestNodeSamplerClientOption(t *testing.T) {
 			},
 			expected: []ids.NodeID{nodeID1},
 		},
+		{
+			name:  "peer scores",
+			peers: []ids.NodeID{nodeID0, nodeID1, nodeID2},
+			option: func(*testing.T, *Network) ClientOption {
+				return WithPeerScores(testPeerScores{
+					nodeID0: 0.5,
+					nodeID1: 1,
+					nodeID2: 0,
+				})
+			},
+			expected: []ids.NodeID{nodeID1},
+		},
 		{
 			name:  "validator disconnected",
 			peers: []ids.NodeID{nodeID0},
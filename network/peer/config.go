@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -33,7 +34,7 @@ type Config struct {
 	Network              Network
 	Router               router.InboundHandler
 	VersionCompatibility version.Compatibility
-	MySubnets            set.Set[ids.ID]
+	MySubnets            *utils.Atomic[set.Set[ids.ID]]
 	Beacons              validators.Manager
 	Validators           validators.Manager
 	NetworkID            uint32
@@ -51,6 +52,9 @@ type Config struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker tracker.ResourceTracker
 
+	// Scores peers based on their observed behavior.
+	PeerScorer tracker.PeerScorer
+
 	// Calculates uptime of peers
 	UptimeCalculator uptime.Calculator
 
@@ -6,6 +6,7 @@ package peer
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -14,9 +15,16 @@ import (
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
-const initialQueueSize = 64
+const (
+	initialQueueSize = 64
+
+	// virtualTimeScale is the number of units of virtual time that it takes
+	// to send a byte of a class with a weight of 1.
+	virtualTimeScale = 1 << 16
+)
 
 var (
 	_ MessageQueue = (*throttledMessageQueue)(nil)
@@ -52,12 +60,28 @@ type MessageQueue interface {
 	Close()
 }
 
+// MessageQueueMetrics is notified when messages leave a throttled message
+// queue.
+type MessageQueueMetrics interface {
+	SendFailedCallback
+
+	// Dequeued is called when a message of [class] is popped after having
+	// been queued for [delay].
+	Dequeued(class PriorityClass, delay time.Duration)
+}
+
+// throttledMessageQueue queues the messages of each priority class separately.
+// Messages are dequeued in FIFO order within a class. Across classes, messages
+// are dequeued using start-time fair queueing so that, while multiple classes
+// have messages queued, each class receives a share of the sent bytes
+// proportional to its weight.
 type throttledMessageQueue struct {
-	onFailed SendFailedCallback
+	metrics MessageQueueMetrics
 	// [id] of the peer we're sending messages to
 	id                   ids.NodeID
 	log                  logging.Logger
 	outboundMsgThrottler throttling.OutboundMsgThrottler
+	clock                mockable.Clock
 
 	// Signalled when a message is added to the queue and when Close() is
 	// called.
@@ -67,25 +91,57 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.Deque[message.OutboundMessage]
+	// The following fields track the queued messages.
+	// [cond.L] must be held while accessing them.
+
+	// number of messages queued across all classes
+	numQueued int
+	// virtual time at which the last popped message started being sent
+	virtualTime uint64
+	classes     [numPriorityClasses]*priorityQueue
+}
+
+// priorityQueue is the queue of a single priority class.
+type priorityQueue struct {
+	weight   uint64
+	maxBytes uint64
+
+	queue buffer.Deque[queuedMessage]
+	// number of bytes of the messages in [queue]
+	bytes uint64
+	// virtual time at which the next message of this class will start
+	// being sent
+	start uint64
+}
+
+type queuedMessage struct {
+	msg    message.OutboundMessage
+	pushed time.Time
 }
 
 func NewThrottledMessageQueue(
-	onFailed SendFailedCallback,
+	metrics MessageQueueMetrics,
 	id ids.NodeID,
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
+	config MessageQueueConfig,
 ) MessageQueue {
-	return &throttledMessageQueue{
-		onFailed:             onFailed,
+	q := &throttledMessageQueue{
+		metrics:              metrics,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 		cond:                 sync.NewCond(&sync.Mutex{}),
-		queue:                buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
 	}
+	for _, class := range PriorityClasses {
+		classConfig := config.Class(class)
+		q.classes[class] = &priorityQueue{
+			weight:   max(classConfig.Weight, 1),
+			maxBytes: classConfig.MaxBytes,
+			queue:    buffer.NewUnboundedDeque[queuedMessage](initialQueueSize),
+		}
+	}
+	return q
 }
 
 func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
@@ -96,7 +152,7 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 			zap.Stringer("nodeID", q.id),
 			zap.Error(err),
 		)
-		q.onFailed.SendFailed(msg)
+		q.metrics.SendFailed(msg)
 		return false
 	}
 
@@ -108,7 +164,7 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 			zap.Stringer("messageOp", msg.Op()),
 			zap.Stringer("nodeID", q.id),
 		)
-		q.onFailed.SendFailed(msg)
+		q.metrics.SendFailed(msg)
 		return false
 	}
 
@@ -127,11 +183,42 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 			zap.Stringer("nodeID", q.id),
 		)
 		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+		q.metrics.SendFailed(msg)
 		return false
 	}
 
-	q.queue.PushRight(msg)
+	var (
+		class     = PriorityClassOf(msg.Op())
+		classQ    = q.classes[class]
+		numBytes  = uint64(len(msg.Bytes()))
+		classFull = classQ.maxBytes != 0 && classQ.bytes+numBytes > classQ.maxBytes
+	)
+	// A message that is larger than the class limit is still queued if the
+	// class is empty, otherwise it could never be sent.
+	if classFull && classQ.queue.Len() > 0 {
+		q.log.Debug(
+			"dropping outgoing message",
+			zap.String("reason", "priority class full"),
+			zap.Stringer("priorityClass", class),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Stringer("nodeID", q.id),
+		)
+		q.outboundMsgThrottler.Release(msg, q.id)
+		q.metrics.SendFailed(msg)
+		return false
+	}
+
+	if classQ.queue.Len() == 0 {
+		// A class that was idle doesn't accumulate credit for the time it
+		// didn't have anything to send.
+		classQ.start = max(classQ.start, q.virtualTime)
+	}
+	classQ.queue.PushRight(queuedMessage{
+		msg:    msg,
+		pushed: q.clock.Time(),
+	})
+	classQ.bytes += numBytes
+	q.numQueued++
 	q.cond.Signal()
 	return true
 }
@@ -144,7 +231,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.numQueued > 0 {
 			// There is a message
 			break
 		}
@@ -159,7 +246,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.numQueued == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -167,10 +254,38 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// pop removes the next message to send.
+//
+// Assumes [q.cond.L] is held and that there is at least one queued message.
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg, _ := q.queue.PopLeft()
+	// Find the non-empty class whose next message has the earliest virtual
+	// start time. Ties are broken in favor of the higher priority class.
+	var (
+		class  PriorityClass
+		classQ *priorityQueue
+	)
+	for _, c := range PriorityClasses {
+		cq := q.classes[c]
+		if cq.queue.Len() == 0 {
+			continue
+		}
+		if classQ == nil || cq.start < classQ.start {
+			class = c
+			classQ = cq
+		}
+	}
+
+	queued, _ := classQ.queue.PopLeft()
+	msg := queued.msg
+	numBytes := uint64(len(msg.Bytes()))
+	classQ.bytes -= numBytes
+	q.numQueued--
+
+	q.virtualTime = classQ.start
+	classQ.start += (numBytes + 1) * virtualTimeScale / classQ.weight
 
 	q.outboundMsgThrottler.Release(msg, q.id)
+	q.metrics.Dequeued(class, q.clock.Time().Sub(queued.pushed))
 	return msg
 }
 
@@ -184,12 +299,16 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.PopLeft()
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for _, classQ := range q.classes {
+		for classQ.queue.Len() > 0 {
+			queued, _ := classQ.queue.PopLeft()
+			q.outboundMsgThrottler.Release(queued.msg, q.id)
+			q.metrics.SendFailed(queued.msg)
+		}
+		classQ.queue = nil
+		classQ.bytes = 0
 	}
-	q.queue = nil
+	q.numQueued = 0
 
 	q.cond.Broadcast()
 }
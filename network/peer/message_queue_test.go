@@ -6,15 +6,47 @@ package peer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/proto/pb/p2p"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
+type testQueueMetrics struct {
+	failed []message.OutboundMessage
+	delays map[PriorityClass][]time.Duration
+}
+
+func (m *testQueueMetrics) SendFailed(msg message.OutboundMessage) {
+	m.failed = append(m.failed, msg)
+}
+
+func (m *testQueueMetrics) Dequeued(class PriorityClass, delay time.Duration) {
+	if m.delays == nil {
+		m.delays = make(map[PriorityClass][]time.Duration)
+	}
+	m.delays[class] = append(m.delays[class], delay)
+}
+
+func newTestThrottledMessageQueue(config MessageQueueConfig) (*throttledMessageQueue, *testQueueMetrics) {
+	metrics := &testQueueMetrics{}
+	q := NewThrottledMessageQueue(
+		metrics,
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+		config,
+	)
+	return q.(*throttledMessageQueue), metrics
+}
+
 func TestMessageQueue(t *testing.T) {
 	require := require.New(t)
 
@@ -87,3 +119,190 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+func TestPriorityClassOf(t *testing.T) {
+	tests := []struct {
+		op       message.Op
+		expected PriorityClass
+	}{
+		{op: message.PingOp, expected: ConsensusClass},
+		{op: message.HandshakeOp, expected: ConsensusClass},
+		{op: message.PushQueryOp, expected: ConsensusClass},
+		{op: message.ChitsOp, expected: ConsensusClass},
+		{op: message.GetAcceptedFrontierOp, expected: BootstrappingClass},
+		{op: message.AncestorsOp, expected: BootstrappingClass},
+		{op: message.StateSummaryFrontierOp, expected: BootstrappingClass},
+		{op: message.AppRequestOp, expected: AppClass},
+		{op: message.AppResponseOp, expected: AppClass},
+		{op: message.AppGossipOp, expected: GossipClass},
+		{op: message.PeerListOp, expected: GossipClass},
+	}
+	for _, test := range tests {
+		t.Run(test.op.String(), func(t *testing.T) {
+			require.Equal(t, test.expected, PriorityClassOf(test.op))
+		})
+	}
+}
+
+func TestThrottledMessageQueuePriority(t *testing.T) {
+	require := require.New(t)
+
+	q, metrics := newTestThrottledMessageQueue(DefaultMessageQueueConfig)
+	mc := newMessageCreator(t)
+
+	gossipMsgs := make([]message.OutboundMessage, 3)
+	for i := range gossipMsgs {
+		msg, err := mc.AppGossip(ids.GenerateTestID(), utils.RandomBytes(1024))
+		require.NoError(err)
+		require.True(q.Push(context.Background(), msg))
+		gossipMsgs[i] = msg
+	}
+
+	chitsMsg, err := mc.Chits(ids.Empty, 0, ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(err)
+	require.True(q.Push(context.Background(), chitsMsg))
+
+	// The consensus message skips ahead of the queued gossip
+	msg, ok := q.PopNow()
+	require.True(ok)
+	require.Equal(chitsMsg, msg)
+
+	for _, expected := range gossipMsgs {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(expected, msg)
+	}
+
+	_, ok = q.PopNow()
+	require.False(ok)
+	require.Empty(metrics.failed)
+	require.Len(metrics.delays[ConsensusClass], 1)
+	require.Len(metrics.delays[GossipClass], len(gossipMsgs))
+}
+
+func TestThrottledMessageQueueWeights(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultMessageQueueConfig
+	config.App.Weight = 3
+	config.Gossip.Weight = 1
+	q, _ := newTestThrottledMessageQueue(config)
+	mc := newMessageCreator(t)
+
+	const numMsgs = 8
+	for i := 0; i < numMsgs; i++ {
+		appMsg, err := mc.AppResponse(ids.Empty, uint32(i), utils.RandomBytes(units.KiB))
+		require.NoError(err)
+		require.True(q.Push(context.Background(), appMsg))
+
+		gossipMsg, err := mc.AppGossip(ids.Empty, utils.RandomBytes(units.KiB))
+		require.NoError(err)
+		require.True(q.Push(context.Background(), gossipMsg))
+	}
+
+	// While both classes have messages queued, the app class should be
+	// dequeued 3 times as often as the gossip class.
+	numPopped := make(map[PriorityClass]int)
+	for i := 0; i < numMsgs; i++ {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		numPopped[PriorityClassOf(msg.Op())]++
+	}
+	require.Equal(
+		map[PriorityClass]int{
+			AppClass:    6,
+			GossipClass: 2,
+		},
+		numPopped,
+	)
+}
+
+func TestThrottledMessageQueueMaxBytes(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	msg0, err := mc.AppGossip(ids.Empty, utils.RandomBytes(units.KiB))
+	require.NoError(err)
+	msg1, err := mc.AppGossip(ids.Empty, utils.RandomBytes(units.KiB))
+	require.NoError(err)
+
+	config := DefaultMessageQueueConfig
+	config.Gossip.MaxBytes = uint64(len(msg0.Bytes()))
+	q, metrics := newTestThrottledMessageQueue(config)
+
+	// The first message fits in the class
+	require.True(q.Push(context.Background(), msg0))
+
+	// The class is full
+	require.False(q.Push(context.Background(), msg1))
+	require.Equal([]message.OutboundMessage{msg1}, metrics.failed)
+
+	// Other classes are unaffected
+	pingMsg, err := mc.Ping(0, nil)
+	require.NoError(err)
+	require.True(q.Push(context.Background(), pingMsg))
+
+	// Once the class is emptied, messages larger than the limit can still be
+	// queued
+	msg, ok := q.PopNow()
+	require.True(ok)
+	require.Equal(pingMsg, msg)
+
+	msg, ok = q.PopNow()
+	require.True(ok)
+	require.Equal(msg0, msg)
+
+	largeMsg, err := mc.AppGossip(ids.Empty, utils.RandomBytes(2*units.KiB))
+	require.NoError(err)
+	require.True(q.Push(context.Background(), largeMsg))
+}
+
+func TestThrottledMessageQueueDelay(t *testing.T) {
+	require := require.New(t)
+
+	q, metrics := newTestThrottledMessageQueue(DefaultMessageQueueConfig)
+	mc := newMessageCreator(t)
+
+	now := time.Now()
+	q.clock.Set(now)
+
+	msg, err := mc.Ancestors(ids.Empty, 0, [][]byte{utils.RandomBytes(units.KiB)})
+	require.NoError(err)
+	require.True(q.Push(context.Background(), msg))
+
+	q.clock.Set(now.Add(time.Second))
+	_, ok := q.PopNow()
+	require.True(ok)
+
+	require.Equal(
+		map[PriorityClass][]time.Duration{
+			BootstrappingClass: {time.Second},
+		},
+		metrics.delays,
+	)
+}
+
+func TestThrottledMessageQueueClose(t *testing.T) {
+	require := require.New(t)
+
+	q, metrics := newTestThrottledMessageQueue(DefaultMessageQueueConfig)
+	mc := newMessageCreator(t)
+
+	pingMsg, err := mc.Ping(0, nil)
+	require.NoError(err)
+	require.True(q.Push(context.Background(), pingMsg))
+
+	gossipMsg, err := mc.AppGossip(ids.Empty, nil)
+	require.NoError(err)
+	require.True(q.Push(context.Background(), gossipMsg))
+
+	q.Close()
+	require.Equal([]message.OutboundMessage{pingMsg, gossipMsg}, metrics.failed)
+
+	_, ok := q.Pop()
+	require.False(ok)
+
+	// Pushing to a closed queue fails
+	require.False(q.Push(context.Background(), pingMsg))
+	require.Len(metrics.failed, 3)
+}
@@ -5,6 +5,7 @@ package peer
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -78,11 +79,16 @@ func NewMessageMetrics(
 	return msg
 }
 
+var _ MessageQueueMetrics = (*Metrics)(nil)
+
 type Metrics struct {
 	Log            logging.Logger
 	ClockSkew      metric.Averager
 	FailedToParse  prometheus.Counter
 	MessageMetrics map[message.Op]*MessageMetrics
+	// QueueingDelay tracks the time messages of each priority class spend in
+	// the outbound message queues
+	QueueingDelay map[PriorityClass]metric.Averager
 }
 
 func NewMetrics(
@@ -98,6 +104,7 @@ func NewMetrics(
 			Help:      "Number of messages that could not be parsed or were invalidly formed",
 		}),
 		MessageMetrics: make(map[message.Op]*MessageMetrics, len(message.ExternalOps)),
+		QueueingDelay:  make(map[PriorityClass]metric.Averager, len(PriorityClasses)),
 	}
 
 	errs := wrappers.Errs{}
@@ -107,6 +114,15 @@ func NewMetrics(
 	for _, op := range message.ExternalOps {
 		m.MessageMetrics[op] = NewMessageMetrics(op, namespace, registerer, &errs)
 	}
+	for _, class := range PriorityClasses {
+		m.QueueingDelay[class] = metric.NewAveragerWithErrs(
+			namespace,
+			fmt.Sprintf("%s_queueing_delay", class),
+			fmt.Sprintf("time (in ns) %s messages spent in the outbound message queue", class),
+			registerer,
+			&errs,
+		)
+	}
 
 	m.ClockSkew = metric.NewAveragerWithErrs(
 		namespace,
@@ -164,6 +180,20 @@ func (m *Metrics) SendFailed(msg message.OutboundMessage) {
 	msgMetrics.NumFailed.Inc()
 }
 
+// Dequeued updates the metrics for having popped a message of [class] from an
+// outbound message queue after [delay].
+func (m *Metrics) Dequeued(class PriorityClass, delay time.Duration) {
+	queueingDelay := m.QueueingDelay[class]
+	if queueingDelay == nil {
+		m.Log.Error(
+			"unknown priority class dequeued",
+			zap.Stringer("priorityClass", class),
+		)
+		return
+	}
+	queueingDelay.Observe(float64(delay))
+}
+
 func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
 	op := msg.Op()
 	msgMetrics := m.MessageMetrics[op]
@@ -31,9 +31,14 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
-// maxBloomSaltLen restricts the allowed size of the bloom salt to prevent
-// excessively expensive bloom filter contains checks.
-const maxBloomSaltLen = 32
+const (
+	// maxBloomSaltLen restricts the allowed size of the bloom salt to prevent
+	// excessively expensive bloom filter contains checks.
+	maxBloomSaltLen = 32
+	// maxZstdDictionaries restricts the number of zstd dictionaries a peer
+	// can advertise in its handshake.
+	maxZstdDictionaries = 32
+)
 
 var (
 	errClosed = errors.New("closed")
@@ -137,6 +142,10 @@ type peer struct {
 	// options of ACPs provided in the Handshake message.
 	supportedACPs set.Set[uint32]
 	objectedACPs  set.Set[uint32]
+	// zstdDictionaries are the IDs of the zstd dictionaries that the peer
+	// can decompress messages with. Set by the reader goroutine when the
+	// Handshake message is received.
+	zstdDictionaries utils.Atomic[set.Set[uint32]]
 
 	// txIDOfVerifiedBLSKey is the txID that added the BLS key that was most
 	// recently verified to have signed the IP.
@@ -290,6 +299,7 @@ func (p *peer) Info() Info {
 		TrackedSubnets:        p.trackedSubnets,
 		SupportedACPs:         p.supportedACPs,
 		ObjectedACPs:          p.objectedACPs,
+		Score:                 p.PeerScorer.PeerScore(p.id),
 	}
 }
 
@@ -479,6 +489,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.FailedToParse.Inc()
+			p.PeerScorer.RegisterInvalidMessage(p.id)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -503,7 +514,7 @@ func (p *peer) writeMessages() {
 		p.close()
 	}()
 
-	writer := bufio.NewWriterSize(p.conn, p.Config.WriteBufferSize)
+	writer := newMessageWriter(p.conn, p.Config.WriteBufferSize)
 
 	// Make sure that the Handshake is the first message sent
 	mySignedIP, err := p.IPSigner.GetSignedIP()
@@ -536,7 +547,7 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.TLSSignature,
 		mySignedIP.BLSSignatureBytes,
-		p.MySubnets.List(),
+		p.MySubnets.Get().List(),
 		p.SupportedACPs,
 		p.ObjectedACPs,
 		knownPeersFilter,
@@ -580,7 +591,23 @@ func (p *peer) writeMessages() {
 	}
 }
 
-func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+func (p *peer) writeMessage(writer *messageWriter, msg message.OutboundMessage) {
+	// Messages can only be compressed with a dictionary that the peer
+	// advertised in its Handshake.
+	zstdDictionaries := p.zstdDictionaries.Get()
+	if zstdDictionary := msg.ZstdDictionary(); zstdDictionary != 0 && !zstdDictionaries.Contains(zstdDictionary) {
+		fallbackMsg, err := msg.WithoutZstdDictionary()
+		if err != nil {
+			p.Log.Verbo("error compressing message without zstd dictionary",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Error(err),
+			)
+			return
+		}
+		msg = fallbackMsg
+	}
+
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
@@ -605,9 +632,17 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 		return
 	}
 
+	classWriter, err := writer.Writer(PriorityClassOf(msg.Op()))
+	if err != nil {
+		p.Log.Verbo("error opening stream",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
 	// Write the message
 	var buf net.Buffers = [][]byte{msgLenBytes[:], msgBytes}
-	if _, err := io.CopyN(writer, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
+	if _, err := io.CopyN(classWriter, &buf, int64(wrappers.IntLen+msgLen)); err != nil {
 		p.Log.Verbo("error writing message",
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
@@ -851,6 +886,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 	}
 	p.observeUptime(constants.PrimaryNetworkID, primaryUptime)
 
+	mySubnets := p.MySubnets.Get()
 	for _, subnetUptime := range subnetUptimes {
 		subnetID, err := ids.ToID(subnetUptime.SubnetId)
 		if err != nil {
@@ -862,7 +898,7 @@ func (p *peer) observeUptimes(primaryUptime uint32, subnetUptimes []*p2p.SubnetU
 			return
 		}
 
-		if !p.MySubnets.Contains(subnetID) {
+		if !mySubnets.Contains(subnetID) {
 			p.Log.Debug("dropping message with unexpected subnetID",
 				zap.Stringer("nodeID", p.id),
 				zap.Stringer("subnetID", subnetID),
@@ -960,6 +996,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 	}
 
 	// handle subnet IDs
+	mySubnets := p.MySubnets.Get()
 	for _, subnetIDBytes := range msg.TrackedSubnets {
 		subnetID, err := ids.ToID(subnetIDBytes)
 		if err != nil {
@@ -971,7 +1008,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			return
 		}
 		// add only if we also track this subnet
-		if p.MySubnets.Contains(subnetID) {
+		if mySubnets.Contains(subnetID) {
 			p.trackedSubnets.Add(subnetID)
 		}
 	}
@@ -987,6 +1024,18 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		}
 	}
 
+	if numZstdDictionaries := len(msg.ZstdDictionaries); numZstdDictionaries > maxZstdDictionaries {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.HandshakeOp),
+			zap.String("field", "ZstdDictionaries"),
+			zap.Int("numZstdDictionaries", numZstdDictionaries),
+		)
+		p.StartClose()
+		return
+	}
+	p.zstdDictionaries.Set(set.Of(msg.ZstdDictionaries...))
+
 	if p.supportedACPs.Overlaps(p.objectedACPs) {
 		p.Log.Debug("message with invalid field",
 			zap.Stringer("nodeID", p.id),
@@ -7,6 +7,8 @@ import (
 	"context"
 	"crypto"
 	"net"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -22,6 +24,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -48,11 +52,19 @@ type rawTestPeer struct {
 func newMessageCreator(t *testing.T) message.Creator {
 	t.Helper()
 
+	return newMessageCreatorWithCompression(t, message.CompressionConfig{
+		Type: constants.DefaultNetworkCompressionType,
+	})
+}
+
+func newMessageCreatorWithCompression(t *testing.T, compressionConfig message.CompressionConfig) message.Creator {
+	t.Helper()
+
 	mc, err := message.NewCreator(
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		"",
-		constants.DefaultNetworkCompressionType,
+		compressionConfig,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -102,7 +114,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 		Log:                  logging.NoLog{},
 		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
 		VersionCompatibility: version.GetCompatibility(constants.LocalID),
-		MySubnets:            trackedSubnets,
+		MySubnets:            utils.NewAtomic(trackedSubnets),
 		UptimeCalculator:     uptime.NoOpCalculator,
 		Beacons:              validators.NewManager(),
 		Validators:           validators.NewManager(),
@@ -111,6 +123,7 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		PeerScorer:           tracker.NewNoPeerScorer(),
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -160,7 +173,10 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 
 func makeTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*testPeer, *testPeer) {
 	rawPeer0, rawPeer1 := makeRawTestPeers(t, trackedSubnets)
+	return startTestPeers(rawPeer0, rawPeer1)
+}
 
+func startTestPeers(rawPeer0 *rawTestPeer, rawPeer1 *rawTestPeer) (*testPeer, *testPeer) {
 	peer0 := &testPeer{
 		Peer: Start(
 			rawPeer0.config,
@@ -172,6 +188,7 @@ func makeTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*testPeer, *te
 				rawPeer1.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				DefaultMessageQueueConfig,
 			),
 		),
 		inboundMsgChan: rawPeer0.inboundMsgChan,
@@ -187,6 +204,7 @@ func makeTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*testPeer, *te
 				rawPeer0.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				DefaultMessageQueueConfig,
 			),
 		),
 		inboundMsgChan: rawPeer1.inboundMsgChan,
@@ -223,6 +241,7 @@ func TestReady(t *testing.T) {
 			rawPeer1.nodeID,
 			logging.NoLog{},
 			throttling.NewNoOutboundThrottler(),
+			DefaultMessageQueueConfig,
 		),
 	)
 
@@ -238,6 +257,7 @@ func TestReady(t *testing.T) {
 			rawPeer0.nodeID,
 			logging.NoLog{},
 			throttling.NewNoOutboundThrottler(),
+			DefaultMessageQueueConfig,
 		),
 	)
 
@@ -271,6 +291,67 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendZstdDictionary(t *testing.T) {
+	dictionary, err := os.ReadFile(filepath.Join("..", "..", "utils", "compression", "zstd_dictionary.bin"))
+	require.NoError(t, err)
+	dictionaryID, err := compression.ZstdDictionaryID(dictionary)
+	require.NoError(t, err)
+
+	compressionConfig := message.CompressionConfig{
+		Type: compression.TypeZstd,
+		ZstdDictionaries: map[message.Op][]byte{
+			message.AppGossipOp: dictionary,
+		},
+	}
+	appBytes := []byte(`{"type": "gossip", "chain": "C", "txs": [{"nonce": 42, "gasPrice": "25000000000"}]}`)
+
+	tests := []struct {
+		name                  string
+		receiverHasDictionary bool
+	}{
+		{
+			name:                  "receiver has dictionary",
+			receiverHasDictionary: true,
+		},
+		{
+			name:                  "receiver doesn't have dictionary",
+			receiverHasDictionary: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+			rawPeer0.config.MessageCreator = newMessageCreatorWithCompression(t, compressionConfig)
+			if test.receiverHasDictionary {
+				rawPeer1.config.MessageCreator = newMessageCreatorWithCompression(t, compressionConfig)
+			}
+			peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+			require.NoError(peer0.AwaitReady(context.Background()))
+			require.NoError(peer1.AwaitReady(context.Background()))
+
+			peer1Dictionaries := peer0.Peer.(*peer).zstdDictionaries.Get()
+			require.Equal(test.receiverHasDictionary, peer1Dictionaries.Contains(dictionaryID))
+
+			outboundMsg, err := rawPeer0.config.MessageCreator.AppGossip(ids.Empty, appBytes)
+			require.NoError(err)
+			require.Equal(dictionaryID, outboundMsg.ZstdDictionary())
+			require.True(peer0.Send(context.Background(), outboundMsg))
+
+			// The receiver is only able to parse the message if it was
+			// compressed with a dictionary the receiver has.
+			inboundMsg := <-peer1.inboundMsgChan
+			require.Equal(message.AppGossipOp, inboundMsg.Op())
+			require.Equal(appBytes, inboundMsg.Message().(*p2p.AppGossip).AppBytes)
+
+			peer1.StartClose()
+			require.NoError(peer0.AwaitClosed(context.Background()))
+			require.NoError(peer1.AwaitClosed(context.Background()))
+		})
+	}
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
@@ -419,6 +500,7 @@ func TestInvalidBLSKeyDisconnects(t *testing.T) {
 				rawPeer1.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				DefaultMessageQueueConfig,
 			),
 		),
 		inboundMsgChan: rawPeer0.inboundMsgChan,
@@ -434,6 +516,7 @@ func TestInvalidBLSKeyDisconnects(t *testing.T) {
 				rawPeer0.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				DefaultMessageQueueConfig,
 			),
 		),
 		inboundMsgChan: rawPeer1.inboundMsgChan,
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+// PriorityClass groups outbound messages that are queued together.
+type PriorityClass int
+
+const (
+	// ConsensusClass contains the latency critical messages of consensus and
+	// of the p2p handshake.
+	ConsensusClass PriorityClass = iota
+	// BootstrappingClass contains the messages used to bootstrap and state
+	// sync chains.
+	BootstrappingClass
+	// AppClass contains the VM defined requests and responses.
+	AppClass
+	// GossipClass contains the messages that are sent unrequested to
+	// propagate data through the network.
+	GossipClass
+
+	numPriorityClasses = iota
+)
+
+// PriorityClasses lists every priority class, from the highest priority to the
+// lowest priority.
+var PriorityClasses = []PriorityClass{
+	ConsensusClass,
+	BootstrappingClass,
+	AppClass,
+	GossipClass,
+}
+
+func (c PriorityClass) String() string {
+	switch c {
+	case ConsensusClass:
+		return "consensus"
+	case BootstrappingClass:
+		return "bootstrapping"
+	case AppClass:
+		return "app"
+	case GossipClass:
+		return "gossip"
+	default:
+		return "unknown"
+	}
+}
+
+// PriorityClassOf returns the class that messages of type [op] are queued in.
+func PriorityClassOf(op message.Op) PriorityClass {
+	switch op {
+	case message.GetStateSummaryFrontierOp,
+		message.StateSummaryFrontierOp,
+		message.GetAcceptedStateSummaryOp,
+		message.AcceptedStateSummaryOp,
+		message.GetAcceptedFrontierOp,
+		message.AcceptedFrontierOp,
+		message.GetAcceptedOp,
+		message.AcceptedOp,
+		message.GetAncestorsOp,
+		message.AncestorsOp:
+		return BootstrappingClass
+	case message.AppRequestOp,
+		message.AppResponseOp,
+		message.AppErrorOp,
+		message.CrossChainAppRequestOp,
+		message.CrossChainAppResponseOp,
+		message.CrossChainAppErrorOp:
+		return AppClass
+	case message.AppGossipOp,
+		message.GetPeerListOp,
+		message.PeerListOp:
+		return GossipClass
+	default:
+		return ConsensusClass
+	}
+}
+
+// PriorityClassConfig describes how the messages of a priority class share the
+// outbound queue of a peer.
+type PriorityClassConfig struct {
+	// Weight is the share of the bandwidth that the class receives while
+	// other classes have messages queued. A weight of 0 is treated as 1.
+	Weight uint64 `json:"weight"`
+
+	// MaxBytes is the maximum number of bytes of messages of the class that
+	// can be queued. If 0, the class is only limited by the outbound message
+	// throttler.
+	MaxBytes uint64 `json:"maxBytes"`
+}
+
+// MessageQueueConfig configures each priority class of a peer's outbound
+// message queue.
+type MessageQueueConfig struct {
+	Consensus     PriorityClassConfig `json:"consensus"`
+	Bootstrapping PriorityClassConfig `json:"bootstrapping"`
+	App           PriorityClassConfig `json:"app"`
+	Gossip        PriorityClassConfig `json:"gossip"`
+}
+
+var DefaultMessageQueueConfig = MessageQueueConfig{
+	Consensus: PriorityClassConfig{
+		Weight: 8,
+	},
+	Bootstrapping: PriorityClassConfig{
+		Weight:   2,
+		MaxBytes: 32 * units.MiB,
+	},
+	App: PriorityClassConfig{
+		Weight:   4,
+		MaxBytes: 8 * units.MiB,
+	},
+	Gossip: PriorityClassConfig{
+		Weight:   1,
+		MaxBytes: 4 * units.MiB,
+	},
+}
+
+// Class returns the configuration of [class].
+func (c *MessageQueueConfig) Class(class PriorityClass) PriorityClassConfig {
+	switch class {
+	case BootstrappingClass:
+		return c.Bootstrapping
+	case AppClass:
+		return c.App
+	case GossipClass:
+		return c.Gossip
+	default:
+		return c.Consensus
+	}
+}
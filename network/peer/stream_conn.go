@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"bufio"
+	"io"
+	"net"
+)
+
+// StreamConn is a connection that sends the messages of each priority class
+// over an independent stream, so that a large message of one class doesn't
+// delay the messages of the other classes.
+//
+// Reading from a StreamConn returns whole messages, from any of the streams.
+type StreamConn interface {
+	net.Conn
+
+	// Stream returns the stream that the messages of [class] are written to.
+	Stream(class PriorityClass) (io.Writer, error)
+}
+
+// messageWriter buffers the messages written to a connection. If the
+// connection is a StreamConn, the messages of each priority class are buffered
+// separately and written to the class's stream.
+type messageWriter struct {
+	conn StreamConn
+	size int
+
+	// If [conn] isn't a StreamConn, every class shares the first writer.
+	writers [numPriorityClasses]*bufio.Writer
+}
+
+func newMessageWriter(conn net.Conn, size int) *messageWriter {
+	w := &messageWriter{
+		size: size,
+	}
+	if streamConn, ok := conn.(StreamConn); ok {
+		w.conn = streamConn
+		return w
+	}
+
+	writer := bufio.NewWriterSize(conn, size)
+	for i := range w.writers {
+		w.writers[i] = writer
+	}
+	return w
+}
+
+// Writer returns the writer that messages of [class] should be written to.
+func (w *messageWriter) Writer(class PriorityClass) (io.Writer, error) {
+	if writer := w.writers[class]; writer != nil {
+		return writer, nil
+	}
+
+	stream, err := w.conn.Stream(class)
+	if err != nil {
+		return nil, err
+	}
+	writer := bufio.NewWriterSize(stream, w.size)
+	w.writers[class] = writer
+	return writer, nil
+}
+
+// Flush writes all the buffered messages to the connection.
+func (w *messageWriter) Flush() error {
+	for i, writer := range w.writers {
+		// Writers shared by multiple classes only need to be flushed once.
+		if writer == nil || (i > 0 && writer == w.writers[i-1]) {
+			continue
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
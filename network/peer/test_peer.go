@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/ips"
@@ -63,10 +64,7 @@ func StartTestPeer(
 	}
 
 	tlsConfg := TLSConfig(*tlsCert, nil)
-	clientUpgrader := NewTLSClientUpgrader(
-		tlsConfg,
-		prometheus.NewCounter(prometheus.CounterOpts{}),
-	)
+	clientUpgrader := NewTLSClientUpgrader(tlsConfg)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
 	if err != nil {
@@ -77,7 +75,9 @@ func StartTestPeer(
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 		"",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		10*time.Second,
 	)
 	if err != nil {
@@ -119,7 +119,7 @@ func StartTestPeer(
 			Network:              TestNetwork,
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(networkID),
-			MySubnets:            set.Set[ids.ID]{},
+			MySubnets:            utils.NewAtomic(set.Set[ids.ID]{}),
 			Beacons:              validators.NewManager(),
 			Validators:           validators.NewManager(),
 			NetworkID:            networkID,
@@ -127,6 +127,7 @@ func StartTestPeer(
 			PongTimeout:          constants.DefaultPingPongTimeout,
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
+			PeerScorer:           tracker.NewNoPeerScorer(),
 			UptimeCalculator:     uptime.NoOpCalculator,
 			IPSigner:             NewIPSigner(signerIP, tlsKey, blsKey),
 		},
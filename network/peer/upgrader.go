@@ -6,15 +6,18 @@ package peer
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
 
-	"github.com/prometheus/client_golang/prometheus"
-
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/staking"
 )
 
 var (
+	// ErrInvalidCert is returned by an Upgrader when the peer's certificate
+	// isn't a valid staking certificate.
+	ErrInvalidCert = errors.New("invalid staking certificate")
+
 	errNoCert = errors.New("tls handshake finished with no peer certificate")
 
 	_ Upgrader = (*tlsServerUpgrader)(nil)
@@ -27,54 +30,58 @@ type Upgrader interface {
 }
 
 type tlsServerUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config *tls.Config
 }
 
-func NewTLSServerUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSServerUpgrader(config *tls.Config) Upgrader {
 	return &tlsServerUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config: config,
 	}
 }
 
 func (t *tlsServerUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Server(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Server(conn, t.config))
 }
 
 type tlsClientUpgrader struct {
-	config       *tls.Config
-	invalidCerts prometheus.Counter
+	config *tls.Config
 }
 
-func NewTLSClientUpgrader(config *tls.Config, invalidCerts prometheus.Counter) Upgrader {
+func NewTLSClientUpgrader(config *tls.Config) Upgrader {
 	return &tlsClientUpgrader{
-		config:       config,
-		invalidCerts: invalidCerts,
+		config: config,
 	}
 }
 
 func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
-	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
+	return connToIDAndCert(tls.Client(conn, t.config))
 }
 
-func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+func connToIDAndCert(conn *tls.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.EmptyNodeID, nil, nil, err
 	}
 
-	state := conn.ConnectionState()
+	nodeID, cert, err := StateToIDAndCert(conn.ConnectionState())
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	return nodeID, conn, cert, nil
+}
+
+// StateToIDAndCert returns the NodeID and the staking certificate of the peer
+// that completed the TLS handshake described by [state].
+func StateToIDAndCert(state tls.ConnectionState) (ids.NodeID, *staking.Certificate, error) {
 	if len(state.PeerCertificates) == 0 {
-		return ids.EmptyNodeID, nil, nil, errNoCert
+		return ids.EmptyNodeID, nil, errNoCert
 	}
 
 	tlsCert := state.PeerCertificates[0]
 	peerCert, err := staking.ParseCertificate(tlsCert.Raw)
 	if err != nil {
-		invalidCerts.Inc()
-		return ids.EmptyNodeID, nil, nil, err
+		return ids.EmptyNodeID, nil, fmt.Errorf("%w: %w", ErrInvalidCert, err)
 	}
 
 	nodeID := ids.NodeIDFromCert(peerCert)
-	return nodeID, conn, peerCert, nil
+	return nodeID, peerCert, nil
 }
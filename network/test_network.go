@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
@@ -83,7 +84,9 @@ func NewTestNetwork(
 		logging.NoLog{},
 		metrics,
 		"",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		constants.DefaultNetworkMaximumInboundTimeout,
 	)
 	if err != nil {
@@ -124,6 +127,7 @@ func NewTestNetwork(
 				AtLargeAllocSize:    constants.DefaultOutboundThrottlerAtLargeAllocSize,
 				NodeMaxAtLargeBytes: constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes,
 			},
+			OutboundMsgQueueConfig: peer.DefaultMessageQueueConfig,
 
 			MaxInboundConnsPerSec: constants.DefaultInboundThrottlerMaxConnsPerSec,
 		},
@@ -162,6 +166,11 @@ func NewTestNetwork(
 			MaxReconnectDelay:     constants.DefaultNetworkMaxReconnectDelay,
 		},
 
+		PeerScoreConfig: tracker.PeerScoreConfig{
+			Halflife:      constants.DefaultNetworkPeerScoreHalflife,
+			TargetLatency: constants.DefaultNetworkPeerScoreTargetLatency,
+		},
+
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
 		CompressionType:              constants.DefaultNetworkCompressionType,
 		PingFrequency:                constants.DefaultPingFrequency,
@@ -226,6 +235,13 @@ func NewTestNetwork(
 		currentValidators,
 		networkConfig.ResourceTracker.DiskTracker(),
 	)
+	networkConfig.PeerScorer = tracker.NewPeerScorer(
+		&networkConfig.PeerScoreConfig,
+		networkConfig.ResourceTracker.CPUTracker(),
+		networkConfig.CPUTargeter,
+		networkConfig.ResourceTracker.DiskTracker(),
+		networkConfig.DiskTargeter,
+	)
 
 	networkConfig.MyIPPort = ips.NewDynamicIPPort(net.IPv4zero, 1)
 
@@ -234,14 +250,17 @@ func NewTestNetwork(
 		msgCreator,
 		metrics,
 		log,
-		newNoopListener(),
-		dialer.NewDialer(
-			constants.NetworkType,
-			dialer.Config{
-				ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
-				ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
-			},
-			log,
+		transport.NewTLS(
+			newNoopListener(),
+			dialer.NewDialer(
+				constants.NetworkType,
+				dialer.Config{
+					ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
+					ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+				},
+				log,
+			),
+			tlsConfig,
 		),
 		router,
 	)
@@ -0,0 +1,202 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// nextProto is the ALPN protocol negotiated by QUIC connections
+const nextProto = "avalanche"
+
+var (
+	errNotQUICConn = errors.New("connection is not a QUIC connection")
+
+	_ Transport     = (*quicTransport)(nil)
+	_ net.Listener  = (*quicListener)(nil)
+	_ dialer.Dialer = (*quicDialer)(nil)
+	_ peer.Upgrader = quicUpgrader{}
+)
+
+type quicTransport struct {
+	listener *quicListener
+	dialer   *quicDialer
+}
+
+// NewQUIC returns a transport that sends and receives QUIC packets over
+// [packetConn]. [config] is used for the TLS handshake of the QUIC
+// connections. The returned connections send each priority class of messages
+// over an independent stream, and close if the peer sends a message larger
+// than [maxMessageSize].
+func NewQUIC(
+	packetConn net.PacketConn,
+	config *tls.Config,
+	dialerConfig dialer.Config,
+	maxMessageSize uint32,
+	log logging.Logger,
+) (Transport, error) {
+	tlsConfig := config.Clone()
+	tlsConfig.NextProtos = []string{nextProto}
+	quicConfig := &quic.Config{
+		// Peers only open unidirectional streams, one for each priority
+		// class.
+		MaxIncomingStreams:    -1,
+		MaxIncomingUniStreams: int64(len(peer.PriorityClasses)),
+	}
+
+	transport := &quic.Transport{
+		Conn: packetConn,
+	}
+	listener, err := transport.Listen(tlsConfig, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var throttler throttling.DialThrottler
+	if dialerConfig.ThrottleRps <= 0 {
+		throttler = throttling.NewNoDialThrottler()
+	} else {
+		throttler = throttling.NewDialThrottler(int(dialerConfig.ThrottleRps))
+	}
+	log.Debug(
+		"creating QUIC transport",
+		zap.Stringer("address", packetConn.LocalAddr()),
+		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
+		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+	)
+	return &quicTransport{
+		listener: &quicListener{
+			packetConn:     packetConn,
+			transport:      transport,
+			listener:       listener,
+			maxMessageSize: maxMessageSize,
+		},
+		dialer: &quicDialer{
+			transport:      transport,
+			tlsConfig:      tlsConfig,
+			quicConfig:     quicConfig,
+			timeout:        dialerConfig.ConnectionTimeout,
+			maxMessageSize: maxMessageSize,
+			log:            log,
+			throttler:      throttler,
+		},
+	}, nil
+}
+
+func (t *quicTransport) Listener() net.Listener {
+	return t.listener
+}
+
+func (t *quicTransport) Dialer() dialer.Dialer {
+	return t.dialer
+}
+
+func (*quicTransport) ServerUpgrader() peer.Upgrader {
+	return quicUpgrader{}
+}
+
+func (*quicTransport) ClientUpgrader() peer.Upgrader {
+	return quicUpgrader{}
+}
+
+type quicListener struct {
+	packetConn     net.PacketConn
+	transport      *quic.Transport
+	listener       *quic.Listener
+	maxMessageSize uint32
+}
+
+// Accept returns the next connection that completed the QUIC handshake.
+func (l *quicListener) Accept() (net.Conn, error) {
+	conn, err := l.listener.Accept(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return newQUICConn(conn, l.maxMessageSize), nil
+}
+
+// Close stops accepting connections and closes all the QUIC connections of
+// the transport.
+func (l *quicListener) Close() error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		l.listener.Close(),
+		l.transport.Close(),
+		l.packetConn.Close(),
+	)
+	return errs.Err
+}
+
+func (l *quicListener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+type quicDialer struct {
+	transport      *quic.Transport
+	tlsConfig      *tls.Config
+	quicConfig     *quic.Config
+	timeout        time.Duration
+	maxMessageSize uint32
+	log            logging.Logger
+	throttler      throttling.DialThrottler
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	if err := d.throttler.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	d.log.Verbo("dialing",
+		zap.Stringer("ip", ip),
+	)
+
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+	addr := &net.UDPAddr{
+		IP:   ip.IP,
+		Port: int(ip.Port),
+	}
+	conn, err := d.transport.Dial(ctx, addr, d.tlsConfig, d.quicConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+	return newQUICConn(conn, d.maxMessageSize), nil
+}
+
+// quicUpgrader authenticates the peer of a QUIC connection. The TLS handshake
+// is part of the QUIC handshake, so both inbound and outbound connections are
+// upgraded the same way.
+type quicUpgrader struct{}
+
+func (quicUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	quicConn, ok := conn.(*quicConn)
+	if !ok {
+		return ids.EmptyNodeID, nil, nil, fmt.Errorf("%w: %T", errNotQUICConn, conn)
+	}
+
+	nodeID, cert, err := peer.StateToIDAndCert(quicConn.conn.ConnectionState().TLS)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	return nodeID, quicConn, cert, nil
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	errUnknownPriorityClass     = errors.New("unknown priority class")
+	errMaxMessageLengthExceeded = errors.New("maximum message length exceeded")
+
+	_ peer.StreamConn = (*quicConn)(nil)
+)
+
+// quicConn sends the messages of each priority class over a separate
+// unidirectional QUIC stream. The first byte written to a stream is the
+// priority class of the stream.
+//
+// Messages are expected to be length prefixed. Reads return whole messages,
+// including their length prefix, from any of the streams the peer opened.
+// Messages from other streams are only read after the first message of the
+// peer's consensus stream, which is the peer's handshake.
+//
+// Each stream buffers at most one message that wasn't read yet, so a stream
+// isn't blocked by the messages of the other streams. If messages of multiple
+// streams are buffered, the message of the highest priority class is read
+// first.
+type quicConn struct {
+	conn           quic.Connection
+	maxMessageSize uint32
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	// handshakeRead is closed once the first message of a consensus stream
+	// has been read.
+	handshakeOnce sync.Once
+	handshakeRead chan struct{}
+
+	// messages read from the peer's streams, by priority class
+	messages map[peer.PriorityClass]chan []byte
+	// messageReady is signaled after a message is added to [messages]
+	messageReady chan struct{}
+
+	// readLock serializes Read calls
+	readLock sync.Mutex
+	// unread is the remainder of the message currently being read
+	unread []byte
+
+	// lock protects the deadlines and the streams
+	lock          sync.Mutex
+	readDeadline  time.Time
+	writeDeadline time.Time
+	streams       map[peer.PriorityClass]quic.SendStream
+}
+
+func newQUICConn(conn quic.Connection, maxMessageSize uint32) *quicConn {
+	c := &quicConn{
+		conn:           conn,
+		maxMessageSize: maxMessageSize,
+		closed:         make(chan struct{}),
+		handshakeRead:  make(chan struct{}),
+		messages:       make(map[peer.PriorityClass]chan []byte, len(peer.PriorityClasses)),
+		messageReady:   make(chan struct{}, 1),
+		streams:        make(map[peer.PriorityClass]quic.SendStream),
+	}
+	for _, class := range peer.PriorityClasses {
+		c.messages[class] = make(chan []byte, 1)
+	}
+	go c.acceptStreams()
+	return c
+}
+
+func (c *quicConn) Stream(class peer.PriorityClass) (io.Writer, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if stream, ok := c.streams[class]; ok {
+		return stream, nil
+	}
+
+	stream, err := c.conn.OpenUniStream()
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SetWriteDeadline(c.writeDeadline); err != nil {
+		return nil, err
+	}
+	if _, err := stream.Write([]byte{byte(class)}); err != nil {
+		return nil, err
+	}
+	c.streams[class] = stream
+	return stream, nil
+}
+
+func (c *quicConn) Read(b []byte) (int, error) {
+	c.readLock.Lock()
+	defer c.readLock.Unlock()
+
+	if len(c.unread) == 0 {
+		msg, err := c.nextMessage()
+		if err != nil {
+			return 0, err
+		}
+		c.unread = msg
+	}
+
+	n := copy(b, c.unread)
+	c.unread = c.unread[n:]
+	return n, nil
+}
+
+// nextMessage blocks until a message is read from one of the peer's streams.
+// The read deadline is only checked when the wait begins.
+func (c *quicConn) nextMessage() ([]byte, error) {
+	c.lock.Lock()
+	deadline := c.readDeadline
+	c.lock.Unlock()
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for {
+		for _, class := range peer.PriorityClasses {
+			select {
+			case msg := <-c.messages[class]:
+				return msg, nil
+			default:
+			}
+		}
+
+		select {
+		case <-c.messageReady:
+		case <-timeout:
+			return nil, os.ErrDeadlineExceeded
+		case <-c.closed:
+			return nil, net.ErrClosed
+		case <-c.conn.Context().Done():
+			return nil, io.EOF
+		}
+	}
+}
+
+// Write writes [b] to the consensus stream.
+func (c *quicConn) Write(b []byte) (int, error) {
+	stream, err := c.Stream(peer.ConsensusClass)
+	if err != nil {
+		return 0, err
+	}
+	return stream.Write(b)
+}
+
+func (c *quicConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.conn.CloseWithError(0, "")
+	})
+	return err
+}
+
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *quicConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *quicConn) SetReadDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.readDeadline = t
+	return nil
+}
+
+func (c *quicConn) SetWriteDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeDeadline = t
+	for _, stream := range c.streams {
+		if err := stream.SetWriteDeadline(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *quicConn) acceptStreams() {
+	for {
+		stream, err := c.conn.AcceptUniStream(c.conn.Context())
+		if err != nil {
+			// The connection was closed
+			_ = c.Close()
+			return
+		}
+		go func() {
+			if err := c.readStream(stream); err != nil {
+				_ = c.Close()
+			}
+		}()
+	}
+}
+
+// readStream reads the messages of [stream] until the stream ends or this
+// connection is closed.
+func (c *quicConn) readStream(stream quic.ReceiveStream) error {
+	reader := bufio.NewReader(stream)
+	classByte, err := reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	class := peer.PriorityClass(classByte)
+	if !slices.Contains(peer.PriorityClasses, class) {
+		return fmt.Errorf("%w: %d", errUnknownPriorityClass, classByte)
+	}
+
+	msgLenBytes := make([]byte, wrappers.IntLen)
+	for {
+		if _, err := io.ReadFull(reader, msgLenBytes); err != nil {
+			return err
+		}
+		msgLen := binary.BigEndian.Uint32(msgLenBytes)
+		if msgLen > c.maxMessageSize {
+			return fmt.Errorf(
+				"%w; the message length %d exceeds the specified limit %d",
+				errMaxMessageLengthExceeded,
+				msgLen,
+				c.maxMessageSize,
+			)
+		}
+
+		msg := make([]byte, wrappers.IntLen+msgLen)
+		copy(msg, msgLenBytes)
+		if _, err := io.ReadFull(reader, msg[wrappers.IntLen:]); err != nil {
+			return err
+		}
+
+		if class != peer.ConsensusClass {
+			select {
+			case <-c.handshakeRead:
+			case <-c.closed:
+				return nil
+			}
+		}
+
+		select {
+		case c.messages[class] <- msg:
+		case <-c.closed:
+			return nil
+		}
+		select {
+		case c.messageReady <- struct{}{}:
+		default:
+		}
+
+		if class == peer.ConsensusClass {
+			c.handshakeOnce.Do(func() {
+				close(c.handshakeRead)
+			})
+		}
+	}
+}
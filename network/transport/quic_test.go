@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+func newTestQUIC(t *testing.T, maxMessageSize uint32) (Transport, ids.NodeID) {
+	t.Helper()
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	transport, err := NewQUIC(
+		packetConn,
+		peer.TLSConfig(*tlsCert, nil),
+		dialer.Config{
+			ConnectionTimeout: 10 * time.Second,
+		},
+		maxMessageSize,
+		logging.NoLog{},
+	)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = transport.Listener().Close()
+	})
+	return transport, ids.NodeIDFromCert(cert)
+}
+
+// connectTestQUIC returns the upgraded client and server sides of a QUIC
+// connection from [client] to [server].
+func connectTestQUIC(t *testing.T, client Transport, clientID ids.NodeID, server Transport, serverID ids.NodeID) (net.Conn, net.Conn) {
+	t.Helper()
+	require := require.New(t)
+
+	serverIPPort, err := ips.ToIPPort(server.Listener().Addr().String())
+	require.NoError(err)
+
+	clientConn, err := client.Dialer().Dial(context.Background(), serverIPPort)
+	require.NoError(err)
+	serverConn, err := server.Listener().Accept()
+	require.NoError(err)
+
+	nodeID, clientConn, _, err := client.ClientUpgrader().Upgrade(clientConn)
+	require.NoError(err)
+	require.Equal(serverID, nodeID)
+
+	nodeID, serverConn, _, err = server.ServerUpgrader().Upgrade(serverConn)
+	require.NoError(err)
+	require.Equal(clientID, nodeID)
+	return clientConn, serverConn
+}
+
+func writeTestMessage(t *testing.T, w io.Writer, msg []byte) {
+	t.Helper()
+
+	msgLen := make([]byte, wrappers.IntLen)
+	binary.BigEndian.PutUint32(msgLen, uint32(len(msg)))
+	_, err := w.Write(append(msgLen, msg...))
+	require.NoError(t, err)
+}
+
+func readTestMessage(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	require := require.New(t)
+
+	msgLen := make([]byte, wrappers.IntLen)
+	_, err := io.ReadFull(r, msgLen)
+	require.NoError(err)
+	msg := make([]byte, binary.BigEndian.Uint32(msgLen))
+	_, err = io.ReadFull(r, msg)
+	require.NoError(err)
+	return msg
+}
+
+func TestQUICStreams(t *testing.T) {
+	require := require.New(t)
+
+	client, clientID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	server, serverID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	clientConn, serverConn := connectTestQUIC(t, client, clientID, server, serverID)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	streamConn, ok := clientConn.(peer.StreamConn)
+	require.True(ok)
+
+	// Messages of other classes must only be read after the handshake, which
+	// is the first consensus message.
+	gossip, err := streamConn.Stream(peer.GossipClass)
+	require.NoError(err)
+	writeTestMessage(t, gossip, []byte("gossip"))
+
+	consensus, err := streamConn.Stream(peer.ConsensusClass)
+	require.NoError(err)
+	writeTestMessage(t, consensus, []byte("handshake"))
+
+	require.Equal([]byte("handshake"), readTestMessage(t, serverConn))
+	require.Equal([]byte("gossip"), readTestMessage(t, serverConn))
+
+	// Writing to the connection writes to the consensus stream.
+	writeTestMessage(t, serverConn, []byte("reply"))
+	require.Equal([]byte("reply"), readTestMessage(t, clientConn))
+}
+
+func TestQUICReadsByPriority(t *testing.T) {
+	require := require.New(t)
+
+	client, clientID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	server, serverID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	clientConn, serverConn := connectTestQUIC(t, client, clientID, server, serverID)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	streamConn, ok := clientConn.(peer.StreamConn)
+	require.True(ok)
+	consensus, err := streamConn.Stream(peer.ConsensusClass)
+	require.NoError(err)
+	writeTestMessage(t, consensus, []byte("handshake"))
+	require.Equal([]byte("handshake"), readTestMessage(t, serverConn))
+
+	gossip, err := streamConn.Stream(peer.GossipClass)
+	require.NoError(err)
+	writeTestMessage(t, gossip, []byte("gossip"))
+	writeTestMessage(t, consensus, []byte("consensus"))
+
+	// Wait for both messages to be buffered.
+	quicServerConn, ok := serverConn.(*quicConn)
+	require.True(ok)
+	require.Eventually(
+		func() bool {
+			return len(quicServerConn.messages[peer.GossipClass]) == 1 &&
+				len(quicServerConn.messages[peer.ConsensusClass]) == 1
+		},
+		10*time.Second,
+		time.Millisecond,
+	)
+
+	// The consensus message is read first, even though the gossip message
+	// was sent first.
+	require.Equal([]byte("consensus"), readTestMessage(t, serverConn))
+	require.Equal([]byte("gossip"), readTestMessage(t, serverConn))
+}
+
+func TestQUICMaxMessageSize(t *testing.T) {
+	require := require.New(t)
+
+	client, clientID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	server, serverID := newTestQUIC(t, 4)
+	clientConn, serverConn := connectTestQUIC(t, client, clientID, server, serverID)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	// The connection is closed once a message exceeding the limit is read.
+	writeTestMessage(t, clientConn, []byte("handshake"))
+	_, err := serverConn.Read(make([]byte, 1))
+	require.True(errors.Is(err, net.ErrClosed) || errors.Is(err, io.EOF))
+}
+
+func TestQUICReadDeadline(t *testing.T) {
+	require := require.New(t)
+
+	client, clientID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	server, serverID := newTestQUIC(t, constants.DefaultMaxMessageSize)
+	clientConn, serverConn := connectTestQUIC(t, client, clientID, server, serverID)
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	require.NoError(serverConn.SetReadDeadline(time.Now().Add(10 * time.Millisecond)))
+	_, err := serverConn.Read(make([]byte, 1))
+	require.ErrorIs(err, os.ErrDeadlineExceeded)
+}
+
+func TestQUICUpgradeInvalidConn(t *testing.T) {
+	require := require.New(t)
+
+	conn0, conn1 := net.Pipe()
+	defer conn0.Close()
+	defer conn1.Close()
+
+	_, _, _, err := quicUpgrader{}.Upgrade(conn0)
+	require.ErrorIs(err, errNotQUICConn)
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"net"
+
+	"github.com/ava-labs/avalanchego/network/throttling"
+)
+
+var _ Transport = (*throttledTransport)(nil)
+
+type throttledTransport struct {
+	Transport
+	listener net.Listener
+}
+
+// NewThrottled wraps [transport] so that its listener accepts at most
+// [maxConnsPerSec] connections per second. [maxConnsPerSec] must be
+// non-negative.
+func NewThrottled(transport Transport, maxConnsPerSec float64) Transport {
+	return &throttledTransport{
+		Transport: transport,
+		listener:  throttling.NewThrottledListener(transport.Listener(), maxConnsPerSec),
+	}
+}
+
+func (t *throttledTransport) Listener() net.Listener {
+	return t.listener
+}
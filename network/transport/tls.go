@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"crypto/tls"
+	"net"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+var _ Transport = (*tlsTransport)(nil)
+
+type tlsTransport struct {
+	listener       net.Listener
+	dialer         dialer.Dialer
+	serverUpgrader peer.Upgrader
+	clientUpgrader peer.Upgrader
+}
+
+// NewTLS returns a transport that does a TLS handshake, using [config], over
+// the connections of [listener] and [dialer].
+func NewTLS(listener net.Listener, dialer dialer.Dialer, config *tls.Config) Transport {
+	return &tlsTransport{
+		listener:       listener,
+		dialer:         dialer,
+		serverUpgrader: peer.NewTLSServerUpgrader(config),
+		clientUpgrader: peer.NewTLSClientUpgrader(config),
+	}
+}
+
+func (t *tlsTransport) Listener() net.Listener {
+	return t.listener
+}
+
+func (t *tlsTransport) Dialer() dialer.Dialer {
+	return t.dialer
+}
+
+func (t *tlsTransport) ServerUpgrader() peer.Upgrader {
+	return t.serverUpgrader
+}
+
+func (t *tlsTransport) ClientUpgrader() peer.Upgrader {
+	return t.clientUpgrader
+}
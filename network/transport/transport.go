@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"net"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+// Transport provides the connections that the network communicates with its
+// peers over.
+//
+// Connections returned by the Listener must be upgraded with the
+// ServerUpgrader and connections returned by the Dialer must be upgraded with
+// the ClientUpgrader. Upgrading a connection authenticates the peer by its
+// staking certificate.
+type Transport interface {
+	// Listener accepts inbound connections. Closing the listener releases the
+	// resources held by the transport.
+	Listener() net.Listener
+	// Dialer makes outbound connections.
+	Dialer() dialer.Dialer
+	// ServerUpgrader upgrades inbound connections.
+	ServerUpgrader() peer.Upgrader
+	// ClientUpgrader upgrades outbound connections.
+	ClientUpgrader() peer.Upgrader
+}
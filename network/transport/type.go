@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transport
+
+import (
+	"errors"
+	"strings"
+)
+
+var errUnknownTransportType = errors.New("unknown transport type")
+
+type Type byte
+
+const (
+	// TypeTLS is TLS over TCP
+	TypeTLS Type = iota + 1
+	// TypeQUIC is QUIC over UDP
+	TypeQUIC
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeTLS:
+		return "tls"
+	case TypeQUIC:
+		return "quic"
+	default:
+		return "unknown"
+	}
+}
+
+func TypeFromString(s string) (Type, error) {
+	switch s {
+	case TypeTLS.String():
+		return TypeTLS, nil
+	case TypeQUIC.String():
+		return TypeQUIC, nil
+	default:
+		return TypeTLS, errUnknownTransportType
+	}
+}
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	if _, err := b.WriteString(`"`); err != nil {
+		return nil, err
+	}
+	if _, err := b.WriteString(t.String()); err != nil {
+		return nil, err
+	}
+	if _, err := b.WriteString(`"`); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
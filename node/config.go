@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -28,6 +29,8 @@ import (
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexBackfill        bool `json:"indexBackfill"`
+	IndexAttributes      bool `json:"indexAttributes"`
 }
 
 type HTTPConfig struct {
@@ -43,6 +46,9 @@ type HTTPConfig struct {
 	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
 	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
 
+	// HTTPAuthConfig contains secrets, so it isn't marshalled.
+	HTTPAuthConfig server.AuthConfig `json:"-"`
+
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
 }
@@ -56,6 +62,7 @@ type APIConfig struct {
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
 	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
 	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	EventsAPIEnabled   bool `json:"eventsAPIEnabled"`
 }
 
 type IPConfig struct {
@@ -118,6 +125,17 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Directory that database checkpoints are written to
+	BackupDir string `json:"backupDir"`
+
+	// If non-empty, path to a database checkpoint to restore before the
+	// database is opened
+	RestoreDir string `json:"restoreDir"`
+
+	// If non-empty, the type of database to migrate the database from before
+	// it is opened
+	MigrateFrom string `json:"migrateFrom"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -139,6 +157,7 @@ type Config struct {
 
 	// Health
 	HealthCheckFreq time.Duration `json:"healthCheckFreq"`
+	HealthConfig    health.Config `json:"healthConfig"`
 
 	// Network configuration
 	NetworkConfig network.Config `json:"networkConfig"`
@@ -174,6 +193,9 @@ type Config struct {
 	ChainConfigs map[string]chains.ChainConfig `json:"-"`
 	ChainAliases map[ids.ID][]string           `json:"chainAliases"`
 
+	// Reads the chain and subnet configs again when they are reloaded
+	ConfigReader chains.ConfigReader `json:"-"`
+
 	VMAliases map[ids.ID][]string `json:"vmAliases"`
 
 	// Halflife to use for the processing requests tracker.
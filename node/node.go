@@ -33,9 +33,11 @@ import (
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/backup"
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/meterdb"
+	"github.com/ava-labs/avalanchego/database/migration"
 	"github.com/ava-labs/avalanchego/database/pebble"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
@@ -47,7 +49,8 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
-	"github.com/ava-labs/avalanchego/network/throttling"
+	"github.com/ava-labs/avalanchego/network/transport"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -70,6 +73,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/avm"
@@ -87,15 +91,23 @@ const (
 	stakingPortName = constants.AppName + "-staking"
 	httpPortName    = constants.AppName + "-http"
 
+	// quicNetworkType is the network the QUIC transport listens on
+	quicNetworkType = "udp"
+
 	ipResolutionTimeout = 30 * time.Second
+
+	dbMigrationBatchSize         = 4 * units.MiB
+	dbMigrationProgressFrequency = 30 * time.Second
 )
 
 var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix        = []byte{0x00}
+	keystoreDBPrefix       = []byte("keystore")
+	trackedSubnetsDBPrefix = []byte("tracked subnets")
+	managedPeersDBPrefix   = []byte("managed peers")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -173,6 +185,10 @@ func New(
 		return nil, fmt.Errorf("problem initializing database: %w", err)
 	}
 
+	if err := n.initTrackedSubnets(); err != nil { // Apply the persisted tracked subnet changes
+		return nil, fmt.Errorf("couldn't initialize tracked subnets: %w", err)
+	}
+
 	if err := n.initKeystoreAPI(); err != nil { // Start the Keystore API
 		return nil, fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
@@ -188,7 +204,11 @@ func New(
 		n.Log,
 		n.MetricsRegisterer,
 		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionType,
+		message.CompressionConfig{
+			Type:             n.Config.NetworkConfig.CompressionType,
+			OpTypes:          n.Config.NetworkConfig.OpCompressionTypes,
+			ZstdDictionaries: n.Config.NetworkConfig.ZstdDictionaries,
+		},
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
@@ -241,6 +261,9 @@ func New(
 	if err := n.initIndexer(); err != nil {
 		return nil, fmt.Errorf("couldn't initialize indexer: %w", err)
 	}
+	if err := n.initEventsAPI(); err != nil {
+		return nil, fmt.Errorf("couldn't initialize events API: %w", err)
+	}
 
 	n.health.Start(context.TODO(), n.Config.HealthCheckFreq)
 	n.initProfiler()
@@ -286,6 +309,9 @@ type Node struct {
 	// Manages shared memory
 	sharedMemory *atomic.Memory
 
+	// Persists the changes made to the tracked subnets at runtime
+	trackedSubnetsDB database.Database
+
 	// Monitors node health and runs health checks
 	health health.Health
 
@@ -376,6 +402,10 @@ type Node struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	diskTargeter tracker.Targeter
+
+	// Scores peers based on their response latency, failure rate, invalid
+	// messages and resource usage.
+	peerScorer tracker.PeerScorer
 }
 
 /*
@@ -405,15 +435,31 @@ func (n *Node) initNetworking() error {
 	// 1: https://apple.stackexchange.com/questions/393715/do-you-want-the-application-main-to-accept-incoming-network-connections-pop
 	// 2: https://github.com/golang/go/issues/56998
 	listenAddress := net.JoinHostPort(n.Config.ListenHost, strconv.FormatUint(uint64(n.Config.ListenPort), 10))
-	listener, err := net.Listen(constants.NetworkType, listenAddress)
-	if err != nil {
-		return err
-	}
-	// Wrap listener so it will only accept a certain number of incoming connections per second
-	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
+	var (
+		listener   net.Listener
+		packetConn net.PacketConn
+		err        error
+	)
+	switch n.Config.NetworkConfig.Transport {
+	case transport.TypeQUIC:
+		packetConn, err = net.ListenPacket(quicNetworkType, listenAddress)
+		if err != nil {
+			return err
+		}
+
+		// Record the bound address to enable inclusion in process context
+		// file.
+		n.stakingAddress = packetConn.LocalAddr().String()
+	default:
+		listener, err = net.Listen(constants.NetworkType, listenAddress)
+		if err != nil {
+			return err
+		}
 
-	// Record the bound address to enable inclusion in process context file.
-	n.stakingAddress = listener.Addr().String()
+		// Record the bound address to enable inclusion in process context
+		// file.
+		n.stakingAddress = listener.Addr().String()
+	}
 	ipPort, err := ips.ToIPPort(n.stakingAddress)
 	if err != nil {
 		return err
@@ -582,6 +628,14 @@ func (n *Node) initNetworking() error {
 		}()
 	}
 
+	n.peerScorer = tracker.NewPeerScorer(
+		&n.Config.NetworkConfig.PeerScoreConfig,
+		n.resourceTracker.CPUTracker(),
+		n.cpuTargeter,
+		n.resourceTracker.DiskTracker(),
+		n.diskTargeter,
+	)
+
 	// add node configs to network config
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
@@ -598,14 +652,40 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.PeerScorer = n.peerScorer
+	n.Config.NetworkConfig.ManagedPeersDB = prefixdb.New(managedPeersDBPrefix, n.DB)
+
+	var p2pTransport transport.Transport
+	switch n.Config.NetworkConfig.Transport {
+	case transport.TypeQUIC:
+		p2pTransport, err = transport.NewQUIC(
+			packetConn,
+			tlsConfig,
+			n.Config.NetworkConfig.DialerConfig,
+			constants.DefaultMaxMessageSize,
+			n.Log,
+		)
+		if err != nil {
+			_ = packetConn.Close()
+			return err
+		}
+	default:
+		p2pTransport = transport.NewTLS(
+			listener,
+			dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+			tlsConfig,
+		)
+	}
+	// Wrap the listener so it will only accept a certain number of incoming
+	// connections per second
+	p2pTransport = transport.NewThrottled(p2pTransport, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
 		n.msgCreator,
 		n.MetricsRegisterer,
 		n.Log,
-		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		p2pTransport,
 		consensusRouter,
 	)
 
@@ -718,13 +798,94 @@ func (n *Node) Dispatch() error {
  ******************************************************************************
  */
 
+// databasePath returns the directory that the on-disk database of type
+// [dbName] is stored in.
+func (n *Node) databasePath(dbName string) string {
+	if dbName == pebble.Name {
+		return filepath.Join(n.Config.DatabaseConfig.Path, pebble.Name)
+	}
+	// Prior to v1.10.15, the only on-disk database was leveldb, and its files
+	// went to [dbPath]/[networkID]/v1.4.5.
+	return filepath.Join(n.Config.DatabaseConfig.Path, version.CurrentDatabase.String())
+}
+
+// restoreDatabase copies the configured database checkpoint into the database
+// directory. The checkpoint must match the configured database type, the
+// current database version, and the network ID. The restore is skipped if the
+// database already exists.
+func (n *Node) restoreDatabase() error {
+	dbName := n.Config.DatabaseConfig.Name
+	if dbName != leveldb.Name && dbName != pebble.Name {
+		return fmt.Errorf("can't restore a checkpoint into a %s database", dbName)
+	}
+
+	var (
+		checkpointDir = n.Config.DatabaseConfig.RestoreDir
+		dbPath        = n.databasePath(dbName)
+	)
+	metadata, err := backup.Restore(checkpointDir, dbPath, dbName, n.Config.NetworkID)
+	if errors.Is(err, backup.ErrDatabaseExists) {
+		// The checkpoint was restored by a previous run, or the database was
+		// created before the restore was configured.
+		n.Log.Warn("skipping database checkpoint restore",
+			zap.String("reason", "database already exists"),
+			zap.String("checkpoint", checkpointDir),
+			zap.String("path", dbPath),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("couldn't restore database checkpoint %s: %w", checkpointDir, err)
+	}
+
+	n.Log.Info("restored database checkpoint",
+		zap.String("checkpoint", checkpointDir),
+		zap.String("path", dbPath),
+		zap.String("nodeVersion", metadata.NodeVersion),
+		zap.Time("timestamp", metadata.Timestamp),
+		zap.Uint64("numKeys", metadata.NumKeys),
+	)
+	return nil
+}
+
+// migrateDatabase copies the database of the configured migration source type
+// to the configured database type. The source database isn't modified.
+func (n *Node) migrateDatabase() error {
+	var (
+		sourceName = n.Config.DatabaseConfig.MigrateFrom
+		targetName = n.Config.DatabaseConfig.Name
+	)
+	err := migration.Migrate(context.TODO(), migration.Config{
+		Log:               n.Log,
+		SourceName:        sourceName,
+		SourcePath:        n.databasePath(sourceName),
+		TargetName:        targetName,
+		TargetPath:        n.databasePath(targetName),
+		BatchSize:         dbMigrationBatchSize,
+		ProgressFrequency: dbMigrationProgressFrequency,
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't migrate database from %s to %s: %w", sourceName, targetName, err)
+	}
+	return nil
+}
+
 func (n *Node) initDatabase() error {
+	if n.Config.DatabaseConfig.RestoreDir != "" {
+		if err := n.restoreDatabase(); err != nil {
+			return err
+		}
+	}
+	if n.Config.DatabaseConfig.MigrateFrom != "" {
+		if err := n.migrateDatabase(); err != nil {
+			return err
+		}
+	}
+
 	// start the db
 	switch n.Config.DatabaseConfig.Name {
 	case leveldb.Name:
-		// Prior to v1.10.15, the only on-disk database was leveldb, and its
-		// files went to [dbPath]/[networkID]/v1.4.5.
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, version.CurrentDatabase.String())
+		dbPath := n.databasePath(n.Config.DatabaseConfig.Name)
 		var err error
 		n.DB, err = leveldb.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, "db_internal", n.MetricsRegisterer)
 		if err != nil {
@@ -733,7 +894,7 @@ func (n *Node) initDatabase() error {
 	case memdb.Name:
 		n.DB = memdb.New()
 	case pebble.Name:
-		dbPath := filepath.Join(n.Config.DatabaseConfig.Path, pebble.Name)
+		dbPath := n.databasePath(n.Config.DatabaseConfig.Name)
 		var err error
 		n.DB, err = pebble.New(dbPath, n.Config.DatabaseConfig.Config, n.Log, "db_internal", n.MetricsRegisterer)
 		if err != nil {
@@ -806,6 +967,21 @@ func (n *Node) initDatabase() error {
 	return nil
 }
 
+// initTrackedSubnets applies the changes that were made to the tracked subnets
+// through the admin API. These changes take precedence over the tracked subnets
+// provided in the config.
+func (n *Node) initTrackedSubnets() error {
+	n.trackedSubnetsDB = prefixdb.New(trackedSubnetsDBPrefix, n.DB)
+	if err := chains.ApplyTrackedSubnetChanges(n.trackedSubnetsDB, &n.Config.TrackedSubnets); err != nil {
+		return fmt.Errorf("couldn't read tracked subnet changes: %w", err)
+	}
+
+	n.Log.Info("tracking subnets",
+		zap.Reflect("subnetIDs", n.Config.TrackedSubnets),
+	)
+	return nil
+}
+
 // Set the node IDs of the peers this node should first connect to
 func (n *Node) initBootstrappers() error {
 	n.bootstrappers = validators.NewManager()
@@ -836,14 +1012,16 @@ func (n *Node) initIndexer() error {
 	txIndexerDB := prefixdb.New(indexerDBPrefix, n.DB)
 	var err error
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
-		IndexingEnabled:      n.Config.IndexAPIEnabled,
-		AllowIncompleteIndex: n.Config.IndexAllowIncomplete,
-		DB:                   txIndexerDB,
-		Log:                  n.Log,
-		BlockAcceptorGroup:   n.BlockAcceptorGroup,
-		TxAcceptorGroup:      n.TxAcceptorGroup,
-		VertexAcceptorGroup:  n.VertexAcceptorGroup,
-		APIServer:            n.APIServer,
+		IndexingEnabled:         n.Config.IndexAPIEnabled,
+		AllowIncompleteIndex:    n.Config.IndexAllowIncomplete,
+		BackfillIncompleteIndex: n.Config.IndexBackfill,
+		IndexAttributes:         n.Config.IndexAttributes,
+		DB:                      txIndexerDB,
+		Log:                     n.Log,
+		BlockAcceptorGroup:      n.BlockAcceptorGroup,
+		TxAcceptorGroup:         n.TxAcceptorGroup,
+		VertexAcceptorGroup:     n.VertexAcceptorGroup,
+		APIServer:               n.APIServer,
 		ShutdownF: func() {
 			n.Shutdown(0) // TODO put exit code here
 		},
@@ -960,6 +1138,11 @@ func (n *Node) initAPIServer() error {
 			MinVersion:   tls.VersionTLS12,
 			Certificates: []tls.Certificate{cert},
 		}
+		if n.Config.HTTPAuthConfig.RequiresClientCertificates() {
+			// Client certificates are authenticated by their fingerprints
+			// rather than by a certificate authority.
+			config.ClientAuth = tls.RequestClientCert
+		}
 		listener = tls.NewListener(listener, config)
 
 		protocol = "https"
@@ -979,6 +1162,7 @@ func (n *Node) initAPIServer() error {
 		n.MetricsRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		n.Config.HTTPAuthConfig,
 	)
 	return err
 }
@@ -1023,6 +1207,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	n.timeoutManager, err = timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
 		n.benchlistManager,
+		n.peerScorer,
 		"requests",
 		n.MetricsRegisterer,
 	)
@@ -1087,6 +1272,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			Metrics:                                 n.MetricsGatherer,
 			SubnetConfigs:                           n.Config.SubnetConfigs,
 			ChainConfigs:                            n.Config.ChainConfigs,
+			ConfigReader:                            n.Config.ConfigReader,
 			FrontierPollFrequency:                   n.Config.FrontierPollFrequency,
 			ConsensusAppConcurrency:                 n.Config.ConsensusAppConcurrency,
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
@@ -1095,11 +1281,13 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 			ApricotPhase4MinPChainHeight:            version.ApricotPhase4MinPChainHeight[n.Config.NetworkID],
 			ResourceTracker:                         n.resourceTracker,
+			PeerScorer:                              n.peerScorer,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
 			Tracer:                                  n.tracer,
 			ChainDataDir:                            n.Config.ChainDataDir,
 			Subnets:                                 subnets,
+			TrackedSubnetsDB:                        n.trackedSubnetsDB,
 		},
 	)
 
@@ -1121,6 +1309,10 @@ func (n *Node) initVMs() error {
 		vdrs = validators.NewManager()
 	}
 
+	// The P-chain modifies its tracked subnets when subnets are tracked at
+	// runtime, so it is given its own copy.
+	trackedSubnets := set.Of(n.Config.TrackedSubnets.List()...)
+
 	// Register the VMs that Avalanche supports
 	err := utils.Err(
 		n.VMManager.RegisterFactory(context.TODO(), constants.PlatformVMID, &platformvm.Factory{
@@ -1130,7 +1322,7 @@ func (n *Node) initVMs() error {
 				UptimeLockedCalculator:        n.uptimeCalculator,
 				SybilProtectionEnabled:        n.Config.SybilProtectionEnabled,
 				PartialSyncPrimaryNetwork:     n.Config.PartialSyncPrimaryNetwork,
-				TrackedSubnets:                n.Config.TrackedSubnets,
+				TrackedSubnets:                trackedSubnets,
 				TxFee:                         n.Config.TxFee,
 				CreateAssetTxFee:              n.Config.CreateAssetTxFee,
 				CreateSubnetTxFee:             n.Config.CreateSubnetTxFee,
@@ -1254,6 +1446,30 @@ func (n *Node) initMetricsAPI() error {
 	)
 }
 
+// initEventsAPI initializes the Events API, which streams the containers
+// accepted by every chain over websockets.
+// Assumes n.APIServer, n.chainManager, and the acceptor groups are already
+// initialized
+func (n *Node) initEventsAPI() error {
+	if !n.Config.EventsAPIEnabled {
+		n.Log.Info("skipping events API initialization because it has been disabled")
+		return nil
+	}
+
+	n.Log.Info("initializing events API")
+	// The accepted containers of different chains can't be replayed in
+	// order, so connections can't resume.
+	server := pubsub.New(n.Log)
+	n.chainManager.AddRegistrant(pubsub.NewChainPublisher(
+		n.Log,
+		server,
+		n.BlockAcceptorGroup,
+		n.TxAcceptorGroup,
+		n.VertexAcceptorGroup,
+	))
+	return n.APIServer.AddRoute(server, "events", "")
+}
+
 // initAdminAPI initializes the Admin API service
 // Assumes n.log, n.chainManager, and n.ValidatorAPI already initialized
 func (n *Node) initAdminAPI() error {
@@ -1273,6 +1489,10 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			NetworkID:    n.Config.NetworkID,
+			DBName:       n.Config.DatabaseConfig.Name,
+			DBBackupDir:  n.Config.DatabaseConfig.BackupDir,
+			Network:      n.Net,
 		},
 	)
 	if err != nil {
@@ -1317,7 +1537,7 @@ func (n *Node) initInfoAPI() error {
 
 	n.Log.Info("initializing info API")
 
-	service, err := info.NewService(
+	handlers, err := info.NewService(
 		info.Parameters{
 			Version:                       version.CurrentApp,
 			NodeID:                        n.ID,
@@ -1341,21 +1561,23 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.MetricsGatherer,
 	)
 	if err != nil {
 		return err
 	}
-	return n.APIServer.AddRoute(
-		service,
-		"info",
-		"",
-	)
+	for endpoint, handler := range handlers {
+		if err := n.APIServer.AddRoute(handler, "info", endpoint); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // initHealthAPI initializes the Health API service
 // Assumes n.Log, n.Net, n.APIServer, n.HTTPLog already initialized
 func (n *Node) initHealthAPI() error {
-	healthChecker, err := health.New(n.Log, n.MetricsRegisterer)
+	healthChecker, err := health.New(n.Log, n.MetricsRegisterer, n.Config.HealthConfig)
 	if err != nil {
 		return err
 	}
@@ -1424,6 +1646,16 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
+	connectPath, connectHandler := health.NewConnectHandler(n.Log, healthChecker)
+	err = n.APIServer.AddRoute(
+		connectHandler,
+		"health",
+		connectPath,
+	)
+	if err != nil {
+		return err
+	}
+
 	err = n.APIServer.AddRoute(
 		health.NewGetHandler(healthChecker.Readiness),
 		"health",
@@ -76,6 +76,10 @@ func (o *overriddenManager) RegisterCallbackListener(_ ids.ID, listener validato
 	o.manager.RegisterCallbackListener(o.subnetID, listener)
 }
 
+func (o *overriddenManager) DeregisterCallbackListener(_ ids.ID, listener validators.SetCallbackListener) {
+	o.manager.DeregisterCallbackListener(o.subnetID, listener)
+}
+
 func (o *overriddenManager) String() string {
 	return fmt.Sprintf("Overridden Validator Manager (SubnetID = %s): %s", o.subnetID, o.manager)
 }
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: health/health.proto
+
+package healthconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	health "github.com/ava-labs/avalanchego/proto/pb/health"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// HealthName is the fully-qualified name of the Health service.
+	HealthName = "health.Health"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// HealthReadinessProcedure is the fully-qualified name of the Health's Readiness RPC.
+	HealthReadinessProcedure = "/health.Health/Readiness"
+	// HealthHealthProcedure is the fully-qualified name of the Health's Health RPC.
+	HealthHealthProcedure = "/health.Health/Health"
+	// HealthLivenessProcedure is the fully-qualified name of the Health's Liveness RPC.
+	HealthLivenessProcedure = "/health.Health/Liveness"
+	// HealthStreamHealthProcedure is the fully-qualified name of the Health's StreamHealth RPC.
+	HealthStreamHealthProcedure = "/health.Health/StreamHealth"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	healthServiceDescriptor            = health.File_health_health_proto.Services().ByName("Health")
+	healthReadinessMethodDescriptor    = healthServiceDescriptor.Methods().ByName("Readiness")
+	healthHealthMethodDescriptor       = healthServiceDescriptor.Methods().ByName("Health")
+	healthLivenessMethodDescriptor     = healthServiceDescriptor.Methods().ByName("Liveness")
+	healthStreamHealthMethodDescriptor = healthServiceDescriptor.Methods().ByName("StreamHealth")
+)
+
+// HealthClient is a client for the health.Health service.
+type HealthClient interface {
+	Readiness(context.Context, *connect.Request[health.ReadinessRequest]) (*connect.Response[health.ReadinessResponse], error)
+	Health(context.Context, *connect.Request[health.HealthRequest]) (*connect.Response[health.HealthResponse], error)
+	Liveness(context.Context, *connect.Request[health.LivenessRequest]) (*connect.Response[health.LivenessResponse], error)
+	// StreamHealth sends the results of the health checks when the stream is
+	// opened and then whenever any of the checks starts or stops passing.
+	StreamHealth(context.Context, *connect.Request[health.StreamHealthRequest]) (*connect.ServerStreamForClient[health.StreamHealthResponse], error)
+}
+
+// NewHealthClient constructs a client for the health.Health service. By default, it uses the
+// Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewHealthClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) HealthClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &healthClient{
+		readiness: connect.NewClient[health.ReadinessRequest, health.ReadinessResponse](
+			httpClient,
+			baseURL+HealthReadinessProcedure,
+			connect.WithSchema(healthReadinessMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		health: connect.NewClient[health.HealthRequest, health.HealthResponse](
+			httpClient,
+			baseURL+HealthHealthProcedure,
+			connect.WithSchema(healthHealthMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		liveness: connect.NewClient[health.LivenessRequest, health.LivenessResponse](
+			httpClient,
+			baseURL+HealthLivenessProcedure,
+			connect.WithSchema(healthLivenessMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		streamHealth: connect.NewClient[health.StreamHealthRequest, health.StreamHealthResponse](
+			httpClient,
+			baseURL+HealthStreamHealthProcedure,
+			connect.WithSchema(healthStreamHealthMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// healthClient implements HealthClient.
+type healthClient struct {
+	readiness    *connect.Client[health.ReadinessRequest, health.ReadinessResponse]
+	health       *connect.Client[health.HealthRequest, health.HealthResponse]
+	liveness     *connect.Client[health.LivenessRequest, health.LivenessResponse]
+	streamHealth *connect.Client[health.StreamHealthRequest, health.StreamHealthResponse]
+}
+
+// Readiness calls health.Health.Readiness.
+func (c *healthClient) Readiness(ctx context.Context, req *connect.Request[health.ReadinessRequest]) (*connect.Response[health.ReadinessResponse], error) {
+	return c.readiness.CallUnary(ctx, req)
+}
+
+// Health calls health.Health.Health.
+func (c *healthClient) Health(ctx context.Context, req *connect.Request[health.HealthRequest]) (*connect.Response[health.HealthResponse], error) {
+	return c.health.CallUnary(ctx, req)
+}
+
+// Liveness calls health.Health.Liveness.
+func (c *healthClient) Liveness(ctx context.Context, req *connect.Request[health.LivenessRequest]) (*connect.Response[health.LivenessResponse], error) {
+	return c.liveness.CallUnary(ctx, req)
+}
+
+// StreamHealth calls health.Health.StreamHealth.
+func (c *healthClient) StreamHealth(ctx context.Context, req *connect.Request[health.StreamHealthRequest]) (*connect.ServerStreamForClient[health.StreamHealthResponse], error) {
+	return c.streamHealth.CallServerStream(ctx, req)
+}
+
+// HealthHandler is an implementation of the health.Health service.
+type HealthHandler interface {
+	Readiness(context.Context, *connect.Request[health.ReadinessRequest]) (*connect.Response[health.ReadinessResponse], error)
+	Health(context.Context, *connect.Request[health.HealthRequest]) (*connect.Response[health.HealthResponse], error)
+	Liveness(context.Context, *connect.Request[health.LivenessRequest]) (*connect.Response[health.LivenessResponse], error)
+	// StreamHealth sends the results of the health checks when the stream is
+	// opened and then whenever any of the checks starts or stops passing.
+	StreamHealth(context.Context, *connect.Request[health.StreamHealthRequest], *connect.ServerStream[health.StreamHealthResponse]) error
+}
+
+// NewHealthHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewHealthHandler(svc HealthHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	healthReadinessHandler := connect.NewUnaryHandler(
+		HealthReadinessProcedure,
+		svc.Readiness,
+		connect.WithSchema(healthReadinessMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	healthHealthHandler := connect.NewUnaryHandler(
+		HealthHealthProcedure,
+		svc.Health,
+		connect.WithSchema(healthHealthMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	healthLivenessHandler := connect.NewUnaryHandler(
+		HealthLivenessProcedure,
+		svc.Liveness,
+		connect.WithSchema(healthLivenessMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	healthStreamHealthHandler := connect.NewServerStreamHandler(
+		HealthStreamHealthProcedure,
+		svc.StreamHealth,
+		connect.WithSchema(healthStreamHealthMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/health.Health/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case HealthReadinessProcedure:
+			healthReadinessHandler.ServeHTTP(w, r)
+		case HealthHealthProcedure:
+			healthHealthHandler.ServeHTTP(w, r)
+		case HealthLivenessProcedure:
+			healthLivenessHandler.ServeHTTP(w, r)
+		case HealthStreamHealthProcedure:
+			healthStreamHealthHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedHealthHandler returns CodeUnimplemented from all methods.
+type UnimplementedHealthHandler struct{}
+
+func (UnimplementedHealthHandler) Readiness(context.Context, *connect.Request[health.ReadinessRequest]) (*connect.Response[health.ReadinessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("health.Health.Readiness is not implemented"))
+}
+
+func (UnimplementedHealthHandler) Health(context.Context, *connect.Request[health.HealthRequest]) (*connect.Response[health.HealthResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("health.Health.Health is not implemented"))
+}
+
+func (UnimplementedHealthHandler) Liveness(context.Context, *connect.Request[health.LivenessRequest]) (*connect.Response[health.LivenessResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("health.Health.Liveness is not implemented"))
+}
+
+func (UnimplementedHealthHandler) StreamHealth(context.Context, *connect.Request[health.StreamHealthRequest], *connect.ServerStream[health.StreamHealthResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("health.Health.StreamHealth is not implemented"))
+}
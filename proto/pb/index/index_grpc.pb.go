@@ -0,0 +1,438 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: index/index.proto
+
+package index
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Index_GetLastAccepted_FullMethodName        = "/index.Index/GetLastAccepted"
+	Index_GetContainerByIndex_FullMethodName    = "/index.Index/GetContainerByIndex"
+	Index_GetContainerByID_FullMethodName       = "/index.Index/GetContainerByID"
+	Index_GetContainerRange_FullMethodName      = "/index.Index/GetContainerRange"
+	Index_GetContainersByAddress_FullMethodName = "/index.Index/GetContainersByAddress"
+	Index_GetContainersByType_FullMethodName    = "/index.Index/GetContainersByType"
+	Index_GetIndex_FullMethodName               = "/index.Index/GetIndex"
+	Index_IsAccepted_FullMethodName             = "/index.Index/IsAccepted"
+	Index_StreamAccepted_FullMethodName         = "/index.Index/StreamAccepted"
+)
+
+// IndexClient is the client API for Index service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IndexClient interface {
+	GetLastAccepted(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetLastAcceptedResponse, error)
+	GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*GetContainerByIndexResponse, error)
+	GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*GetContainerByIDResponse, error)
+	GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error)
+	GetContainersByAddress(ctx context.Context, in *GetContainersByAddressRequest, opts ...grpc.CallOption) (*GetContainersByAddressResponse, error)
+	GetContainersByType(ctx context.Context, in *GetContainersByTypeRequest, opts ...grpc.CallOption) (*GetContainersByTypeResponse, error)
+	GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error)
+	IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error)
+	// StreamAccepted sends the containers at [start_index] onward in the order
+	// they were accepted and then sends each container as it is accepted.
+	StreamAccepted(ctx context.Context, in *StreamAcceptedRequest, opts ...grpc.CallOption) (Index_StreamAcceptedClient, error)
+}
+
+type indexClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndexClient(cc grpc.ClientConnInterface) IndexClient {
+	return &indexClient{cc}
+}
+
+func (c *indexClient) GetLastAccepted(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetLastAcceptedResponse, error) {
+	out := new(GetLastAcceptedResponse)
+	err := c.cc.Invoke(ctx, Index_GetLastAccepted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByIndex(ctx context.Context, in *GetContainerByIndexRequest, opts ...grpc.CallOption) (*GetContainerByIndexResponse, error) {
+	out := new(GetContainerByIndexResponse)
+	err := c.cc.Invoke(ctx, Index_GetContainerByIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerByID(ctx context.Context, in *GetContainerByIDRequest, opts ...grpc.CallOption) (*GetContainerByIDResponse, error) {
+	out := new(GetContainerByIDResponse)
+	err := c.cc.Invoke(ctx, Index_GetContainerByID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainerRange(ctx context.Context, in *GetContainerRangeRequest, opts ...grpc.CallOption) (*GetContainerRangeResponse, error) {
+	out := new(GetContainerRangeResponse)
+	err := c.cc.Invoke(ctx, Index_GetContainerRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainersByAddress(ctx context.Context, in *GetContainersByAddressRequest, opts ...grpc.CallOption) (*GetContainersByAddressResponse, error) {
+	out := new(GetContainersByAddressResponse)
+	err := c.cc.Invoke(ctx, Index_GetContainersByAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetContainersByType(ctx context.Context, in *GetContainersByTypeRequest, opts ...grpc.CallOption) (*GetContainersByTypeResponse, error) {
+	out := new(GetContainersByTypeResponse)
+	err := c.cc.Invoke(ctx, Index_GetContainersByType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) GetIndex(ctx context.Context, in *GetIndexRequest, opts ...grpc.CallOption) (*GetIndexResponse, error) {
+	out := new(GetIndexResponse)
+	err := c.cc.Invoke(ctx, Index_GetIndex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) IsAccepted(ctx context.Context, in *IsAcceptedRequest, opts ...grpc.CallOption) (*IsAcceptedResponse, error) {
+	out := new(IsAcceptedResponse)
+	err := c.cc.Invoke(ctx, Index_IsAccepted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexClient) StreamAccepted(ctx context.Context, in *StreamAcceptedRequest, opts ...grpc.CallOption) (Index_StreamAcceptedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Index_ServiceDesc.Streams[0], Index_StreamAccepted_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &indexStreamAcceptedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Index_StreamAcceptedClient interface {
+	Recv() (*StreamAcceptedResponse, error)
+	grpc.ClientStream
+}
+
+type indexStreamAcceptedClient struct {
+	grpc.ClientStream
+}
+
+func (x *indexStreamAcceptedClient) Recv() (*StreamAcceptedResponse, error) {
+	m := new(StreamAcceptedResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IndexServer is the server API for Index service.
+// All implementations must embed UnimplementedIndexServer
+// for forward compatibility
+type IndexServer interface {
+	GetLastAccepted(context.Context, *emptypb.Empty) (*GetLastAcceptedResponse, error)
+	GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*GetContainerByIndexResponse, error)
+	GetContainerByID(context.Context, *GetContainerByIDRequest) (*GetContainerByIDResponse, error)
+	GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error)
+	GetContainersByAddress(context.Context, *GetContainersByAddressRequest) (*GetContainersByAddressResponse, error)
+	GetContainersByType(context.Context, *GetContainersByTypeRequest) (*GetContainersByTypeResponse, error)
+	GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error)
+	IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error)
+	// StreamAccepted sends the containers at [start_index] onward in the order
+	// they were accepted and then sends each container as it is accepted.
+	StreamAccepted(*StreamAcceptedRequest, Index_StreamAcceptedServer) error
+	mustEmbedUnimplementedIndexServer()
+}
+
+// UnimplementedIndexServer must be embedded to have forward compatible implementations.
+type UnimplementedIndexServer struct {
+}
+
+func (UnimplementedIndexServer) GetLastAccepted(context.Context, *emptypb.Empty) (*GetLastAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLastAccepted not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByIndex(context.Context, *GetContainerByIndexRequest) (*GetContainerByIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByIndex not implemented")
+}
+func (UnimplementedIndexServer) GetContainerByID(context.Context, *GetContainerByIDRequest) (*GetContainerByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerByID not implemented")
+}
+func (UnimplementedIndexServer) GetContainerRange(context.Context, *GetContainerRangeRequest) (*GetContainerRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainerRange not implemented")
+}
+func (UnimplementedIndexServer) GetContainersByAddress(context.Context, *GetContainersByAddressRequest) (*GetContainersByAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainersByAddress not implemented")
+}
+func (UnimplementedIndexServer) GetContainersByType(context.Context, *GetContainersByTypeRequest) (*GetContainersByTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContainersByType not implemented")
+}
+func (UnimplementedIndexServer) GetIndex(context.Context, *GetIndexRequest) (*GetIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIndex not implemented")
+}
+func (UnimplementedIndexServer) IsAccepted(context.Context, *IsAcceptedRequest) (*IsAcceptedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsAccepted not implemented")
+}
+func (UnimplementedIndexServer) StreamAccepted(*StreamAcceptedRequest, Index_StreamAcceptedServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAccepted not implemented")
+}
+func (UnimplementedIndexServer) mustEmbedUnimplementedIndexServer() {}
+
+// UnsafeIndexServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndexServer will
+// result in compilation errors.
+type UnsafeIndexServer interface {
+	mustEmbedUnimplementedIndexServer()
+}
+
+func RegisterIndexServer(s grpc.ServiceRegistrar, srv IndexServer) {
+	s.RegisterService(&Index_ServiceDesc, srv)
+}
+
+func _Index_GetLastAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetLastAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetLastAccepted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetLastAccepted(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetContainerByIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByIndex(ctx, req.(*GetContainerByIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetContainerByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerByID(ctx, req.(*GetContainerByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainerRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainerRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainerRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetContainerRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainerRange(ctx, req.(*GetContainerRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainersByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainersByAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainersByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetContainersByAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainersByAddress(ctx, req.(*GetContainersByAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetContainersByType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContainersByTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetContainersByType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetContainersByType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetContainersByType(ctx, req.(*GetContainersByTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_GetIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).GetIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_GetIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).GetIndex(ctx, req.(*GetIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_IsAccepted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsAcceptedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServer).IsAccepted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Index_IsAccepted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServer).IsAccepted(ctx, req.(*IsAcceptedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Index_StreamAccepted_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamAcceptedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndexServer).StreamAccepted(m, &indexStreamAcceptedServer{stream})
+}
+
+type Index_StreamAcceptedServer interface {
+	Send(*StreamAcceptedResponse) error
+	grpc.ServerStream
+}
+
+type indexStreamAcceptedServer struct {
+	grpc.ServerStream
+}
+
+func (x *indexStreamAcceptedServer) Send(m *StreamAcceptedResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Index_ServiceDesc is the grpc.ServiceDesc for Index service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Index_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "index.Index",
+	HandlerType: (*IndexServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLastAccepted",
+			Handler:    _Index_GetLastAccepted_Handler,
+		},
+		{
+			MethodName: "GetContainerByIndex",
+			Handler:    _Index_GetContainerByIndex_Handler,
+		},
+		{
+			MethodName: "GetContainerByID",
+			Handler:    _Index_GetContainerByID_Handler,
+		},
+		{
+			MethodName: "GetContainerRange",
+			Handler:    _Index_GetContainerRange_Handler,
+		},
+		{
+			MethodName: "GetContainersByAddress",
+			Handler:    _Index_GetContainersByAddress_Handler,
+		},
+		{
+			MethodName: "GetContainersByType",
+			Handler:    _Index_GetContainersByType_Handler,
+		},
+		{
+			MethodName: "GetIndex",
+			Handler:    _Index_GetIndex_Handler,
+		},
+		{
+			MethodName: "IsAccepted",
+			Handler:    _Index_IsAccepted_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAccepted",
+			Handler:       _Index_StreamAccepted_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "index/index.proto",
+}
@@ -0,0 +1,352 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: index/index.proto
+
+package indexconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	index "github.com/ava-labs/avalanchego/proto/pb/index"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// IndexName is the fully-qualified name of the Index service.
+	IndexName = "index.Index"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// IndexGetLastAcceptedProcedure is the fully-qualified name of the Index's GetLastAccepted RPC.
+	IndexGetLastAcceptedProcedure = "/index.Index/GetLastAccepted"
+	// IndexGetContainerByIndexProcedure is the fully-qualified name of the Index's GetContainerByIndex
+	// RPC.
+	IndexGetContainerByIndexProcedure = "/index.Index/GetContainerByIndex"
+	// IndexGetContainerByIDProcedure is the fully-qualified name of the Index's GetContainerByID RPC.
+	IndexGetContainerByIDProcedure = "/index.Index/GetContainerByID"
+	// IndexGetContainerRangeProcedure is the fully-qualified name of the Index's GetContainerRange RPC.
+	IndexGetContainerRangeProcedure = "/index.Index/GetContainerRange"
+	// IndexGetContainersByAddressProcedure is the fully-qualified name of the Index's
+	// GetContainersByAddress RPC.
+	IndexGetContainersByAddressProcedure = "/index.Index/GetContainersByAddress"
+	// IndexGetContainersByTypeProcedure is the fully-qualified name of the Index's GetContainersByType
+	// RPC.
+	IndexGetContainersByTypeProcedure = "/index.Index/GetContainersByType"
+	// IndexGetIndexProcedure is the fully-qualified name of the Index's GetIndex RPC.
+	IndexGetIndexProcedure = "/index.Index/GetIndex"
+	// IndexIsAcceptedProcedure is the fully-qualified name of the Index's IsAccepted RPC.
+	IndexIsAcceptedProcedure = "/index.Index/IsAccepted"
+	// IndexStreamAcceptedProcedure is the fully-qualified name of the Index's StreamAccepted RPC.
+	IndexStreamAcceptedProcedure = "/index.Index/StreamAccepted"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	indexServiceDescriptor                      = index.File_index_index_proto.Services().ByName("Index")
+	indexGetLastAcceptedMethodDescriptor        = indexServiceDescriptor.Methods().ByName("GetLastAccepted")
+	indexGetContainerByIndexMethodDescriptor    = indexServiceDescriptor.Methods().ByName("GetContainerByIndex")
+	indexGetContainerByIDMethodDescriptor       = indexServiceDescriptor.Methods().ByName("GetContainerByID")
+	indexGetContainerRangeMethodDescriptor      = indexServiceDescriptor.Methods().ByName("GetContainerRange")
+	indexGetContainersByAddressMethodDescriptor = indexServiceDescriptor.Methods().ByName("GetContainersByAddress")
+	indexGetContainersByTypeMethodDescriptor    = indexServiceDescriptor.Methods().ByName("GetContainersByType")
+	indexGetIndexMethodDescriptor               = indexServiceDescriptor.Methods().ByName("GetIndex")
+	indexIsAcceptedMethodDescriptor             = indexServiceDescriptor.Methods().ByName("IsAccepted")
+	indexStreamAcceptedMethodDescriptor         = indexServiceDescriptor.Methods().ByName("StreamAccepted")
+)
+
+// IndexClient is a client for the index.Index service.
+type IndexClient interface {
+	GetLastAccepted(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[index.GetLastAcceptedResponse], error)
+	GetContainerByIndex(context.Context, *connect.Request[index.GetContainerByIndexRequest]) (*connect.Response[index.GetContainerByIndexResponse], error)
+	GetContainerByID(context.Context, *connect.Request[index.GetContainerByIDRequest]) (*connect.Response[index.GetContainerByIDResponse], error)
+	GetContainerRange(context.Context, *connect.Request[index.GetContainerRangeRequest]) (*connect.Response[index.GetContainerRangeResponse], error)
+	GetContainersByAddress(context.Context, *connect.Request[index.GetContainersByAddressRequest]) (*connect.Response[index.GetContainersByAddressResponse], error)
+	GetContainersByType(context.Context, *connect.Request[index.GetContainersByTypeRequest]) (*connect.Response[index.GetContainersByTypeResponse], error)
+	GetIndex(context.Context, *connect.Request[index.GetIndexRequest]) (*connect.Response[index.GetIndexResponse], error)
+	IsAccepted(context.Context, *connect.Request[index.IsAcceptedRequest]) (*connect.Response[index.IsAcceptedResponse], error)
+	// StreamAccepted sends the containers at [start_index] onward in the order
+	// they were accepted and then sends each container as it is accepted.
+	StreamAccepted(context.Context, *connect.Request[index.StreamAcceptedRequest]) (*connect.ServerStreamForClient[index.StreamAcceptedResponse], error)
+}
+
+// NewIndexClient constructs a client for the index.Index service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewIndexClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) IndexClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &indexClient{
+		getLastAccepted: connect.NewClient[emptypb.Empty, index.GetLastAcceptedResponse](
+			httpClient,
+			baseURL+IndexGetLastAcceptedProcedure,
+			connect.WithSchema(indexGetLastAcceptedMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainerByIndex: connect.NewClient[index.GetContainerByIndexRequest, index.GetContainerByIndexResponse](
+			httpClient,
+			baseURL+IndexGetContainerByIndexProcedure,
+			connect.WithSchema(indexGetContainerByIndexMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainerByID: connect.NewClient[index.GetContainerByIDRequest, index.GetContainerByIDResponse](
+			httpClient,
+			baseURL+IndexGetContainerByIDProcedure,
+			connect.WithSchema(indexGetContainerByIDMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainerRange: connect.NewClient[index.GetContainerRangeRequest, index.GetContainerRangeResponse](
+			httpClient,
+			baseURL+IndexGetContainerRangeProcedure,
+			connect.WithSchema(indexGetContainerRangeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainersByAddress: connect.NewClient[index.GetContainersByAddressRequest, index.GetContainersByAddressResponse](
+			httpClient,
+			baseURL+IndexGetContainersByAddressProcedure,
+			connect.WithSchema(indexGetContainersByAddressMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getContainersByType: connect.NewClient[index.GetContainersByTypeRequest, index.GetContainersByTypeResponse](
+			httpClient,
+			baseURL+IndexGetContainersByTypeProcedure,
+			connect.WithSchema(indexGetContainersByTypeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getIndex: connect.NewClient[index.GetIndexRequest, index.GetIndexResponse](
+			httpClient,
+			baseURL+IndexGetIndexProcedure,
+			connect.WithSchema(indexGetIndexMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		isAccepted: connect.NewClient[index.IsAcceptedRequest, index.IsAcceptedResponse](
+			httpClient,
+			baseURL+IndexIsAcceptedProcedure,
+			connect.WithSchema(indexIsAcceptedMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		streamAccepted: connect.NewClient[index.StreamAcceptedRequest, index.StreamAcceptedResponse](
+			httpClient,
+			baseURL+IndexStreamAcceptedProcedure,
+			connect.WithSchema(indexStreamAcceptedMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// indexClient implements IndexClient.
+type indexClient struct {
+	getLastAccepted        *connect.Client[emptypb.Empty, index.GetLastAcceptedResponse]
+	getContainerByIndex    *connect.Client[index.GetContainerByIndexRequest, index.GetContainerByIndexResponse]
+	getContainerByID       *connect.Client[index.GetContainerByIDRequest, index.GetContainerByIDResponse]
+	getContainerRange      *connect.Client[index.GetContainerRangeRequest, index.GetContainerRangeResponse]
+	getContainersByAddress *connect.Client[index.GetContainersByAddressRequest, index.GetContainersByAddressResponse]
+	getContainersByType    *connect.Client[index.GetContainersByTypeRequest, index.GetContainersByTypeResponse]
+	getIndex               *connect.Client[index.GetIndexRequest, index.GetIndexResponse]
+	isAccepted             *connect.Client[index.IsAcceptedRequest, index.IsAcceptedResponse]
+	streamAccepted         *connect.Client[index.StreamAcceptedRequest, index.StreamAcceptedResponse]
+}
+
+// GetLastAccepted calls index.Index.GetLastAccepted.
+func (c *indexClient) GetLastAccepted(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[index.GetLastAcceptedResponse], error) {
+	return c.getLastAccepted.CallUnary(ctx, req)
+}
+
+// GetContainerByIndex calls index.Index.GetContainerByIndex.
+func (c *indexClient) GetContainerByIndex(ctx context.Context, req *connect.Request[index.GetContainerByIndexRequest]) (*connect.Response[index.GetContainerByIndexResponse], error) {
+	return c.getContainerByIndex.CallUnary(ctx, req)
+}
+
+// GetContainerByID calls index.Index.GetContainerByID.
+func (c *indexClient) GetContainerByID(ctx context.Context, req *connect.Request[index.GetContainerByIDRequest]) (*connect.Response[index.GetContainerByIDResponse], error) {
+	return c.getContainerByID.CallUnary(ctx, req)
+}
+
+// GetContainerRange calls index.Index.GetContainerRange.
+func (c *indexClient) GetContainerRange(ctx context.Context, req *connect.Request[index.GetContainerRangeRequest]) (*connect.Response[index.GetContainerRangeResponse], error) {
+	return c.getContainerRange.CallUnary(ctx, req)
+}
+
+// GetContainersByAddress calls index.Index.GetContainersByAddress.
+func (c *indexClient) GetContainersByAddress(ctx context.Context, req *connect.Request[index.GetContainersByAddressRequest]) (*connect.Response[index.GetContainersByAddressResponse], error) {
+	return c.getContainersByAddress.CallUnary(ctx, req)
+}
+
+// GetContainersByType calls index.Index.GetContainersByType.
+func (c *indexClient) GetContainersByType(ctx context.Context, req *connect.Request[index.GetContainersByTypeRequest]) (*connect.Response[index.GetContainersByTypeResponse], error) {
+	return c.getContainersByType.CallUnary(ctx, req)
+}
+
+// GetIndex calls index.Index.GetIndex.
+func (c *indexClient) GetIndex(ctx context.Context, req *connect.Request[index.GetIndexRequest]) (*connect.Response[index.GetIndexResponse], error) {
+	return c.getIndex.CallUnary(ctx, req)
+}
+
+// IsAccepted calls index.Index.IsAccepted.
+func (c *indexClient) IsAccepted(ctx context.Context, req *connect.Request[index.IsAcceptedRequest]) (*connect.Response[index.IsAcceptedResponse], error) {
+	return c.isAccepted.CallUnary(ctx, req)
+}
+
+// StreamAccepted calls index.Index.StreamAccepted.
+func (c *indexClient) StreamAccepted(ctx context.Context, req *connect.Request[index.StreamAcceptedRequest]) (*connect.ServerStreamForClient[index.StreamAcceptedResponse], error) {
+	return c.streamAccepted.CallServerStream(ctx, req)
+}
+
+// IndexHandler is an implementation of the index.Index service.
+type IndexHandler interface {
+	GetLastAccepted(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[index.GetLastAcceptedResponse], error)
+	GetContainerByIndex(context.Context, *connect.Request[index.GetContainerByIndexRequest]) (*connect.Response[index.GetContainerByIndexResponse], error)
+	GetContainerByID(context.Context, *connect.Request[index.GetContainerByIDRequest]) (*connect.Response[index.GetContainerByIDResponse], error)
+	GetContainerRange(context.Context, *connect.Request[index.GetContainerRangeRequest]) (*connect.Response[index.GetContainerRangeResponse], error)
+	GetContainersByAddress(context.Context, *connect.Request[index.GetContainersByAddressRequest]) (*connect.Response[index.GetContainersByAddressResponse], error)
+	GetContainersByType(context.Context, *connect.Request[index.GetContainersByTypeRequest]) (*connect.Response[index.GetContainersByTypeResponse], error)
+	GetIndex(context.Context, *connect.Request[index.GetIndexRequest]) (*connect.Response[index.GetIndexResponse], error)
+	IsAccepted(context.Context, *connect.Request[index.IsAcceptedRequest]) (*connect.Response[index.IsAcceptedResponse], error)
+	// StreamAccepted sends the containers at [start_index] onward in the order
+	// they were accepted and then sends each container as it is accepted.
+	StreamAccepted(context.Context, *connect.Request[index.StreamAcceptedRequest], *connect.ServerStream[index.StreamAcceptedResponse]) error
+}
+
+// NewIndexHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewIndexHandler(svc IndexHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	indexGetLastAcceptedHandler := connect.NewUnaryHandler(
+		IndexGetLastAcceptedProcedure,
+		svc.GetLastAccepted,
+		connect.WithSchema(indexGetLastAcceptedMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetContainerByIndexHandler := connect.NewUnaryHandler(
+		IndexGetContainerByIndexProcedure,
+		svc.GetContainerByIndex,
+		connect.WithSchema(indexGetContainerByIndexMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetContainerByIDHandler := connect.NewUnaryHandler(
+		IndexGetContainerByIDProcedure,
+		svc.GetContainerByID,
+		connect.WithSchema(indexGetContainerByIDMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetContainerRangeHandler := connect.NewUnaryHandler(
+		IndexGetContainerRangeProcedure,
+		svc.GetContainerRange,
+		connect.WithSchema(indexGetContainerRangeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetContainersByAddressHandler := connect.NewUnaryHandler(
+		IndexGetContainersByAddressProcedure,
+		svc.GetContainersByAddress,
+		connect.WithSchema(indexGetContainersByAddressMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetContainersByTypeHandler := connect.NewUnaryHandler(
+		IndexGetContainersByTypeProcedure,
+		svc.GetContainersByType,
+		connect.WithSchema(indexGetContainersByTypeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexGetIndexHandler := connect.NewUnaryHandler(
+		IndexGetIndexProcedure,
+		svc.GetIndex,
+		connect.WithSchema(indexGetIndexMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexIsAcceptedHandler := connect.NewUnaryHandler(
+		IndexIsAcceptedProcedure,
+		svc.IsAccepted,
+		connect.WithSchema(indexIsAcceptedMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	indexStreamAcceptedHandler := connect.NewServerStreamHandler(
+		IndexStreamAcceptedProcedure,
+		svc.StreamAccepted,
+		connect.WithSchema(indexStreamAcceptedMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/index.Index/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case IndexGetLastAcceptedProcedure:
+			indexGetLastAcceptedHandler.ServeHTTP(w, r)
+		case IndexGetContainerByIndexProcedure:
+			indexGetContainerByIndexHandler.ServeHTTP(w, r)
+		case IndexGetContainerByIDProcedure:
+			indexGetContainerByIDHandler.ServeHTTP(w, r)
+		case IndexGetContainerRangeProcedure:
+			indexGetContainerRangeHandler.ServeHTTP(w, r)
+		case IndexGetContainersByAddressProcedure:
+			indexGetContainersByAddressHandler.ServeHTTP(w, r)
+		case IndexGetContainersByTypeProcedure:
+			indexGetContainersByTypeHandler.ServeHTTP(w, r)
+		case IndexGetIndexProcedure:
+			indexGetIndexHandler.ServeHTTP(w, r)
+		case IndexIsAcceptedProcedure:
+			indexIsAcceptedHandler.ServeHTTP(w, r)
+		case IndexStreamAcceptedProcedure:
+			indexStreamAcceptedHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedIndexHandler returns CodeUnimplemented from all methods.
+type UnimplementedIndexHandler struct{}
+
+func (UnimplementedIndexHandler) GetLastAccepted(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[index.GetLastAcceptedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetLastAccepted is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetContainerByIndex(context.Context, *connect.Request[index.GetContainerByIndexRequest]) (*connect.Response[index.GetContainerByIndexResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetContainerByIndex is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetContainerByID(context.Context, *connect.Request[index.GetContainerByIDRequest]) (*connect.Response[index.GetContainerByIDResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetContainerByID is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetContainerRange(context.Context, *connect.Request[index.GetContainerRangeRequest]) (*connect.Response[index.GetContainerRangeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetContainerRange is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetContainersByAddress(context.Context, *connect.Request[index.GetContainersByAddressRequest]) (*connect.Response[index.GetContainersByAddressResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetContainersByAddress is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetContainersByType(context.Context, *connect.Request[index.GetContainersByTypeRequest]) (*connect.Response[index.GetContainersByTypeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetContainersByType is not implemented"))
+}
+
+func (UnimplementedIndexHandler) GetIndex(context.Context, *connect.Request[index.GetIndexRequest]) (*connect.Response[index.GetIndexResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.GetIndex is not implemented"))
+}
+
+func (UnimplementedIndexHandler) IsAccepted(context.Context, *connect.Request[index.IsAcceptedRequest]) (*connect.Response[index.IsAcceptedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.IsAccepted is not implemented"))
+}
+
+func (UnimplementedIndexHandler) StreamAccepted(context.Context, *connect.Request[index.StreamAcceptedRequest], *connect.ServerStream[index.StreamAcceptedResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("index.Index.StreamAccepted is not implemented"))
+}
@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: info/info.proto
+
+package infoconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	info "github.com/ava-labs/avalanchego/proto/pb/info"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// InfoName is the fully-qualified name of the Info service.
+	InfoName = "info.Info"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// InfoGetNodeVersionProcedure is the fully-qualified name of the Info's GetNodeVersion RPC.
+	InfoGetNodeVersionProcedure = "/info.Info/GetNodeVersion"
+	// InfoGetNodeIDProcedure is the fully-qualified name of the Info's GetNodeID RPC.
+	InfoGetNodeIDProcedure = "/info.Info/GetNodeID"
+	// InfoGetNodeIPProcedure is the fully-qualified name of the Info's GetNodeIP RPC.
+	InfoGetNodeIPProcedure = "/info.Info/GetNodeIP"
+	// InfoGetNetworkIDProcedure is the fully-qualified name of the Info's GetNetworkID RPC.
+	InfoGetNetworkIDProcedure = "/info.Info/GetNetworkID"
+	// InfoGetNetworkNameProcedure is the fully-qualified name of the Info's GetNetworkName RPC.
+	InfoGetNetworkNameProcedure = "/info.Info/GetNetworkName"
+	// InfoGetBlockchainIDProcedure is the fully-qualified name of the Info's GetBlockchainID RPC.
+	InfoGetBlockchainIDProcedure = "/info.Info/GetBlockchainID"
+	// InfoIsBootstrappedProcedure is the fully-qualified name of the Info's IsBootstrapped RPC.
+	InfoIsBootstrappedProcedure = "/info.Info/IsBootstrapped"
+	// InfoUptimeProcedure is the fully-qualified name of the Info's Uptime RPC.
+	InfoUptimeProcedure = "/info.Info/Uptime"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	infoServiceDescriptor               = info.File_info_info_proto.Services().ByName("Info")
+	infoGetNodeVersionMethodDescriptor  = infoServiceDescriptor.Methods().ByName("GetNodeVersion")
+	infoGetNodeIDMethodDescriptor       = infoServiceDescriptor.Methods().ByName("GetNodeID")
+	infoGetNodeIPMethodDescriptor       = infoServiceDescriptor.Methods().ByName("GetNodeIP")
+	infoGetNetworkIDMethodDescriptor    = infoServiceDescriptor.Methods().ByName("GetNetworkID")
+	infoGetNetworkNameMethodDescriptor  = infoServiceDescriptor.Methods().ByName("GetNetworkName")
+	infoGetBlockchainIDMethodDescriptor = infoServiceDescriptor.Methods().ByName("GetBlockchainID")
+	infoIsBootstrappedMethodDescriptor  = infoServiceDescriptor.Methods().ByName("IsBootstrapped")
+	infoUptimeMethodDescriptor          = infoServiceDescriptor.Methods().ByName("Uptime")
+)
+
+// InfoClient is a client for the info.Info service.
+type InfoClient interface {
+	GetNodeVersion(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeVersionResponse], error)
+	GetNodeID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIDResponse], error)
+	GetNodeIP(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIPResponse], error)
+	GetNetworkID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkIDResponse], error)
+	GetNetworkName(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkNameResponse], error)
+	GetBlockchainID(context.Context, *connect.Request[info.GetBlockchainIDRequest]) (*connect.Response[info.GetBlockchainIDResponse], error)
+	IsBootstrapped(context.Context, *connect.Request[info.IsBootstrappedRequest]) (*connect.Response[info.IsBootstrappedResponse], error)
+	Uptime(context.Context, *connect.Request[info.UptimeRequest]) (*connect.Response[info.UptimeResponse], error)
+}
+
+// NewInfoClient constructs a client for the info.Info service. By default, it uses the Connect
+// protocol with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed
+// requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewInfoClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) InfoClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &infoClient{
+		getNodeVersion: connect.NewClient[emptypb.Empty, info.GetNodeVersionResponse](
+			httpClient,
+			baseURL+InfoGetNodeVersionProcedure,
+			connect.WithSchema(infoGetNodeVersionMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getNodeID: connect.NewClient[emptypb.Empty, info.GetNodeIDResponse](
+			httpClient,
+			baseURL+InfoGetNodeIDProcedure,
+			connect.WithSchema(infoGetNodeIDMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getNodeIP: connect.NewClient[emptypb.Empty, info.GetNodeIPResponse](
+			httpClient,
+			baseURL+InfoGetNodeIPProcedure,
+			connect.WithSchema(infoGetNodeIPMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getNetworkID: connect.NewClient[emptypb.Empty, info.GetNetworkIDResponse](
+			httpClient,
+			baseURL+InfoGetNetworkIDProcedure,
+			connect.WithSchema(infoGetNetworkIDMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getNetworkName: connect.NewClient[emptypb.Empty, info.GetNetworkNameResponse](
+			httpClient,
+			baseURL+InfoGetNetworkNameProcedure,
+			connect.WithSchema(infoGetNetworkNameMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getBlockchainID: connect.NewClient[info.GetBlockchainIDRequest, info.GetBlockchainIDResponse](
+			httpClient,
+			baseURL+InfoGetBlockchainIDProcedure,
+			connect.WithSchema(infoGetBlockchainIDMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		isBootstrapped: connect.NewClient[info.IsBootstrappedRequest, info.IsBootstrappedResponse](
+			httpClient,
+			baseURL+InfoIsBootstrappedProcedure,
+			connect.WithSchema(infoIsBootstrappedMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		uptime: connect.NewClient[info.UptimeRequest, info.UptimeResponse](
+			httpClient,
+			baseURL+InfoUptimeProcedure,
+			connect.WithSchema(infoUptimeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// infoClient implements InfoClient.
+type infoClient struct {
+	getNodeVersion  *connect.Client[emptypb.Empty, info.GetNodeVersionResponse]
+	getNodeID       *connect.Client[emptypb.Empty, info.GetNodeIDResponse]
+	getNodeIP       *connect.Client[emptypb.Empty, info.GetNodeIPResponse]
+	getNetworkID    *connect.Client[emptypb.Empty, info.GetNetworkIDResponse]
+	getNetworkName  *connect.Client[emptypb.Empty, info.GetNetworkNameResponse]
+	getBlockchainID *connect.Client[info.GetBlockchainIDRequest, info.GetBlockchainIDResponse]
+	isBootstrapped  *connect.Client[info.IsBootstrappedRequest, info.IsBootstrappedResponse]
+	uptime          *connect.Client[info.UptimeRequest, info.UptimeResponse]
+}
+
+// GetNodeVersion calls info.Info.GetNodeVersion.
+func (c *infoClient) GetNodeVersion(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeVersionResponse], error) {
+	return c.getNodeVersion.CallUnary(ctx, req)
+}
+
+// GetNodeID calls info.Info.GetNodeID.
+func (c *infoClient) GetNodeID(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIDResponse], error) {
+	return c.getNodeID.CallUnary(ctx, req)
+}
+
+// GetNodeIP calls info.Info.GetNodeIP.
+func (c *infoClient) GetNodeIP(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIPResponse], error) {
+	return c.getNodeIP.CallUnary(ctx, req)
+}
+
+// GetNetworkID calls info.Info.GetNetworkID.
+func (c *infoClient) GetNetworkID(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkIDResponse], error) {
+	return c.getNetworkID.CallUnary(ctx, req)
+}
+
+// GetNetworkName calls info.Info.GetNetworkName.
+func (c *infoClient) GetNetworkName(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkNameResponse], error) {
+	return c.getNetworkName.CallUnary(ctx, req)
+}
+
+// GetBlockchainID calls info.Info.GetBlockchainID.
+func (c *infoClient) GetBlockchainID(ctx context.Context, req *connect.Request[info.GetBlockchainIDRequest]) (*connect.Response[info.GetBlockchainIDResponse], error) {
+	return c.getBlockchainID.CallUnary(ctx, req)
+}
+
+// IsBootstrapped calls info.Info.IsBootstrapped.
+func (c *infoClient) IsBootstrapped(ctx context.Context, req *connect.Request[info.IsBootstrappedRequest]) (*connect.Response[info.IsBootstrappedResponse], error) {
+	return c.isBootstrapped.CallUnary(ctx, req)
+}
+
+// Uptime calls info.Info.Uptime.
+func (c *infoClient) Uptime(ctx context.Context, req *connect.Request[info.UptimeRequest]) (*connect.Response[info.UptimeResponse], error) {
+	return c.uptime.CallUnary(ctx, req)
+}
+
+// InfoHandler is an implementation of the info.Info service.
+type InfoHandler interface {
+	GetNodeVersion(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeVersionResponse], error)
+	GetNodeID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIDResponse], error)
+	GetNodeIP(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIPResponse], error)
+	GetNetworkID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkIDResponse], error)
+	GetNetworkName(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkNameResponse], error)
+	GetBlockchainID(context.Context, *connect.Request[info.GetBlockchainIDRequest]) (*connect.Response[info.GetBlockchainIDResponse], error)
+	IsBootstrapped(context.Context, *connect.Request[info.IsBootstrappedRequest]) (*connect.Response[info.IsBootstrappedResponse], error)
+	Uptime(context.Context, *connect.Request[info.UptimeRequest]) (*connect.Response[info.UptimeResponse], error)
+}
+
+// NewInfoHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewInfoHandler(svc InfoHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	infoGetNodeVersionHandler := connect.NewUnaryHandler(
+		InfoGetNodeVersionProcedure,
+		svc.GetNodeVersion,
+		connect.WithSchema(infoGetNodeVersionMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoGetNodeIDHandler := connect.NewUnaryHandler(
+		InfoGetNodeIDProcedure,
+		svc.GetNodeID,
+		connect.WithSchema(infoGetNodeIDMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoGetNodeIPHandler := connect.NewUnaryHandler(
+		InfoGetNodeIPProcedure,
+		svc.GetNodeIP,
+		connect.WithSchema(infoGetNodeIPMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoGetNetworkIDHandler := connect.NewUnaryHandler(
+		InfoGetNetworkIDProcedure,
+		svc.GetNetworkID,
+		connect.WithSchema(infoGetNetworkIDMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoGetNetworkNameHandler := connect.NewUnaryHandler(
+		InfoGetNetworkNameProcedure,
+		svc.GetNetworkName,
+		connect.WithSchema(infoGetNetworkNameMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoGetBlockchainIDHandler := connect.NewUnaryHandler(
+		InfoGetBlockchainIDProcedure,
+		svc.GetBlockchainID,
+		connect.WithSchema(infoGetBlockchainIDMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoIsBootstrappedHandler := connect.NewUnaryHandler(
+		InfoIsBootstrappedProcedure,
+		svc.IsBootstrapped,
+		connect.WithSchema(infoIsBootstrappedMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	infoUptimeHandler := connect.NewUnaryHandler(
+		InfoUptimeProcedure,
+		svc.Uptime,
+		connect.WithSchema(infoUptimeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/info.Info/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case InfoGetNodeVersionProcedure:
+			infoGetNodeVersionHandler.ServeHTTP(w, r)
+		case InfoGetNodeIDProcedure:
+			infoGetNodeIDHandler.ServeHTTP(w, r)
+		case InfoGetNodeIPProcedure:
+			infoGetNodeIPHandler.ServeHTTP(w, r)
+		case InfoGetNetworkIDProcedure:
+			infoGetNetworkIDHandler.ServeHTTP(w, r)
+		case InfoGetNetworkNameProcedure:
+			infoGetNetworkNameHandler.ServeHTTP(w, r)
+		case InfoGetBlockchainIDProcedure:
+			infoGetBlockchainIDHandler.ServeHTTP(w, r)
+		case InfoIsBootstrappedProcedure:
+			infoIsBootstrappedHandler.ServeHTTP(w, r)
+		case InfoUptimeProcedure:
+			infoUptimeHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedInfoHandler returns CodeUnimplemented from all methods.
+type UnimplementedInfoHandler struct{}
+
+func (UnimplementedInfoHandler) GetNodeVersion(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeVersionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetNodeVersion is not implemented"))
+}
+
+func (UnimplementedInfoHandler) GetNodeID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIDResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetNodeID is not implemented"))
+}
+
+func (UnimplementedInfoHandler) GetNodeIP(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNodeIPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetNodeIP is not implemented"))
+}
+
+func (UnimplementedInfoHandler) GetNetworkID(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkIDResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetNetworkID is not implemented"))
+}
+
+func (UnimplementedInfoHandler) GetNetworkName(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[info.GetNetworkNameResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetNetworkName is not implemented"))
+}
+
+func (UnimplementedInfoHandler) GetBlockchainID(context.Context, *connect.Request[info.GetBlockchainIDRequest]) (*connect.Response[info.GetBlockchainIDResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.GetBlockchainID is not implemented"))
+}
+
+func (UnimplementedInfoHandler) IsBootstrapped(context.Context, *connect.Request[info.IsBootstrappedRequest]) (*connect.Response[info.IsBootstrappedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.IsBootstrapped is not implemented"))
+}
+
+func (UnimplementedInfoHandler) Uptime(context.Context, *connect.Request[info.UptimeRequest]) (*connect.Response[info.UptimeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("info.Info.Uptime is not implemented"))
+}
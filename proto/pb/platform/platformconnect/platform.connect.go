@@ -0,0 +1,289 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: platform/platform.proto
+
+package platformconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	platform "github.com/ava-labs/avalanchego/proto/pb/platform"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// PlatformName is the fully-qualified name of the Platform service.
+	PlatformName = "platform.Platform"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PlatformGetHeightProcedure is the fully-qualified name of the Platform's GetHeight RPC.
+	PlatformGetHeightProcedure = "/platform.Platform/GetHeight"
+	// PlatformGetTimestampProcedure is the fully-qualified name of the Platform's GetTimestamp RPC.
+	PlatformGetTimestampProcedure = "/platform.Platform/GetTimestamp"
+	// PlatformGetCurrentSupplyProcedure is the fully-qualified name of the Platform's GetCurrentSupply
+	// RPC.
+	PlatformGetCurrentSupplyProcedure = "/platform.Platform/GetCurrentSupply"
+	// PlatformGetBlockProcedure is the fully-qualified name of the Platform's GetBlock RPC.
+	PlatformGetBlockProcedure = "/platform.Platform/GetBlock"
+	// PlatformGetBlockByHeightProcedure is the fully-qualified name of the Platform's GetBlockByHeight
+	// RPC.
+	PlatformGetBlockByHeightProcedure = "/platform.Platform/GetBlockByHeight"
+	// PlatformGetTxProcedure is the fully-qualified name of the Platform's GetTx RPC.
+	PlatformGetTxProcedure = "/platform.Platform/GetTx"
+	// PlatformGetTxStatusProcedure is the fully-qualified name of the Platform's GetTxStatus RPC.
+	PlatformGetTxStatusProcedure = "/platform.Platform/GetTxStatus"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	platformServiceDescriptor                = platform.File_platform_platform_proto.Services().ByName("Platform")
+	platformGetHeightMethodDescriptor        = platformServiceDescriptor.Methods().ByName("GetHeight")
+	platformGetTimestampMethodDescriptor     = platformServiceDescriptor.Methods().ByName("GetTimestamp")
+	platformGetCurrentSupplyMethodDescriptor = platformServiceDescriptor.Methods().ByName("GetCurrentSupply")
+	platformGetBlockMethodDescriptor         = platformServiceDescriptor.Methods().ByName("GetBlock")
+	platformGetBlockByHeightMethodDescriptor = platformServiceDescriptor.Methods().ByName("GetBlockByHeight")
+	platformGetTxMethodDescriptor            = platformServiceDescriptor.Methods().ByName("GetTx")
+	platformGetTxStatusMethodDescriptor      = platformServiceDescriptor.Methods().ByName("GetTxStatus")
+)
+
+// PlatformClient is a client for the platform.Platform service.
+type PlatformClient interface {
+	GetHeight(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetHeightResponse], error)
+	GetTimestamp(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetTimestampResponse], error)
+	GetCurrentSupply(context.Context, *connect.Request[platform.GetCurrentSupplyRequest]) (*connect.Response[platform.GetCurrentSupplyResponse], error)
+	GetBlock(context.Context, *connect.Request[platform.GetBlockRequest]) (*connect.Response[platform.GetBlockResponse], error)
+	GetBlockByHeight(context.Context, *connect.Request[platform.GetBlockByHeightRequest]) (*connect.Response[platform.GetBlockByHeightResponse], error)
+	GetTx(context.Context, *connect.Request[platform.GetTxRequest]) (*connect.Response[platform.GetTxResponse], error)
+	GetTxStatus(context.Context, *connect.Request[platform.GetTxStatusRequest]) (*connect.Response[platform.GetTxStatusResponse], error)
+}
+
+// NewPlatformClient constructs a client for the platform.Platform service. By default, it uses the
+// Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPlatformClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PlatformClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &platformClient{
+		getHeight: connect.NewClient[emptypb.Empty, platform.GetHeightResponse](
+			httpClient,
+			baseURL+PlatformGetHeightProcedure,
+			connect.WithSchema(platformGetHeightMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getTimestamp: connect.NewClient[emptypb.Empty, platform.GetTimestampResponse](
+			httpClient,
+			baseURL+PlatformGetTimestampProcedure,
+			connect.WithSchema(platformGetTimestampMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getCurrentSupply: connect.NewClient[platform.GetCurrentSupplyRequest, platform.GetCurrentSupplyResponse](
+			httpClient,
+			baseURL+PlatformGetCurrentSupplyProcedure,
+			connect.WithSchema(platformGetCurrentSupplyMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getBlock: connect.NewClient[platform.GetBlockRequest, platform.GetBlockResponse](
+			httpClient,
+			baseURL+PlatformGetBlockProcedure,
+			connect.WithSchema(platformGetBlockMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getBlockByHeight: connect.NewClient[platform.GetBlockByHeightRequest, platform.GetBlockByHeightResponse](
+			httpClient,
+			baseURL+PlatformGetBlockByHeightProcedure,
+			connect.WithSchema(platformGetBlockByHeightMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getTx: connect.NewClient[platform.GetTxRequest, platform.GetTxResponse](
+			httpClient,
+			baseURL+PlatformGetTxProcedure,
+			connect.WithSchema(platformGetTxMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getTxStatus: connect.NewClient[platform.GetTxStatusRequest, platform.GetTxStatusResponse](
+			httpClient,
+			baseURL+PlatformGetTxStatusProcedure,
+			connect.WithSchema(platformGetTxStatusMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// platformClient implements PlatformClient.
+type platformClient struct {
+	getHeight        *connect.Client[emptypb.Empty, platform.GetHeightResponse]
+	getTimestamp     *connect.Client[emptypb.Empty, platform.GetTimestampResponse]
+	getCurrentSupply *connect.Client[platform.GetCurrentSupplyRequest, platform.GetCurrentSupplyResponse]
+	getBlock         *connect.Client[platform.GetBlockRequest, platform.GetBlockResponse]
+	getBlockByHeight *connect.Client[platform.GetBlockByHeightRequest, platform.GetBlockByHeightResponse]
+	getTx            *connect.Client[platform.GetTxRequest, platform.GetTxResponse]
+	getTxStatus      *connect.Client[platform.GetTxStatusRequest, platform.GetTxStatusResponse]
+}
+
+// GetHeight calls platform.Platform.GetHeight.
+func (c *platformClient) GetHeight(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetHeightResponse], error) {
+	return c.getHeight.CallUnary(ctx, req)
+}
+
+// GetTimestamp calls platform.Platform.GetTimestamp.
+func (c *platformClient) GetTimestamp(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetTimestampResponse], error) {
+	return c.getTimestamp.CallUnary(ctx, req)
+}
+
+// GetCurrentSupply calls platform.Platform.GetCurrentSupply.
+func (c *platformClient) GetCurrentSupply(ctx context.Context, req *connect.Request[platform.GetCurrentSupplyRequest]) (*connect.Response[platform.GetCurrentSupplyResponse], error) {
+	return c.getCurrentSupply.CallUnary(ctx, req)
+}
+
+// GetBlock calls platform.Platform.GetBlock.
+func (c *platformClient) GetBlock(ctx context.Context, req *connect.Request[platform.GetBlockRequest]) (*connect.Response[platform.GetBlockResponse], error) {
+	return c.getBlock.CallUnary(ctx, req)
+}
+
+// GetBlockByHeight calls platform.Platform.GetBlockByHeight.
+func (c *platformClient) GetBlockByHeight(ctx context.Context, req *connect.Request[platform.GetBlockByHeightRequest]) (*connect.Response[platform.GetBlockByHeightResponse], error) {
+	return c.getBlockByHeight.CallUnary(ctx, req)
+}
+
+// GetTx calls platform.Platform.GetTx.
+func (c *platformClient) GetTx(ctx context.Context, req *connect.Request[platform.GetTxRequest]) (*connect.Response[platform.GetTxResponse], error) {
+	return c.getTx.CallUnary(ctx, req)
+}
+
+// GetTxStatus calls platform.Platform.GetTxStatus.
+func (c *platformClient) GetTxStatus(ctx context.Context, req *connect.Request[platform.GetTxStatusRequest]) (*connect.Response[platform.GetTxStatusResponse], error) {
+	return c.getTxStatus.CallUnary(ctx, req)
+}
+
+// PlatformHandler is an implementation of the platform.Platform service.
+type PlatformHandler interface {
+	GetHeight(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetHeightResponse], error)
+	GetTimestamp(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetTimestampResponse], error)
+	GetCurrentSupply(context.Context, *connect.Request[platform.GetCurrentSupplyRequest]) (*connect.Response[platform.GetCurrentSupplyResponse], error)
+	GetBlock(context.Context, *connect.Request[platform.GetBlockRequest]) (*connect.Response[platform.GetBlockResponse], error)
+	GetBlockByHeight(context.Context, *connect.Request[platform.GetBlockByHeightRequest]) (*connect.Response[platform.GetBlockByHeightResponse], error)
+	GetTx(context.Context, *connect.Request[platform.GetTxRequest]) (*connect.Response[platform.GetTxResponse], error)
+	GetTxStatus(context.Context, *connect.Request[platform.GetTxStatusRequest]) (*connect.Response[platform.GetTxStatusResponse], error)
+}
+
+// NewPlatformHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPlatformHandler(svc PlatformHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	platformGetHeightHandler := connect.NewUnaryHandler(
+		PlatformGetHeightProcedure,
+		svc.GetHeight,
+		connect.WithSchema(platformGetHeightMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetTimestampHandler := connect.NewUnaryHandler(
+		PlatformGetTimestampProcedure,
+		svc.GetTimestamp,
+		connect.WithSchema(platformGetTimestampMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetCurrentSupplyHandler := connect.NewUnaryHandler(
+		PlatformGetCurrentSupplyProcedure,
+		svc.GetCurrentSupply,
+		connect.WithSchema(platformGetCurrentSupplyMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetBlockHandler := connect.NewUnaryHandler(
+		PlatformGetBlockProcedure,
+		svc.GetBlock,
+		connect.WithSchema(platformGetBlockMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetBlockByHeightHandler := connect.NewUnaryHandler(
+		PlatformGetBlockByHeightProcedure,
+		svc.GetBlockByHeight,
+		connect.WithSchema(platformGetBlockByHeightMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetTxHandler := connect.NewUnaryHandler(
+		PlatformGetTxProcedure,
+		svc.GetTx,
+		connect.WithSchema(platformGetTxMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	platformGetTxStatusHandler := connect.NewUnaryHandler(
+		PlatformGetTxStatusProcedure,
+		svc.GetTxStatus,
+		connect.WithSchema(platformGetTxStatusMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/platform.Platform/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PlatformGetHeightProcedure:
+			platformGetHeightHandler.ServeHTTP(w, r)
+		case PlatformGetTimestampProcedure:
+			platformGetTimestampHandler.ServeHTTP(w, r)
+		case PlatformGetCurrentSupplyProcedure:
+			platformGetCurrentSupplyHandler.ServeHTTP(w, r)
+		case PlatformGetBlockProcedure:
+			platformGetBlockHandler.ServeHTTP(w, r)
+		case PlatformGetBlockByHeightProcedure:
+			platformGetBlockByHeightHandler.ServeHTTP(w, r)
+		case PlatformGetTxProcedure:
+			platformGetTxHandler.ServeHTTP(w, r)
+		case PlatformGetTxStatusProcedure:
+			platformGetTxStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPlatformHandler returns CodeUnimplemented from all methods.
+type UnimplementedPlatformHandler struct{}
+
+func (UnimplementedPlatformHandler) GetHeight(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetHeightResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetHeight is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetTimestamp(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[platform.GetTimestampResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetTimestamp is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetCurrentSupply(context.Context, *connect.Request[platform.GetCurrentSupplyRequest]) (*connect.Response[platform.GetCurrentSupplyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetCurrentSupply is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetBlock(context.Context, *connect.Request[platform.GetBlockRequest]) (*connect.Response[platform.GetBlockResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetBlock is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetBlockByHeight(context.Context, *connect.Request[platform.GetBlockByHeightRequest]) (*connect.Response[platform.GetBlockByHeightResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetBlockByHeight is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetTx(context.Context, *connect.Request[platform.GetTxRequest]) (*connect.Response[platform.GetTxResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetTx is not implemented"))
+}
+
+func (UnimplementedPlatformHandler) GetTxStatus(context.Context, *connect.Request[platform.GetTxStatusRequest]) (*connect.Response[platform.GetTxStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("platform.Platform.GetTxStatus is not implemented"))
+}
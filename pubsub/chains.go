@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	acceptorNamePrefix = "pubsub-"
+
+	// Types of the events published by the ChainPublisher.
+	BlockType  = "block"
+	VertexType = "vertex"
+	TxType     = "tx"
+)
+
+var (
+	_ TypedFilterer = (*acceptedFilterer)(nil)
+	_ snow.Acceptor = (*chainAcceptor)(nil)
+)
+
+// Accepted is the message published when a container is accepted.
+type Accepted struct {
+	ChainID  ids.ID `json:"chainID"`
+	SubnetID ids.ID `json:"subnetID"`
+	Type     string `json:"type"`
+	ID       ids.ID `json:"id"`
+}
+
+// acceptedFilterer passes connections that added the chain or the subnet of
+// the accepted container.
+type acceptedFilterer struct {
+	accepted Accepted
+}
+
+func (f *acceptedFilterer) Filter(filters []Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		resp[i] = c.Check(f.accepted.ChainID[:]) || c.Check(f.accepted.SubnetID[:])
+	}
+	return resp, f.accepted
+}
+
+func (f *acceptedFilterer) Type() string {
+	return f.accepted.Type
+}
+
+type chainAcceptor struct {
+	server *Server
+	typ    string
+}
+
+func (a *chainAcceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, _ []byte) error {
+	a.server.Publish(&acceptedFilterer{
+		accepted: Accepted{
+			ChainID:  ctx.ChainID,
+			SubnetID: ctx.SubnetID,
+			Type:     a.typ,
+			ID:       containerID,
+		},
+	})
+	return nil
+}
+
+// ChainPublisher publishes the blocks, vertices, and transactions accepted by
+// every chain to a Server.
+//
+// Connections can subscribe to chains and subnets by adding their IDs with
+// the AddSubnets command.
+//
+// Containers accepted by different chains aren't ordered relative to each
+// other, so there is no cursor to resume from. The Server should be created
+// without a Replayer so that the Resume command is rejected.
+type ChainPublisher struct {
+	log                 logging.Logger
+	server              *Server
+	blockAcceptorGroup  snow.AcceptorGroup
+	txAcceptorGroup     snow.AcceptorGroup
+	vertexAcceptorGroup snow.AcceptorGroup
+}
+
+func NewChainPublisher(
+	log logging.Logger,
+	server *Server,
+	blockAcceptorGroup snow.AcceptorGroup,
+	txAcceptorGroup snow.AcceptorGroup,
+	vertexAcceptorGroup snow.AcceptorGroup,
+) *ChainPublisher {
+	return &ChainPublisher{
+		log:                 log,
+		server:              server,
+		blockAcceptorGroup:  blockAcceptorGroup,
+		txAcceptorGroup:     txAcceptorGroup,
+		vertexAcceptorGroup: vertexAcceptorGroup,
+	}
+}
+
+// RegisterChain publishes the containers accepted by the chain.
+func (p *ChainPublisher) RegisterChain(chainName string, ctx *snow.ConsensusContext, _ common.VM) {
+	acceptorName := fmt.Sprintf("%s%s", acceptorNamePrefix, ctx.ChainID)
+	acceptors := []struct {
+		group snow.AcceptorGroup
+		typ   string
+	}{
+		{
+			group: p.blockAcceptorGroup,
+			typ:   BlockType,
+		},
+		{
+			group: p.txAcceptorGroup,
+			typ:   TxType,
+		},
+		{
+			group: p.vertexAcceptorGroup,
+			typ:   VertexType,
+		},
+	}
+	for _, acceptor := range acceptors {
+		// If the chain was restarted, its acceptors are already registered.
+		_ = acceptor.group.DeregisterAcceptor(ctx.ChainID, acceptorName)
+
+		err := acceptor.group.RegisterAcceptor(
+			ctx.ChainID,
+			acceptorName,
+			&chainAcceptor{
+				server: p.server,
+				typ:    acceptor.typ,
+			},
+			false,
+		)
+		if err != nil {
+			p.log.Error("failed to register pubsub acceptor",
+				zap.String("chainName", chainName),
+				zap.String("type", acceptor.typ),
+				zap.Error(err),
+			)
+		}
+	}
+}
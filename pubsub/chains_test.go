@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestAcceptedFilterer(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	subnetID := ids.GenerateTestID()
+	accepted := Accepted{
+		ChainID:  chainID,
+		SubnetID: subnetID,
+		Type:     BlockType,
+		ID:       ids.GenerateTestID(),
+	}
+
+	chainFilter := NewFilterParam()
+	require.NoError(chainFilter.Add(chainID[:]))
+
+	subnetFilter := NewFilterParam()
+	require.NoError(subnetFilter.Add(subnetID[:]))
+
+	otherID := ids.GenerateTestID()
+	otherFilter := NewFilterParam()
+	require.NoError(otherFilter.Add(otherID[:]))
+
+	f := &acceptedFilterer{accepted: accepted}
+	toNotify, msg := f.Filter([]Filter{chainFilter, subnetFilter, otherFilter})
+	require.Equal([]bool{true, true, false}, toNotify)
+	require.Equal(accepted, msg)
+	require.Equal(BlockType, f.Type())
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -19,8 +20,11 @@ import (
 var (
 	ErrFilterNotInitialized        = errors.New("filter not initialized")
 	ErrAddressLimit                = errors.New("address limit exceeded")
+	ErrTypeLimit                   = errors.New("type limit exceeded")
 	ErrInvalidFilterParam          = errors.New("invalid bloom filter params")
 	ErrInvalidCommand              = errors.New("invalid command")
+	ErrReplayNotSupported          = errors.New("replay not supported")
+	ErrReplayInProgress            = errors.New("replay already in progress")
 	_                       Filter = (*connection)(nil)
 )
 
@@ -41,6 +45,23 @@ type connection struct {
 	fp *FilterParam
 
 	active uint32
+	// Closed when the connection is deactivated.
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	replayLock sync.Mutex
+	// True while the events published before the connection resumed are
+	// being replayed.
+	replaying bool
+	// Events published while replaying, which are sent once the replay
+	// finishes.
+	backlog []backlogMsg
+	// True if events were dropped from [backlog] because it was full.
+	backlogDropped bool
+	// Cursor of the first event with a cursor that was dropped from
+	// [backlog], if [hasDroppedCursor] is true.
+	droppedCursor    uint64
+	hasDroppedCursor bool
 }
 
 func (c *connection) Check(addr []byte) bool {
@@ -54,6 +75,9 @@ func (c *connection) isActive() bool {
 
 func (c *connection) deactivate() {
 	atomic.StoreUint32(&c.active, 0)
+	c.closeOnce.Do(func() {
+		close(c.closed)
+	})
 }
 
 func (c *connection) Send(msg interface{}) bool {
@@ -174,6 +198,12 @@ func (c *connection) readMessage() error {
 		c.handleNewSet(cmd.NewSet)
 	case cmd.AddAddresses != nil:
 		err = c.handleAddAddresses(cmd.AddAddresses)
+	case cmd.AddSubnets != nil:
+		err = c.handleAddSubnets(cmd.AddSubnets)
+	case cmd.SetTypes != nil:
+		err = c.handleSetTypes(cmd.SetTypes)
+	case cmd.Resume != nil:
+		err = c.handleResume(cmd.Resume)
 	default:
 		err = ErrInvalidCommand
 	}
@@ -212,3 +242,43 @@ func (c *connection) handleAddAddresses(cmd *AddAddresses) error {
 	c.s.subscribedConnections.Add(c)
 	return nil
 }
+
+func (c *connection) handleAddSubnets(cmd *AddSubnets) error {
+	subnetIDs := make([][]byte, len(cmd.Subnets))
+	for i, subnetID := range cmd.Subnets {
+		subnetIDs[i] = subnetID[:]
+	}
+	if err := c.fp.Add(subnetIDs...); err != nil {
+		return fmt.Errorf("subnet append failed %w", err)
+	}
+	c.s.subscribedConnections.Add(c)
+	return nil
+}
+
+func (c *connection) handleSetTypes(cmd *SetTypes) error {
+	if err := c.fp.SetTypes(cmd.Types...); err != nil {
+		return fmt.Errorf("setting types failed %w", err)
+	}
+	c.s.subscribedConnections.Add(c)
+	return nil
+}
+
+func (c *connection) handleResume(cmd *Resume) error {
+	if c.s.replayer == nil {
+		return ErrReplayNotSupported
+	}
+
+	c.replayLock.Lock()
+	if c.replaying {
+		c.replayLock.Unlock()
+		return ErrReplayInProgress
+	}
+	c.replaying = true
+	c.replayLock.Unlock()
+
+	// The connection must be replaying before it's subscribed so that no
+	// events are sent out of order.
+	c.s.subscribedConnections.Add(c)
+	go c.replay(uint64(cmd.Cursor))
+	return nil
+}
@@ -14,6 +14,8 @@ type FilterParam struct {
 	lock   sync.RWMutex
 	set    set.Set[string]
 	filter bloom.Filter
+	// If non-empty, only events of these types pass the filter.
+	types set.Set[string]
 }
 
 func NewFilterParam() *FilterParam {
@@ -85,3 +87,33 @@ func (f *FilterParam) Len() int {
 
 	return len(f.set)
 }
+
+// SetTypes replaces the event types that pass the filter. If [types] is
+// empty, events of every type pass the filter.
+func (f *FilterParam) SetTypes(types ...string) error {
+	if len(types) > MaxTypes {
+		return ErrTypeLimit
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.types = set.Of(types...)
+	return nil
+}
+
+// CheckType returns true if events of type [typ] pass the filter.
+func (f *FilterParam) CheckType(typ string) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.types.Len() == 0 || f.types.Contains(typ)
+}
+
+// HasAddresses returns true if any addresses have been added to the filter.
+func (f *FilterParam) HasAddresses() bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.filter != nil || len(f.set) != 0
+}
@@ -75,3 +75,25 @@ func TestNewBloom(t *testing.T) {
 	cm := &NewBloom{}
 	require.False(t, cm.IsParamsValid())
 }
+
+func TestFilterParamTypes(t *testing.T) {
+	require := require.New(t)
+
+	fp := NewFilterParam()
+	require.True(fp.CheckType(BlockType))
+	require.True(fp.CheckType(TxType))
+	require.False(fp.HasAddresses())
+
+	require.NoError(fp.SetTypes(BlockType))
+	require.True(fp.CheckType(BlockType))
+	require.False(fp.CheckType(TxType))
+
+	require.NoError(fp.SetTypes())
+	require.True(fp.CheckType(TxType))
+
+	types := make([]string, MaxTypes+1)
+	require.ErrorIs(fp.SetTypes(types...), ErrTypeLimit)
+
+	require.NoError(fp.Add([]byte("abc")))
+	require.True(fp.HasAddresses())
+}
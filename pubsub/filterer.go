@@ -6,3 +6,12 @@ package pubsub
 type Filterer interface {
 	Filter(connections []Filter) ([]bool, interface{})
 }
+
+// TypedFilterer is a Filterer of an event that connections can subscribe to
+// by its type.
+type TypedFilterer interface {
+	Filterer
+
+	// Type returns the type of the event.
+	Type() string
+}
@@ -5,6 +5,7 @@ package pubsub
 
 import (
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 )
@@ -34,6 +35,31 @@ type AddAddresses struct {
 	addressIds [][]byte
 }
 
+// AddSubnets command to add subnets. Events that affect any of the subnets
+// are sent to the connection.
+//
+// Deprecated: The pubsub server is deprecated.
+type AddSubnets struct {
+	Subnets []ids.ID `json:"subnets"`
+}
+
+// SetTypes command to only receive events of the given types. If no addresses
+// or subnets have been added, all events of the given types are received.
+//
+// Deprecated: The pubsub server is deprecated.
+type SetTypes struct {
+	Types []string `json:"types"`
+}
+
+// Resume command to replay the events that were published at or after a
+// cursor before receiving new events. The filters of the connection should be
+// set before the command is sent.
+//
+// Deprecated: The pubsub server is deprecated.
+type Resume struct {
+	Cursor json.Uint64 `json:"cursor"`
+}
+
 // Command execution command
 //
 // Deprecated: The pubsub server is deprecated.
@@ -41,6 +67,9 @@ type Command struct {
 	NewBloom     *NewBloom     `json:"newBloom,omitempty"`
 	NewSet       *NewSet       `json:"newSet,omitempty"`
 	AddAddresses *AddAddresses `json:"addAddresses,omitempty"`
+	AddSubnets   *AddSubnets   `json:"addSubnets,omitempty"`
+	SetTypes     *SetTypes     `json:"setTypes,omitempty"`
+	Resume       *Resume       `json:"resume,omitempty"`
 }
 
 func (c *Command) String() string {
@@ -51,6 +80,12 @@ func (c *Command) String() string {
 		return "newSet"
 	case c.AddAddresses != nil:
 		return "addAddresses"
+	case c.AddSubnets != nil:
+		return "addSubnets"
+	case c.SetTypes != nil:
+		return "setTypes"
+	case c.Resume != nil:
+		return "resume"
 	default:
 		return "unknown"
 	}
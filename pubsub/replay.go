@@ -0,0 +1,232 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+// CursorFilterer is a Filterer of an event that can be replayed.
+//
+// Cursors are non-decreasing in the order that events are published. Events
+// may share a cursor, such as the transactions of a block sharing the block's
+// height.
+type CursorFilterer interface {
+	Filterer
+
+	// Cursor returns the cursor of the event.
+	Cursor() uint64
+}
+
+// Replayer provides the events that were published before a connection
+// resumed.
+type Replayer interface {
+	// Replay returns the events with cursors in the range [start, next), in
+	// the order that they were published, where next <= start+limit.
+	//
+	// If next == start, the events at or after [start] haven't been
+	// published yet.
+	Replay(start uint64, limit uint64) (filterers []Filterer, next uint64, err error)
+}
+
+// replayedMsg is sent once every event that was published before a
+// connection resumed has been replayed.
+type replayedMsg struct {
+	// Cursor of the first event that wasn't replayed.
+	Replayed json.Uint64 `json:"replayed"`
+}
+
+// backlogMsg is an event that was published while a connection was replaying.
+type backlogMsg struct {
+	hasCursor bool
+	cursor    uint64
+	msg       interface{}
+}
+
+// shouldSend returns true if an event with a filterer of [parser], which
+// [passed] the connection's filter, should be sent to the connection.
+func (c *connection) shouldSend(parser Filterer, passed bool) bool {
+	typed, ok := parser.(TypedFilterer)
+	if !ok {
+		return passed
+	}
+	if !c.fp.CheckType(typed.Type()) {
+		return false
+	}
+	// Connections that only filter by type receive every event of their
+	// types.
+	return passed || !c.fp.HasAddresses()
+}
+
+// publish sends [msg], which was published with [parser], to the connection.
+// If the connection is replaying, [msg] is sent once the replay finishes.
+func (c *connection) publish(parser Filterer, msg interface{}) {
+	c.replayLock.Lock()
+	if c.replaying {
+		defer c.replayLock.Unlock()
+
+		if len(c.backlog) >= maxPendingMessages {
+			// The dropped events will be replayed instead.
+			if !c.hasDroppedCursor {
+				c.droppedCursor, c.hasDroppedCursor = firstCursor(c.backlog)
+			}
+			c.backlog = nil
+			c.backlogDropped = true
+		}
+		pending := backlogMsg{msg: msg}
+		if cursorFilterer, ok := parser.(CursorFilterer); ok {
+			pending.hasCursor = true
+			pending.cursor = cursorFilterer.Cursor()
+		}
+		c.backlog = append(c.backlog, pending)
+		return
+	}
+	c.replayLock.Unlock()
+
+	if !c.Send(msg) {
+		c.s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
+	}
+}
+
+// firstCursor returns the cursor of the first event in [backlog] that has a
+// cursor. Returns false if none of the events have a cursor.
+func firstCursor(backlog []backlogMsg) (uint64, bool) {
+	for _, pending := range backlog {
+		if pending.hasCursor {
+			return pending.cursor, true
+		}
+	}
+	return 0, false
+}
+
+// sendBlocking sends [msg] to the connection, waiting for space in the send
+// buffer if needed. Returns false if the connection was closed.
+func (c *connection) sendBlocking(msg interface{}) bool {
+	if !c.isActive() {
+		return false
+	}
+	select {
+	case c.send <- msg:
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+// replay sends the events published at or after [cursor] to the connection
+// and then switches the connection to receiving new events.
+func (c *connection) replay(cursor uint64) {
+	for c.isActive() {
+		filterers, next, err := c.s.replayer.Replay(cursor, maxReplayBatchSize)
+		if err != nil {
+			c.s.log.Debug("failed to replay events",
+				zap.Uint64("cursor", cursor),
+				zap.Error(err),
+			)
+			c.Send(&errorMsg{
+				Error: err.Error(),
+			})
+			c.finishReplay(cursor, true)
+			return
+		}
+
+		for _, parser := range filterers {
+			toNotify, msg := parser.Filter([]Filter{c})
+			if !c.shouldSend(parser, toNotify[0]) {
+				continue
+			}
+			if !c.sendBlocking(msg) {
+				return
+			}
+		}
+
+		if next == cursor {
+			resume, done := c.finishReplay(next, false)
+			if done {
+				return
+			}
+			next = resume
+		}
+		cursor = next
+	}
+}
+
+// finishReplay switches the connection to receiving new events and sends the
+// events published while replaying with cursors at or after [next].
+//
+// If [force] is false and events were dropped while replaying, the connection
+// keeps replaying and false is returned along with the cursor to keep
+// replaying from. In that case, [replayedMsg] is sent again once the replay
+// finishes.
+func (c *connection) finishReplay(next uint64, force bool) (uint64, bool) {
+	c.replayLock.Lock()
+	if c.backlogDropped && !force {
+		c.backlogDropped = false
+		c.hasDroppedCursor = false
+		c.replayLock.Unlock()
+		return next, false
+	}
+	backlog := c.backlog
+	c.backlog = nil
+	c.replayLock.Unlock()
+
+	c.Send(&replayedMsg{
+		Replayed: json.Uint64(next),
+	})
+
+	// The lock isn't held while sending. Events published in the meantime
+	// are still added to the backlog, so they are sent in order by a later
+	// iteration.
+	for {
+		for _, pending := range backlog {
+			if pending.hasCursor && pending.cursor < next {
+				continue
+			}
+			if !c.Send(pending.msg) {
+				c.s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
+			}
+		}
+
+		var (
+			resume  uint64
+			dropped bool
+		)
+		backlog, resume, dropped = c.takeBacklog(force)
+		if dropped {
+			return resume, false
+		}
+		if len(backlog) == 0 {
+			return 0, true
+		}
+	}
+}
+
+// takeBacklog returns the events that were added to the backlog since it was
+// last taken. If the backlog is empty, the connection stops replaying.
+//
+// If [force] is false and events with cursors were dropped from the backlog,
+// true is returned along with the cursor of the first dropped event, which the
+// connection must keep replaying from. Events that share that cursor and were
+// already sent are sent again.
+func (c *connection) takeBacklog(force bool) ([]backlogMsg, uint64, bool) {
+	c.replayLock.Lock()
+	defer c.replayLock.Unlock()
+
+	if c.backlogDropped && c.hasDroppedCursor && !force {
+		c.backlogDropped = false
+		c.hasDroppedCursor = false
+		return nil, c.droppedCursor, true
+	}
+
+	backlog := c.backlog
+	c.backlog = nil
+	if len(backlog) == 0 {
+		c.replaying = false
+		c.backlogDropped = false
+		c.hasDroppedCursor = false
+	}
+	return backlog, 0, false
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var (
+	_ CursorFilterer = (*testFilterer)(nil)
+	_ Replayer       = (*testReplayer)(nil)
+
+	errTestReplay = errors.New("non-nil error")
+)
+
+type testFilterer struct {
+	cursor uint64
+	addr   []byte
+}
+
+func (f *testFilterer) Filter(filters []Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		resp[i] = c.Check(f.addr)
+	}
+	return resp, f.cursor
+}
+
+func (f *testFilterer) Cursor() uint64 {
+	return f.cursor
+}
+
+// testReplayer replays [events], where the event at index i has cursor i.
+type testReplayer struct {
+	events []Filterer
+	err    error
+}
+
+func (r *testReplayer) Replay(start uint64, limit uint64) ([]Filterer, uint64, error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	end := max(min(uint64(len(r.events)), start+limit), start)
+	if start >= end {
+		return nil, start, nil
+	}
+	return r.events[start:end], end, nil
+}
+
+func newTestConnection(s *Server) *connection {
+	return &connection{
+		s:      s,
+		send:   make(chan interface{}, maxPendingMessages),
+		fp:     NewFilterParam(),
+		active: 1,
+		closed: make(chan struct{}),
+	}
+}
+
+func TestResume(t *testing.T) {
+	require := require.New(t)
+
+	addr := []byte("addr")
+	otherAddr := []byte("other")
+	replayer := &testReplayer{}
+	for i := 0; i < 2*maxReplayBatchSize; i++ {
+		eventAddr := addr
+		if i%2 == 1 {
+			eventAddr = otherAddr
+		}
+		replayer.events = append(replayer.events, &testFilterer{
+			cursor: uint64(i),
+			addr:   eventAddr,
+		})
+	}
+
+	s := NewReplayable(logging.NoLog{}, replayer)
+	c := newTestConnection(s)
+	require.NoError(c.fp.Add(addr))
+
+	require.NoError(c.handleResume(&Resume{Cursor: 10}))
+	for i := 10; i < len(replayer.events); i += 2 {
+		require.Equal(uint64(i), <-c.send)
+	}
+	require.Equal(&replayedMsg{Replayed: json.Uint64(len(replayer.events))}, <-c.send)
+
+	// New events are sent once the replay finishes.
+	s.Publish(&testFilterer{
+		cursor: uint64(len(replayer.events)),
+		addr:   addr,
+	})
+	require.Equal(uint64(len(replayer.events)), <-c.send)
+}
+
+func TestResumeBacklog(t *testing.T) {
+	require := require.New(t)
+
+	addr := []byte("addr")
+	s := NewReplayable(logging.NoLog{}, &testReplayer{})
+	c := newTestConnection(s)
+	require.NoError(c.fp.Add(addr))
+	c.replaying = true
+	s.subscribedConnections.Add(c)
+
+	// Events published while replaying are held until the replay finishes.
+	for cursor := uint64(3); cursor < 6; cursor++ {
+		s.Publish(&testFilterer{
+			cursor: cursor,
+			addr:   addr,
+		})
+	}
+	require.Empty(c.send)
+
+	// Events that were replayed aren't sent twice.
+	_, done := c.finishReplay(4, false)
+	require.True(done)
+	require.Equal(&replayedMsg{Replayed: 4}, <-c.send)
+	require.Equal(uint64(4), <-c.send)
+	require.Equal(uint64(5), <-c.send)
+	require.Empty(c.send)
+}
+
+func TestResumeBacklogDropped(t *testing.T) {
+	require := require.New(t)
+
+	addr := []byte("addr")
+	s := NewReplayable(logging.NoLog{}, &testReplayer{})
+	c := newTestConnection(s)
+	require.NoError(c.fp.Add(addr))
+	c.replaying = true
+	s.subscribedConnections.Add(c)
+
+	for cursor := uint64(0); cursor <= maxPendingMessages; cursor++ {
+		s.Publish(&testFilterer{
+			cursor: cursor,
+			addr:   addr,
+		})
+	}
+
+	// The dropped events must be replayed before the replay finishes.
+	resume, done := c.finishReplay(0, false)
+	require.False(done)
+	require.Zero(resume)
+	require.True(c.replaying)
+	_, done = c.finishReplay(maxPendingMessages, false)
+	require.True(done)
+	require.Equal(&replayedMsg{Replayed: maxPendingMessages}, <-c.send)
+	require.Equal(uint64(maxPendingMessages), <-c.send)
+	require.Empty(c.send)
+}
+
+func TestResumeBacklogDroppedWhileSending(t *testing.T) {
+	require := require.New(t)
+
+	addr := []byte("addr")
+	s := NewReplayable(logging.NoLog{}, &testReplayer{})
+	c := newTestConnection(s)
+	require.NoError(c.fp.Add(addr))
+	c.replaying = true
+	s.subscribedConnections.Add(c)
+
+	// Events are published and dropped after the backlog was first taken
+	// while finishing the replay.
+	const firstCursor = 10
+	for cursor := uint64(firstCursor); cursor <= firstCursor+maxPendingMessages; cursor++ {
+		s.Publish(&testFilterer{
+			cursor: cursor,
+			addr:   addr,
+		})
+	}
+
+	// The connection keeps replaying from the first dropped event.
+	backlog, resume, dropped := c.takeBacklog(false)
+	require.True(dropped)
+	require.Empty(backlog)
+	require.Equal(uint64(firstCursor), resume)
+	require.True(c.replaying)
+
+	// Events that weren't dropped are kept until the replay finishes.
+	backlog, _, dropped = c.takeBacklog(false)
+	require.False(dropped)
+	require.Len(backlog, 1)
+	require.Equal(uint64(firstCursor+maxPendingMessages), backlog[0].cursor)
+	require.True(c.replaying)
+
+	backlog, _, dropped = c.takeBacklog(false)
+	require.False(dropped)
+	require.Empty(backlog)
+	require.False(c.replaying)
+}
+
+func TestResumeErrors(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestConnection(New(logging.NoLog{}))
+	require.ErrorIs(c.handleResume(&Resume{}), ErrReplayNotSupported)
+
+	s := NewReplayable(logging.NoLog{}, &testReplayer{err: errTestReplay})
+	c = newTestConnection(s)
+	c.replaying = true
+	require.ErrorIs(c.handleResume(&Resume{}), ErrReplayInProgress)
+
+	// A failed replay is reported and the connection receives new events.
+	c.replaying = false
+	require.NoError(c.handleResume(&Resume{Cursor: 1}))
+	require.Equal(&errorMsg{Error: errTestReplay.Error()}, <-c.send)
+	require.Equal(&replayedMsg{Replayed: 1}, <-c.send)
+}
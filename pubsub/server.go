@@ -43,6 +43,12 @@ const (
 
 	// MaxAddresses the max number of addresses allowed
 	MaxAddresses = 10000
+
+	// MaxTypes the max number of event types allowed
+	MaxTypes = 64
+
+	// Maximum number of cursors to replay events from at a time.
+	maxReplayBatchSize = 64
 )
 
 type errorMsg struct {
@@ -65,13 +71,28 @@ type Server struct {
 	conns set.Set[*connection]
 	// subscribedConnections the connections that have activated subscriptions
 	subscribedConnections *connections
+	// replayer provides the events that were published before a connection
+	// resumed. If nil, connections can't resume.
+	replayer Replayer
 }
 
+// New returns a server that publishes events to websocket connections.
+//
 // Deprecated: The pubsub server is deprecated.
 func New(log logging.Logger) *Server {
+	return NewReplayable(log, nil)
+}
+
+// NewReplayable returns a server that publishes events to websocket
+// connections and replays the events provided by [replayer] to connections
+// that resume from a cursor.
+//
+// Deprecated: The pubsub server is deprecated.
+func NewReplayable(log logging.Logger, replayer Replayer) *Server {
 	return &Server{
 		log:                   log,
 		subscribedConnections: newConnections(),
+		replayer:              replayer,
 	}
 }
 
@@ -89,21 +110,24 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		send:   make(chan interface{}, maxPendingMessages),
 		fp:     NewFilterParam(),
 		active: 1,
+		closed: make(chan struct{}),
 	}
 	s.addConnection(conn)
 }
 
 func (s *Server) Publish(parser Filterer) {
 	conns := s.subscribedConnections.Conns()
+	if len(conns) == 0 {
+		return
+	}
+
 	toNotify, msg := parser.Filter(conns)
 	for i, shouldNotify := range toNotify {
-		if !shouldNotify {
-			continue
-		}
 		conn := conns[i].(*connection)
-		if !conn.Send(msg) {
-			s.log.Verbo("dropping message to subscribed connection due to too many pending messages")
+		if !conn.shouldSend(parser, shouldNotify) {
+			continue
 		}
+		conn.publish(parser, msg)
 	}
 }
 
@@ -40,6 +40,17 @@ if [[ $(protoc-gen-go-grpc --version | cut -f2 -d' ') != "${PROTOC_GEN_GO_GRPC_V
   exit 255
 fi
 
+### install "protoc-gen-connect-go"
+# any version changes here should also be bumped in Dockerfile.buf
+# ref. https://github.com/connectrpc/connect-go/releases
+PROTOC_GEN_CONNECT_GO_VERSION='v1.16.1'
+go install -v connectrpc.com/connect/cmd/protoc-gen-connect-go@${PROTOC_GEN_CONNECT_GO_VERSION}
+if [[ $(protoc-gen-connect-go --version) != "${PROTOC_GEN_CONNECT_GO_VERSION#v}" ]]; then
+  # e.g., 1.16.1
+  echo "could not find protoc-gen-connect-go ${PROTOC_GEN_CONNECT_GO_VERSION}, is it installed + in PATH?"
+  exit 255
+fi
+
 TARGET=$PWD/proto
 if [ -n "${1:-}" ]; then
   TARGET="$1"
@@ -62,3 +73,11 @@ if ! buf generate;  then
     echo "ERROR: protobuf generation failed"
     exit 1
 fi
+
+# The public APIs are also served over Connect, so their handlers are generated
+# separately from the handlers of the plugin protocols.
+echo "Re-generating Connect handlers..."
+if ! buf generate --template buf.gen.connect.yaml --path info --path health --path index --path platform;  then
+    echo "ERROR: Connect generation failed"
+    exit 1
+fi
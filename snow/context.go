@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -52,6 +53,11 @@ type Context struct {
 
 	// snowman++ attributes
 	ValidatorState validators.State // interface for P-Chain validators
+
+	// PeerScores exposes the node's scores of its peers. It is nil for VMs
+	// that are run in a separate process.
+	PeerScores tracker.PeerScores
+
 	// Chain-specific directory where arbitrary data can be written
 	ChainDataDir string
 }
@@ -5,6 +5,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/api/health"
@@ -78,3 +79,14 @@ type VM interface {
 	// information about their accounts.
 	CreateHandlers(context.Context) (map[string]http.Handler, error)
 }
+
+var ErrConfigReloaderNotImplemented = errors.New("vm does not implement ConfigReloader interface")
+
+// ConfigReloader is an optional interface that a VM can implement to apply a
+// new chain config without the chain being restarted.
+type ConfigReloader interface {
+	// ReloadConfig replaces the [configBytes] that were passed to Initialize.
+	//
+	// The chain's lock is held when this is called.
+	ReloadConfig(ctx context.Context, configBytes []byte) error
+}
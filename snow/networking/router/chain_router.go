@@ -393,7 +393,7 @@ func (cr *ChainRouter) AddChain(ctx context.Context, chain handler.Handler) {
 		zap.Stringer("chainID", chainID),
 	)
 	chain.SetOnStopped(func() {
-		cr.removeChain(ctx, chainID)
+		cr.removeChain(ctx, chain)
 	})
 	cr.chainHandlers[chainID] = chain
 
@@ -632,10 +632,13 @@ func (cr *ChainRouter) HealthCheck(context.Context) (interface{}, error) {
 
 // RemoveChain removes the specified chain so that incoming
 // messages can't be routed to it
-func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
+// removeChain removes [chain] from the router. If the chain was restarted, the
+// handler that replaced [chain] is not removed.
+func (cr *ChainRouter) removeChain(ctx context.Context, chain handler.Handler) {
+	chainID := chain.Context().ChainID
+
 	cr.lock.Lock()
-	chain, exists := cr.chainHandlers[chainID]
-	if !exists {
+	if registered, exists := cr.chainHandlers[chainID]; !exists || registered != chain {
 		cr.log.Debug("can't remove unknown chain",
 			zap.Stringer("chainID", chainID),
 		)
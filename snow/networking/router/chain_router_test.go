@@ -60,6 +60,7 @@ func TestShutdown(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -199,6 +200,7 @@ func TestShutdownTimesOut(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		metrics,
 	)
@@ -337,6 +339,7 @@ func TestRouterTimeout(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -696,6 +699,7 @@ func TestRouterHonorsRequestedEngine(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -916,6 +920,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -1066,6 +1071,7 @@ func TestConnectedSubnet(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"timeoutManager",
 		prometheus.NewRegistry(),
 	)
@@ -1183,6 +1189,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -1511,6 +1518,7 @@ func newChainRouterTest(t *testing.T) (*ChainRouter, *common.EngineTest) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -1607,3 +1615,72 @@ func newChainRouterTest(t *testing.T) (*ChainRouter, *common.EngineTest) {
 
 	return chainRouter, engine
 }
+
+func TestRemoveRestartedChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	go tm.Dispatch()
+	defer tm.Stop()
+
+	chainRouter := ChainRouter{}
+	require.NoError(chainRouter.Initialize(
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		tm,
+		time.Millisecond,
+		set.Set[ids.ID]{},
+		true,
+		set.Set[ids.ID]{},
+		nil,
+		HealthConfig{},
+		"",
+		prometheus.NewRegistry(),
+	))
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	var oldOnStopped func()
+	oldHandler := handler.NewMockHandler(ctrl)
+	oldHandler.EXPECT().Context().Return(ctx).AnyTimes()
+	oldHandler.EXPECT().Push(gomock.Any(), gomock.Any()).AnyTimes()
+	oldHandler.EXPECT().SetOnStopped(gomock.Any()).Do(func(onStopped func()) {
+		oldOnStopped = onStopped
+	})
+	chainRouter.AddChain(context.Background(), oldHandler)
+
+	var newOnStopped func()
+	newHandler := handler.NewMockHandler(ctrl)
+	newHandler.EXPECT().Context().Return(ctx).AnyTimes()
+	newHandler.EXPECT().Push(gomock.Any(), gomock.Any()).AnyTimes()
+	newHandler.EXPECT().SetOnStopped(gomock.Any()).Do(func(onStopped func()) {
+		newOnStopped = onStopped
+	})
+	chainRouter.AddChain(context.Background(), newHandler)
+
+	// The old handler stopping after it was replaced must not remove the new
+	// handler.
+	oldOnStopped()
+	require.Equal(newHandler, chainRouter.chainHandlers[ctx.ChainID])
+
+	newHandler.EXPECT().Stop(gomock.Any())
+	newHandler.EXPECT().AwaitStopped(gomock.Any())
+	newOnStopped()
+	require.NotContains(chainRouter.chainHandlers, ctx.ChainID)
+}
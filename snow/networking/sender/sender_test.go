@@ -57,6 +57,7 @@ func TestTimeout(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -70,7 +71,9 @@ func TestTimeout(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		"dummyNamespace",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -322,6 +325,7 @@ func TestReliableMessages(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -336,7 +340,9 @@ func TestReliableMessages(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		"dummyNamespace",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -468,6 +474,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -482,7 +489,9 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		logging.NoLog{},
 		metrics,
 		"dummyNamespace",
-		constants.DefaultNetworkCompressionType,
+		message.CompressionConfig{
+			Type: constants.DefaultNetworkCompressionType,
+		},
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
@@ -33,6 +34,9 @@ type Manager interface {
 	// Must be called before any method calls that use the
 	// ID of the chain.
 	RegisterChain(ctx *snow.ConsensusContext) error
+	// DeregisterChain removes the metrics of the given chain, so that the
+	// chain can be registered again after it is restarted.
+	DeregisterChain(chainID ids.ID)
 	// RegisterRequest notes that we expect a response of type [op] from
 	// [nodeID] for chain [chainID]. If we don't receive a response in
 	// time, [timeoutHandler] is executed.
@@ -71,6 +75,7 @@ type Manager interface {
 func NewManager(
 	timeoutConfig *timer.AdaptiveTimeoutConfig,
 	benchlistMgr benchlist.Manager,
+	peerScorer tracker.PeerScorer,
 	metricsNamespace string,
 	metricsRegister prometheus.Registerer,
 ) (Manager, error) {
@@ -84,6 +89,7 @@ func NewManager(
 	}
 	return &manager{
 		benchlistMgr: benchlistMgr,
+		peerScorer:   peerScorer,
 		tm:           tm,
 	}, nil
 }
@@ -91,6 +97,7 @@ func NewManager(
 type manager struct {
 	tm           timer.AdaptiveTimeoutManager
 	benchlistMgr benchlist.Manager
+	peerScorer   tracker.PeerScorer
 	metrics      metrics
 	stopOnce     sync.Once
 }
@@ -119,6 +126,10 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	return nil
 }
 
+func (m *manager) DeregisterChain(chainID ids.ID) {
+	m.metrics.DeregisterChain(chainID)
+}
+
 // RegisterRequest notes that we expect a response of type [op] from
 // [nodeID] regarding chain [chainID]. If we don't receive a response in
 // time, [timeoutHandler]  is executed.
@@ -130,6 +141,7 @@ func (m *manager) RegisterRequest(
 	timeoutHandler func(),
 ) {
 	newTimeoutHandler := func() {
+		m.peerScorer.RegisterFailure(nodeID)
 		if requestID.Op != byte(message.AppResponseOp) {
 			// If the request timed out and wasn't an AppRequest, tell the
 			// benchlist manager.
@@ -151,6 +163,7 @@ func (m *manager) RegisterResponse(
 ) {
 	m.metrics.Observe(nodeID, chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
+	m.peerScorer.RegisterResponse(nodeID, latency)
 	m.tm.Remove(requestID)
 }
 
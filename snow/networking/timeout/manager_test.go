@@ -13,6 +13,8 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/snow/snowtest"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
 
@@ -27,6 +29,7 @@ func TestManagerFire(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 		},
 		benchlist,
+		tracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -47,3 +50,33 @@ func TestManagerFire(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestManagerRegisterChain(t *testing.T) {
+	require := require.New(t)
+
+	manager, err := NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     time.Millisecond,
+			MinimumTimeout:     time.Millisecond,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutCoefficient: 1.25,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		benchlist.NewNoBenchlist(),
+		tracker.NewNoPeerScorer(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(manager.RegisterChain(snowtest.ConsensusContext(snowCtx)))
+
+	// A chain can't be registered twice.
+	err = manager.RegisterChain(snowtest.ConsensusContext(snowCtx))
+	require.ErrorIs(err, errChainAlreadyRegistered)
+
+	// A restarted chain can be registered again once it was deregistered.
+	manager.DeregisterChain(snowCtx.ChainID)
+	require.NoError(manager.RegisterChain(snowtest.ConsensusContext(snowCtx)))
+}
@@ -4,6 +4,7 @@
 package timeout
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -23,6 +24,8 @@ const (
 	validatorIDLabel      = "validatorID"
 )
 
+var errChainAlreadyRegistered = errors.New("chain has already been registered")
+
 type metrics struct {
 	lock           sync.Mutex
 	chainToMetrics map[ids.ID]*chainMetrics
@@ -36,7 +39,7 @@ func (m *metrics) RegisterChain(ctx *snow.ConsensusContext) error {
 		m.chainToMetrics = map[ids.ID]*chainMetrics{}
 	}
 	if _, exists := m.chainToMetrics[ctx.ChainID]; exists {
-		return fmt.Errorf("chain %s has already been registered", ctx.ChainID)
+		return fmt.Errorf("%w: %s", errChainAlreadyRegistered, ctx.ChainID)
 	}
 	cm, err := newChainMetrics(ctx, false)
 	if err != nil {
@@ -46,6 +49,13 @@ func (m *metrics) RegisterChain(ctx *snow.ConsensusContext) error {
 	return nil
 }
 
+func (m *metrics) DeregisterChain(chainID ids.ID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.chainToMetrics, chainID)
+}
+
 // Record that a response of type [op] took [latency]
 func (m *metrics) Observe(nodeID ids.NodeID, chainID ids.ID, op message.Op, latency time.Duration) {
 	m.lock.Lock()
@@ -42,6 +42,18 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// DeregisterChain mocks base method.
+func (m *MockManager) DeregisterChain(arg0 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeregisterChain", arg0)
+}
+
+// DeregisterChain indicates an expected call of DeregisterChain.
+func (mr *MockManagerMockRecorder) DeregisterChain(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterChain", reflect.TypeOf((*MockManager)(nil).DeregisterChain), arg0)
+}
+
 // Dispatch mocks base method.
 func (m *MockManager) Dispatch() {
 	m.ctrl.T.Helper()
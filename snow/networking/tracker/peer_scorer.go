@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+const (
+	// maxScoredPeers bounds the number of peers whose observations are kept.
+	// The observations of the least recently observed peers are dropped
+	// first.
+	maxScoredPeers = 10_000
+
+	// minTargetFactor is the lowest factor that exceeding a target can reduce
+	// a score by, so that peers that are given a target of 0 aren't scored 0.
+	minTargetFactor = .01
+)
+
+var (
+	_ PeerScorer = (*peerScorer)(nil)
+	_ PeerScorer = noPeerScorer{}
+)
+
+type PeerScoreConfig struct {
+	// Halflife of the observations that the scores are based on.
+	Halflife time.Duration `json:"halflife"`
+
+	// TargetLatency is the average response latency that peers are expected
+	// to stay within. Peers with a higher average latency are scored lower.
+	TargetLatency time.Duration `json:"targetLatency"`
+}
+
+// PeerScore is the score of a peer along with the observations it is based
+// on.
+type PeerScore struct {
+	// Score is in the range [0, 1]. Higher scores are better.
+	Score json.Float64 `json:"score"`
+	// Latency is the recent average latency of the peer's responses.
+	Latency time.Duration `json:"latency"`
+	// FailureRate is the portion of recent requests to the peer that failed.
+	FailureRate json.Float64 `json:"failureRate"`
+	// InvalidMessages is the number of invalid messages recently sent by the
+	// peer. Older messages are weighted less.
+	InvalidMessages json.Float64 `json:"invalidMessages"`
+	// CPUUsage is the CPU usage caused by the peer's messages.
+	CPUUsage json.Float64 `json:"cpuUsage"`
+	// DiskUsage is the disk usage caused by the peer's messages.
+	DiskUsage json.Float64 `json:"diskUsage"`
+}
+
+// PeerScores exposes the scores of peers.
+type PeerScores interface {
+	// Score returns the score of [nodeID] in the range [0, 1]. Higher scores
+	// are better. Peers without any observed misbehavior have a score of 1.
+	Score(nodeID ids.NodeID) float64
+}
+
+// PeerScorer combines the response latency, failure rate, invalid messages
+// and resource usage of peers into a single score per peer.
+type PeerScorer interface {
+	PeerScores
+
+	// RegisterResponse registers that [nodeID] responded to a request
+	// [latency] after the request was sent.
+	RegisterResponse(nodeID ids.NodeID, latency time.Duration)
+	// RegisterFailure registers that a request sent to [nodeID] failed.
+	RegisterFailure(nodeID ids.NodeID)
+	// RegisterInvalidMessage registers that [nodeID] sent an invalid message.
+	RegisterInvalidMessage(nodeID ids.NodeID)
+	// PeerScore returns the score of [nodeID] along with the observations it
+	// is based on.
+	PeerScore(nodeID ids.NodeID) PeerScore
+}
+
+type peerObservations struct {
+	// latency is nil until the peer responds to a request.
+	latency safemath.Averager
+	// failures is nil until the peer responds to, or fails, a request.
+	failures safemath.Averager
+
+	invalidMessages        float64
+	invalidMessagesUpdated time.Time
+}
+
+type peerScorer struct {
+	lock  sync.Mutex
+	clock mockable.Clock
+
+	halflife      time.Duration
+	targetLatency time.Duration
+	cpuTracker    Tracker
+	cpuTargeter   Targeter
+	diskTracker   Tracker
+	diskTargeter  Targeter
+
+	peers cache.LRU[ids.NodeID, *peerObservations]
+}
+
+// NewPeerScorer returns a PeerScorer that scores the resource usage of peers
+// against the targets provided by [cpuTargeter] and [diskTargeter].
+func NewPeerScorer(
+	config *PeerScoreConfig,
+	cpuTracker Tracker,
+	cpuTargeter Targeter,
+	diskTracker Tracker,
+	diskTargeter Targeter,
+) PeerScorer {
+	return &peerScorer{
+		halflife:      config.Halflife,
+		targetLatency: config.TargetLatency,
+		cpuTracker:    cpuTracker,
+		cpuTargeter:   cpuTargeter,
+		diskTracker:   diskTracker,
+		diskTargeter:  diskTargeter,
+		peers:         cache.LRU[ids.NodeID, *peerObservations]{Size: maxScoredPeers},
+	}
+}
+
+func (s *peerScorer) RegisterResponse(nodeID ids.NodeID, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Time()
+	observations := s.getObservations(nodeID)
+	if observations.latency == nil {
+		observations.latency = safemath.NewAverager(float64(latency), s.halflife, now)
+	} else {
+		observations.latency.Observe(float64(latency), now)
+	}
+	s.observeFailure(observations, 0, now)
+}
+
+func (s *peerScorer) RegisterFailure(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	observations := s.getObservations(nodeID)
+	s.observeFailure(observations, 1, s.clock.Time())
+}
+
+func (s *peerScorer) RegisterInvalidMessage(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.clock.Time()
+	observations := s.getObservations(nodeID)
+	observations.invalidMessages = s.decay(observations.invalidMessages, observations.invalidMessagesUpdated, now) + 1
+	observations.invalidMessagesUpdated = now
+}
+
+func (s *peerScorer) Score(nodeID ids.NodeID) float64 {
+	return float64(s.PeerScore(nodeID).Score)
+}
+
+func (s *peerScorer) PeerScore(nodeID ids.NodeID) PeerScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var (
+		now             = s.clock.Time()
+		latency         float64
+		failureRate     float64
+		invalidMessages float64
+	)
+	if observations, ok := s.peers.Get(nodeID); ok {
+		if observations.latency != nil {
+			latency = observations.latency.Read()
+		}
+		if observations.failures != nil {
+			failureRate = observations.failures.Read()
+		}
+		invalidMessages = s.decay(observations.invalidMessages, observations.invalidMessagesUpdated, now)
+	}
+
+	var (
+		cpuUsage  = s.cpuTracker.Usage(nodeID, now)
+		diskUsage = s.diskTracker.Usage(nodeID, now)
+		score     = targetFactor(latency, float64(s.targetLatency)) *
+			(1 - failureRate) *
+			(1 / (1 + invalidMessages)) *
+			targetFactor(cpuUsage, s.cpuTargeter.TargetUsage(nodeID)) *
+			targetFactor(diskUsage, s.diskTargeter.TargetUsage(nodeID))
+	)
+	return PeerScore{
+		Score:           json.Float64(score),
+		Latency:         time.Duration(latency),
+		FailureRate:     json.Float64(failureRate),
+		InvalidMessages: json.Float64(invalidMessages),
+		CPUUsage:        json.Float64(cpuUsage),
+		DiskUsage:       json.Float64(diskUsage),
+	}
+}
+
+// Assumes [s.lock] is held.
+func (s *peerScorer) getObservations(nodeID ids.NodeID) *peerObservations {
+	observations, ok := s.peers.Get(nodeID)
+	if !ok {
+		observations = &peerObservations{}
+		s.peers.Put(nodeID, observations)
+	}
+	return observations
+}
+
+// Assumes [s.lock] is held.
+func (s *peerScorer) observeFailure(observations *peerObservations, failure float64, now time.Time) {
+	if observations.failures == nil {
+		observations.failures = safemath.NewAverager(failure, s.halflife, now)
+		return
+	}
+	observations.failures.Observe(failure, now)
+}
+
+// decay returns [value], which was last updated at [updated], halved for every
+// halflife that passed since.
+func (s *peerScorer) decay(value float64, updated time.Time, now time.Time) float64 {
+	elapsed := now.Sub(updated)
+	if value == 0 || elapsed <= 0 {
+		return value
+	}
+	return value * math.Exp2(-float64(elapsed)/float64(s.halflife))
+}
+
+// targetFactor returns 1 if [usage] is within [target]. Otherwise, the
+// returned factor is inversely proportional to [usage], but is at least
+// [minTargetFactor].
+func targetFactor(usage, target float64) float64 {
+	if usage <= target {
+		return 1
+	}
+	return max(target/usage, minTargetFactor)
+}
+
+// NewNoPeerScorer returns a PeerScorer that scores every peer with 1.
+func NewNoPeerScorer() PeerScorer {
+	return noPeerScorer{}
+}
+
+type noPeerScorer struct{}
+
+func (noPeerScorer) RegisterResponse(ids.NodeID, time.Duration) {}
+
+func (noPeerScorer) RegisterFailure(ids.NodeID) {}
+
+func (noPeerScorer) RegisterInvalidMessage(ids.NodeID) {}
+
+func (noPeerScorer) Score(ids.NodeID) float64 {
+	return 1
+}
+
+func (noPeerScorer) PeerScore(ids.NodeID) PeerScore {
+	return PeerScore{Score: 1}
+}
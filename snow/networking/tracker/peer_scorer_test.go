@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+func newTestPeerScorer(t *testing.T, cpuUsage float64, cpuTarget float64) *peerScorer {
+	ctrl := gomock.NewController(t)
+
+	cpuTracker := NewMockTracker(ctrl)
+	cpuTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(cpuUsage).AnyTimes()
+	cpuTargeter := NewMockTargeter(ctrl)
+	cpuTargeter.EXPECT().TargetUsage(gomock.Any()).Return(cpuTarget).AnyTimes()
+	diskTracker := NewMockTracker(ctrl)
+	diskTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(0.0).AnyTimes()
+	diskTargeter := NewMockTargeter(ctrl)
+	diskTargeter.EXPECT().TargetUsage(gomock.Any()).Return(1.0).AnyTimes()
+
+	scorer := NewPeerScorer(
+		&PeerScoreConfig{
+			Halflife:      time.Minute,
+			TargetLatency: time.Second,
+		},
+		cpuTracker,
+		cpuTargeter,
+		diskTracker,
+		diskTargeter,
+	).(*peerScorer)
+	scorer.clock.Set(time.Unix(1, 0))
+	return scorer
+}
+
+func TestPeerScorerUnknownPeer(t *testing.T) {
+	scorer := newTestPeerScorer(t, 0, 1)
+	require.Equal(t, 1.0, scorer.Score(ids.GenerateTestNodeID()))
+}
+
+func TestPeerScorerLatency(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newTestPeerScorer(t, 0, 1)
+	fastNodeID := ids.GenerateTestNodeID()
+	slowNodeID := ids.GenerateTestNodeID()
+
+	scorer.RegisterResponse(fastNodeID, 500*time.Millisecond)
+	scorer.RegisterResponse(slowNodeID, 4*time.Second)
+
+	require.Equal(1.0, scorer.Score(fastNodeID))
+	require.Equal(0.25, scorer.Score(slowNodeID))
+
+	peerScore := scorer.PeerScore(slowNodeID)
+	require.Equal(4*time.Second, peerScore.Latency)
+	require.Zero(peerScore.FailureRate)
+}
+
+func TestPeerScorerFailures(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newTestPeerScorer(t, 0, 1)
+	nodeID := ids.GenerateTestNodeID()
+
+	scorer.RegisterFailure(nodeID)
+	require.Zero(scorer.Score(nodeID))
+
+	// Responses recover the score over time.
+	for i := 0; i < 10; i++ {
+		scorer.clock.Set(scorer.clock.Time().Add(time.Minute))
+		scorer.RegisterResponse(nodeID, time.Millisecond)
+	}
+	score := scorer.Score(nodeID)
+	require.Greater(score, 0.9)
+	require.Less(score, 1.0)
+}
+
+func TestPeerScorerInvalidMessages(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newTestPeerScorer(t, 0, 1)
+	nodeID := ids.GenerateTestNodeID()
+
+	scorer.RegisterInvalidMessage(nodeID)
+	require.Equal(0.5, scorer.Score(nodeID))
+
+	scorer.RegisterInvalidMessage(nodeID)
+	require.InDelta(1.0/3, scorer.Score(nodeID), 1e-9)
+
+	// The invalid messages are halved every halflife.
+	scorer.clock.Set(scorer.clock.Time().Add(time.Minute))
+	require.Equal(json.Float64(1), scorer.PeerScore(nodeID).InvalidMessages)
+	require.Equal(0.5, scorer.Score(nodeID))
+}
+
+func TestPeerScorerResourceUsage(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+
+	scorer := newTestPeerScorer(t, 1, 2)
+	require.Equal(1.0, scorer.Score(nodeID))
+
+	scorer = newTestPeerScorer(t, 4, 2)
+	require.Equal(0.5, scorer.Score(nodeID))
+	require.Equal(json.Float64(4), scorer.PeerScore(nodeID).CPUUsage)
+
+	// Peers without any allotted usage aren't scored 0.
+	scorer = newTestPeerScorer(t, 4, 0)
+	require.Equal(minTargetFactor, scorer.Score(nodeID))
+}
+
+func TestPeerScorerEviction(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newTestPeerScorer(t, 0, 1)
+	nodeID := ids.GenerateTestNodeID()
+	scorer.RegisterFailure(nodeID)
+
+	for i := 0; i < maxScoredPeers; i++ {
+		scorer.RegisterResponse(ids.GenerateTestNodeID(), time.Millisecond)
+	}
+	require.Equal(maxScoredPeers, scorer.peers.Len())
+	require.Equal(1.0, scorer.Score(nodeID))
+}
@@ -91,6 +91,11 @@ type Manager interface {
 	// When a validator's weight changes, or a validator is added/removed,
 	// this listener is called.
 	RegisterCallbackListener(subnetID ids.ID, listener SetCallbackListener)
+
+	// DeregisterCallbackListener stops calling [listener] when the validators
+	// of the subnet change. [listener] must have been registered with
+	// RegisterCallbackListener.
+	DeregisterCallbackListener(subnetID ids.ID, listener SetCallbackListener)
 }
 
 // NewManager returns a new, empty manager
@@ -277,6 +282,23 @@ func (m *manager) RegisterCallbackListener(subnetID ids.ID, listener SetCallback
 	set.RegisterCallbackListener(listener)
 }
 
+func (m *manager) DeregisterCallbackListener(subnetID ids.ID, listener SetCallbackListener) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	set, exists := m.subnetToVdrs[subnetID]
+	if !exists {
+		return
+	}
+
+	set.DeregisterCallbackListener(listener)
+	// If the subnet has no validators and no other callback listeners are
+	// registered, remove the subnet
+	if set.Len() == 0 && !set.HasCallbackRegistered() {
+		delete(m.subnetToVdrs, subnetID)
+	}
+}
+
 func (m *manager) String() string {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
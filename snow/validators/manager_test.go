@@ -550,3 +550,32 @@ func TestValidatorRemovedCallback(t *testing.T) {
 	// should not be called for subnetID2
 	require.Equal(2, callCount)
 }
+
+func TestDeregisterCallback(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.BuildTestNodeID([]byte{1})
+	txID0 := ids.GenerateTestID()
+	weight0 := uint64(1)
+
+	m := NewManager().(*manager)
+	subnetID := ids.GenerateTestID()
+	callCount := 0
+	listener := &callbackListener{
+		t: t,
+		onAdd: func(ids.NodeID, *bls.PublicKey, ids.ID, uint64) {
+			callCount++
+		},
+	}
+	m.RegisterCallbackListener(subnetID, listener)
+	require.NoError(m.AddStaker(subnetID, nodeID0, nil, txID0, weight0))
+	require.Equal(1, callCount)
+
+	// Deregistered listeners aren't called.
+	m.DeregisterCallbackListener(subnetID, listener)
+	require.NoError(m.RemoveWeight(subnetID, nodeID0, weight0))
+	require.Equal(1, callCount)
+
+	// The subnet is removed once it has no validators or listeners.
+	require.Empty(m.subnetToVdrs)
+}
@@ -83,6 +83,18 @@ func (mr *MockManagerMockRecorder) Count(subnetID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockManager)(nil).Count), subnetID)
 }
 
+// DeregisterCallbackListener mocks base method.
+func (m *MockManager) DeregisterCallbackListener(subnetID ids.ID, listener SetCallbackListener) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeregisterCallbackListener", subnetID, listener)
+}
+
+// DeregisterCallbackListener indicates an expected call of DeregisterCallbackListener.
+func (mr *MockManagerMockRecorder) DeregisterCallbackListener(subnetID, listener any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeregisterCallbackListener", reflect.TypeOf((*MockManager)(nil).DeregisterCallbackListener), subnetID, listener)
+}
+
 // GetMap mocks base method.
 func (m *MockManager) GetMap(subnetID ids.ID) map[ids.NodeID]*GetValidatorOutput {
 	m.ctrl.T.Helper()
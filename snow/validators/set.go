@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 	"sync"
 
@@ -315,6 +316,15 @@ func (s *vdrSet) RegisterCallbackListener(callbackListener SetCallbackListener)
 	}
 }
 
+func (s *vdrSet) DeregisterCallbackListener(callbackListener SetCallbackListener) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.callbackListeners = slices.DeleteFunc(s.callbackListeners, func(l SetCallbackListener) bool {
+		return l == callbackListener
+	})
+}
+
 // Assumes [s.lock] is held
 func (s *vdrSet) callWeightChangeCallbacks(node ids.NodeID, oldWeight, newWeight uint64) {
 	for _, callbackListener := range s.callbackListeners {
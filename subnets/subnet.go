@@ -30,6 +30,9 @@ type Subnet interface {
 	// Config returns config of this Subnet
 	Config() Config
 
+	// SetConfig replaces the config of this Subnet
+	SetConfig(Config)
+
 	Allower
 }
 
@@ -90,10 +93,23 @@ func (s *subnet) AddChain(chainID ids.ID) bool {
 }
 
 func (s *subnet) Config() Config {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	return s.config
 }
 
+func (s *subnet) SetConfig(config Config) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.config = config
+}
+
 func (s *subnet) IsAllowed(nodeID ids.NodeID, isValidator bool) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	// Case 1: NodeID is this node
 	// Case 2: This subnet is not validator-only subnet
 	// Case 3: NodeID is a validator for this chain
@@ -68,3 +68,17 @@ func TestIsAllowed(t *testing.T) {
 	require.False(s.IsAllowed(ids.GenerateTestNodeID(), false), "Non-validator should not be allowed with validator only rules and allowed nodes")
 	require.True(s.IsAllowed(allowedNodeID, true), "Non-validator allowed node should be allowed with validator only rules and allowed nodes")
 }
+
+func TestSetConfig(t *testing.T) {
+	require := require.New(t)
+
+	s := New(ids.GenerateTestNodeID(), Config{})
+	require.True(s.IsAllowed(ids.GenerateTestNodeID(), false))
+
+	config := Config{
+		ValidatorOnly: true,
+	}
+	s.SetConfig(config)
+	require.Equal(config, s.Config())
+	require.False(s.IsAllowed(ids.GenerateTestNodeID(), false))
+}
@@ -24,3 +24,13 @@ func TestAtomic(t *testing.T) {
 	a.Set(false)
 	require.False(a.Get())
 }
+
+func TestNewAtomic(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAtomic(true)
+	require.True(a.Get())
+
+	a.Set(false)
+	require.False(a.Get())
+}
@@ -50,16 +50,22 @@ func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
 	reader := zstd.NewReader(bytes.NewReader(msg))
 	defer reader.Close()
 
-	// We allow [io.LimitReader] to read up to [z.maxSize + 1] bytes, so that if
+	return readAtMost(reader, z.maxSize)
+}
+
+// readAtMost reads the decompressed payload from [reader]. If the payload is
+// greater than [maxSize], an error is returned.
+func readAtMost(reader io.Reader, maxSize int64) ([]byte, error) {
+	// We allow [io.LimitReader] to read up to [maxSize + 1] bytes, so that if
 	// the decompressed payload is greater than the maximum size, this function
 	// will return the appropriate error instead of an incomplete byte slice.
-	limitReader := io.LimitReader(reader, z.maxSize+1)
+	limitReader := io.LimitReader(reader, maxSize+1)
 	decompressed, err := io.ReadAll(limitReader)
 	if err != nil {
 		return nil, err
 	}
-	if int64(len(decompressed)) > z.maxSize {
-		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), z.maxSize)
+	if int64(len(decompressed)) > maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), maxSize)
 	}
 	return decompressed, nil
 }
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/DataDog/zstd"
+)
+
+const (
+	zstdFrameMagic      = 0xFD2FB528
+	zstdDictionaryMagic = 0xEC30A437
+
+	// The frame header descriptor follows the frame magic number.
+	zstdFrameHeaderDescriptorOffset = 4
+	zstdDictionaryIDFlagMask        = 0x03
+	zstdSingleSegmentFlag           = 0x20
+)
+
+var (
+	_ Compressor = (*zstdDictionaryCompressor)(nil)
+
+	ErrInvalidZstdDictionary = errors.New("invalid zstd dictionary")
+	ErrInvalidZstdFrame      = errors.New("invalid zstd frame")
+
+	// zstdDictionaryIDSizes maps the dictionary ID flag of a frame header
+	// descriptor to the number of bytes of the frame's dictionary ID.
+	zstdDictionaryIDSizes = [4]int{0, 1, 2, 4}
+)
+
+// ZstdDictionaryID returns the ID of [dictionary]. The dictionary must be in
+// the zstd dictionary format, as produced by `zstd --train`. Raw content
+// dictionaries don't have an ID and are rejected.
+func ZstdDictionaryID(dictionary []byte) (uint32, error) {
+	if len(dictionary) < 8 {
+		return 0, fmt.Errorf("%w: too short", ErrInvalidZstdDictionary)
+	}
+	if magic := binary.LittleEndian.Uint32(dictionary); magic != zstdDictionaryMagic {
+		return 0, fmt.Errorf("%w: unexpected magic number 0x%x", ErrInvalidZstdDictionary, magic)
+	}
+	id := binary.LittleEndian.Uint32(dictionary[4:])
+	if id == 0 {
+		return 0, fmt.Errorf("%w: missing ID", ErrInvalidZstdDictionary)
+	}
+	return id, nil
+}
+
+// ZstdFrameDictionaryID returns the ID of the dictionary that [frame] was
+// compressed with. If [frame] was compressed without a dictionary, 0 is
+// returned.
+func ZstdFrameDictionaryID(frame []byte) (uint32, error) {
+	if len(frame) <= zstdFrameHeaderDescriptorOffset {
+		return 0, fmt.Errorf("%w: too short", ErrInvalidZstdFrame)
+	}
+	if magic := binary.LittleEndian.Uint32(frame); magic != zstdFrameMagic {
+		return 0, fmt.Errorf("%w: unexpected magic number 0x%x", ErrInvalidZstdFrame, magic)
+	}
+
+	descriptor := frame[zstdFrameHeaderDescriptorOffset]
+	offset := zstdFrameHeaderDescriptorOffset + 1
+	if descriptor&zstdSingleSegmentFlag == 0 {
+		// Skip the window descriptor
+		offset++
+	}
+	size := zstdDictionaryIDSizes[descriptor&zstdDictionaryIDFlagMask]
+	if len(frame) < offset+size {
+		return 0, fmt.Errorf("%w: truncated header", ErrInvalidZstdFrame)
+	}
+
+	var id uint32
+	for i := size - 1; i >= 0; i-- {
+		id = id<<8 | uint32(frame[offset+i])
+	}
+	return id, nil
+}
+
+// NewZstdDictionaryCompressor returns a zstd compressor that compresses and
+// decompresses with [dictionary]. See ZstdDictionaryID for the expected format
+// of [dictionary].
+func NewZstdDictionaryCompressor(maxSize int64, dictionary []byte) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// See NewZstdCompressor
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+	if _, err := ZstdDictionaryID(dictionary); err != nil {
+		return nil, err
+	}
+
+	// The bulk processor digests the dictionary once, rather than on every
+	// call to Compress.
+	processor, err := zstd.NewBulkProcessor(dictionary, zstd.DefaultCompression)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidZstdDictionary, err)
+	}
+	return &zstdDictionaryCompressor{
+		maxSize:    maxSize,
+		dictionary: dictionary,
+		processor:  processor,
+	}, nil
+}
+
+type zstdDictionaryCompressor struct {
+	maxSize    int64
+	dictionary []byte
+	processor  *zstd.BulkProcessor
+}
+
+func (z *zstdDictionaryCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), z.maxSize)
+	}
+	compressed, err := z.processor.Compress(nil, msg)
+	// The digested dictionary is freed by a finalizer of the processor, which
+	// could otherwise run while the dictionary is still in use.
+	runtime.KeepAlive(z.processor)
+	return compressed, err
+}
+
+func (z *zstdDictionaryCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The streaming decompressor is used, rather than the bulk processor, so
+	// that the size of the decompressed payload is bounded regardless of the
+	// content size claimed by the frame header.
+	reader := zstd.NewReaderDict(bytes.NewReader(msg), z.dictionary)
+	defer reader.Close()
+
+	return readAtMost(reader, z.maxSize)
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_ "embed"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+const zstdDictionaryID = 1000001
+
+var (
+	// zstdDictionary was trained with `zstd --train` on small JSON
+	// transactions, similar to [zstdDictionarySample].
+	//
+	//go:embed zstd_dictionary.bin
+	zstdDictionary []byte
+
+	zstdDictionarySample = []byte(`{"type": "gossip", "chain": "C", "txs": [{"from": "0x6a3e2c1c4b5b4a8f3f0e1d9c7b2a6e5d4c3b2a19", "nonce": 42, "gasPrice": "25000000000", "value": "123456789"}]}`)
+)
+
+func TestZstdDictionaryID(t *testing.T) {
+	tests := []struct {
+		name        string
+		dictionary  []byte
+		expectedID  uint32
+		expectedErr error
+	}{
+		{
+			name:       "trained dictionary",
+			dictionary: zstdDictionary,
+			expectedID: zstdDictionaryID,
+		},
+		{
+			name:        "too short",
+			dictionary:  zstdDictionary[:7],
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+		{
+			name:        "raw content",
+			dictionary:  zstdDictionarySample,
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+		{
+			name:        "missing ID",
+			dictionary:  []byte{0x37, 0xa4, 0x30, 0xec, 0, 0, 0, 0},
+			expectedErr: ErrInvalidZstdDictionary,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			id, err := ZstdDictionaryID(test.dictionary)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedID, id)
+		})
+	}
+}
+
+func TestZstdFrameDictionaryID(t *testing.T) {
+	require := require.New(t)
+
+	plainCompressor, err := NewZstdCompressor(maxMessageSize)
+	require.NoError(err)
+	dictionaryCompressor, err := NewZstdDictionaryCompressor(maxMessageSize, zstdDictionary)
+	require.NoError(err)
+
+	for _, size := range []int{0, 1, 256, maxMessageSize} {
+		msg := utils.RandomBytes(size)
+
+		frame, err := plainCompressor.Compress(msg)
+		require.NoError(err)
+		id, err := ZstdFrameDictionaryID(frame)
+		require.NoError(err)
+		require.Zero(id)
+
+		frame, err = dictionaryCompressor.Compress(msg)
+		require.NoError(err)
+		id, err = ZstdFrameDictionaryID(frame)
+		require.NoError(err)
+		require.Equal(uint32(zstdDictionaryID), id)
+	}
+
+	_, err = ZstdFrameDictionaryID([]byte{0x28, 0xb5, 0x2f, 0xfd})
+	require.ErrorIs(err, ErrInvalidZstdFrame)
+
+	_, err = ZstdFrameDictionaryID(zstdDictionarySample)
+	require.ErrorIs(err, ErrInvalidZstdFrame)
+
+	// A single segment frame with a 4 byte dictionary ID that is cut short.
+	_, err = ZstdFrameDictionaryID([]byte{0x28, 0xb5, 0x2f, 0xfd, 0x23, 0x41, 0x42})
+	require.ErrorIs(err, ErrInvalidZstdFrame)
+}
+
+func TestZstdDictionaryCompressDecompress(t *testing.T) {
+	require := require.New(t)
+
+	plainCompressor, err := NewZstdCompressor(maxMessageSize)
+	require.NoError(err)
+	dictionaryCompressor, err := NewZstdDictionaryCompressor(maxMessageSize, zstdDictionary)
+	require.NoError(err)
+
+	plainCompressed, err := plainCompressor.Compress(zstdDictionarySample)
+	require.NoError(err)
+	dictionaryCompressed, err := dictionaryCompressor.Compress(zstdDictionarySample)
+	require.NoError(err)
+
+	// Small messages that are similar to the training samples should compress
+	// much better with the dictionary.
+	require.Less(len(dictionaryCompressed), len(plainCompressed))
+
+	decompressed, err := dictionaryCompressor.Decompress(dictionaryCompressed)
+	require.NoError(err)
+	require.Equal(zstdDictionarySample, decompressed)
+
+	// The dictionary is required to decompress the message.
+	_, err = plainCompressor.Decompress(dictionaryCompressed)
+	require.Error(err) //nolint:forbidigo // the error is returned by the zstd library
+
+	// Messages compressed without a dictionary can still be decompressed.
+	decompressed, err = dictionaryCompressor.Decompress(plainCompressed)
+	require.NoError(err)
+	require.Equal(zstdDictionarySample, decompressed)
+
+	maxMessage := utils.RandomBytes(maxMessageSize)
+	maxMessageCompressed, err := dictionaryCompressor.Compress(maxMessage)
+	require.NoError(err)
+	maxMessageDecompressed, err := dictionaryCompressor.Decompress(maxMessageCompressed)
+	require.NoError(err)
+	require.Equal(maxMessage, maxMessageDecompressed)
+}
+
+func TestZstdDictionarySizeLimiting(t *testing.T) {
+	require := require.New(t)
+
+	compressor, err := NewZstdDictionaryCompressor(maxMessageSize, zstdDictionary)
+	require.NoError(err)
+
+	data := make([]byte, maxMessageSize+1)
+	_, err = compressor.Compress(data)
+	require.ErrorIs(err, ErrMsgTooLarge)
+
+	compressor2, err := NewZstdDictionaryCompressor(2*maxMessageSize, zstdDictionary)
+	require.NoError(err)
+
+	dataCompressed, err := compressor2.Compress(data)
+	require.NoError(err)
+
+	_, err = compressor.Decompress(dataCompressed)
+	require.ErrorIs(err, ErrDecompressedMsgTooLarge)
+}
+
+func TestNewZstdDictionaryCompressorErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewZstdDictionaryCompressor(math.MaxInt64, zstdDictionary)
+	require.ErrorIs(err, ErrInvalidMaxSizeCompressor)
+
+	_, err = NewZstdDictionaryCompressor(maxMessageSize, zstdDictionarySample)
+	require.ErrorIs(err, ErrInvalidZstdDictionary)
+}
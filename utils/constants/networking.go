@@ -102,4 +102,8 @@ const (
 	// Delays
 	DefaultNetworkInitialReconnectDelay = time.Second
 	DefaultNetworkMaxReconnectDelay     = time.Minute
+
+	// Peer Scoring
+	DefaultNetworkPeerScoreHalflife      = 5 * time.Minute
+	DefaultNetworkPeerScoreTargetLatency = 500 * time.Millisecond
 )
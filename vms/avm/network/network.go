@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -45,6 +46,7 @@ func New(
 	nodeID ids.NodeID,
 	subnetID ids.ID,
 	vdrs validators.State,
+	peerScores tracker.PeerScores,
 	parser txs.Parser,
 	txVerifier TxVerifier,
 	mempool mempool.Mempool,
@@ -70,6 +72,7 @@ func New(
 	txGossipClient := p2pNetwork.NewClient(
 		txGossipHandlerID,
 		p2p.WithValidatorSampling(validators),
+		p2p.WithPeerScores(peerScores),
 	)
 	txGossipMetrics, err := gossip.NewMetrics(registerer, "tx")
 	if err != nil {
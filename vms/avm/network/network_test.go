@@ -184,6 +184,7 @@ func TestNetworkIssueTxFromRPC(t *testing.T) {
 						return nil, nil
 					},
 				},
+				nil,
 				parser,
 				txVerifierFunc(ctrl),
 				mempoolFunc(ctrl),
@@ -278,6 +279,7 @@ func TestNetworkIssueTxFromRPCWithoutVerification(t *testing.T) {
 						return nil, nil
 					},
 				},
+				nil,
 				parser,
 				executor.NewMockManager(ctrl), // Should never verify a tx
 				mempoolFunc(ctrl),
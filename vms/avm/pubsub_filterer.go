@@ -4,13 +4,19 @@
 package avm
 
 import (
+	"fmt"
+
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 )
 
-var _ pubsub.Filterer = (*connector)(nil)
+var (
+	_ pubsub.Filterer       = (*connector)(nil)
+	_ pubsub.CursorFilterer = (*cursorConnector)(nil)
+	_ pubsub.Replayer       = (*pubsubReplayer)(nil)
+)
 
 type connector struct {
 	tx *txs.Tx
@@ -42,3 +48,64 @@ func (f *connector) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
 		TxID: f.tx.ID(),
 	}
 }
+
+// cursorConnector is the filterer of a transaction that was accepted in a
+// block. The cursor of the transaction is the height of its block.
+type cursorConnector struct {
+	connector
+	height uint64
+}
+
+func newCursorPubSubFilterer(tx *txs.Tx, height uint64) pubsub.Filterer {
+	return &cursorConnector{
+		connector: connector{tx: tx},
+		height:    height,
+	}
+}
+
+func (f *cursorConnector) Cursor() uint64 {
+	return f.height
+}
+
+// pubsubReplayer replays the transactions accepted in blocks. Transactions
+// accepted before the chain was linearized don't have a cursor and can't be
+// replayed.
+type pubsubReplayer struct {
+	vm *VM
+}
+
+func (r *pubsubReplayer) Replay(start uint64, limit uint64) ([]pubsub.Filterer, uint64, error) {
+	r.vm.ctx.Lock.Lock()
+	defer r.vm.ctx.Lock.Unlock()
+
+	if r.vm.chainManager == nil {
+		// No blocks have been accepted before the chain is linearized.
+		return nil, start, nil
+	}
+
+	// The genesis block doesn't contain any transactions.
+	start = max(start, 1)
+
+	lastAcceptedID := r.vm.state.GetLastAccepted()
+	lastAccepted, err := r.vm.state.GetBlock(lastAcceptedID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	end := max(min(lastAccepted.Height()+1, start+limit), start)
+
+	var filterers []pubsub.Filterer
+	for height := start; height < end; height++ {
+		blkID, err := r.vm.state.GetBlockIDAtHeight(height)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+		}
+		blk, err := r.vm.state.GetBlock(blkID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+		for _, tx := range blk.Txs() {
+			filterers = append(filterers, newCursorPubSubFilterer(tx, height))
+		}
+	}
+	return filterers, end, nil
+}
@@ -5,12 +5,15 @@ package avm
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/avm/config"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -48,3 +51,62 @@ func TestFilter(t *testing.T) {
 	fr, _ := parser.Filter([]pubsub.Filter{&mockFilter{addr: addrBytes}})
 	require.Equal([]bool{true}, fr)
 }
+
+func TestPubSubReplayer(t *testing.T) {
+	require := require.New(t)
+
+	env := setup(t, &envConfig{
+		vmStaticConfig: &config.Config{},
+	})
+	env.vm.ctx.Lock.Unlock()
+	defer func() {
+		env.vm.ctx.Lock.Lock()
+		require.NoError(env.vm.Shutdown(context.Background()))
+		env.vm.ctx.Lock.Unlock()
+	}()
+
+	key := keys[0]
+	addr := key.PublicKey().Address()
+	txAssetID := avax.Asset{ID: env.genesisTx.ID()}
+
+	var txIDs []ids.ID
+	for i := 0; i < 2; i++ {
+		utxoID := avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		}
+		env.vm.ctx.Lock.Lock()
+		env.vm.state.AddUTXO(buildUTXO(utxoID, txAssetID, addr))
+		env.vm.ctx.Lock.Unlock()
+
+		tx := buildTX(env.vm.ctx.XChainID, utxoID, txAssetID, addr)
+		require.NoError(tx.SignSECP256K1Fx(env.vm.parser.Codec(), [][]*secp256k1.PrivateKey{{key}}))
+		issueAndAccept(require, env.vm, env.issuer, tx)
+		txIDs = append(txIDs, tx.ID())
+	}
+
+	// The genesis block isn't replayed, so the first transaction was accepted
+	// at height 1.
+	replayer := &pubsubReplayer{vm: env.vm}
+	filterers, next, err := replayer.Replay(0, 10)
+	require.NoError(err)
+	require.Equal(uint64(3), next)
+	require.Len(filterers, 2)
+	for i, filterer := range filterers {
+		cursorFilterer := filterer.(*cursorConnector)
+		require.Equal(txIDs[i], cursorFilterer.tx.ID())
+		require.Equal(uint64(i+1), cursorFilterer.Cursor())
+	}
+
+	// Every accepted block has been replayed.
+	filterers, next, err = replayer.Replay(next, 10)
+	require.NoError(err)
+	require.Equal(uint64(3), next)
+	require.Empty(filterers)
+
+	// The next accepted transaction is published with the next cursor.
+	env.vm.ctx.Lock.Lock()
+	filterer, err := env.vm.newPubSubFilterer(&txs.Tx{Unsigned: &txs.BaseTx{}})
+	env.vm.ctx.Lock.Unlock()
+	require.NoError(err)
+	require.Equal(next, filterer.(pubsub.CursorFilterer).Cursor())
+}
@@ -196,7 +196,7 @@ func (vm *VM) Initialize(
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
 
-	vm.pubsub = pubsub.New(ctx.Log)
+	vm.pubsub = pubsub.NewReplayable(ctx.Log, &pubsubReplayer{vm: vm})
 
 	typedFxs := make([]extensions.Fx, len(fxs))
 	vm.fxs = make([]*extensions.ParsedFx, len(fxs))
@@ -434,6 +434,7 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.ctx.NodeID,
 		vm.ctx.SubnetID,
 		vm.ctx.ValidatorState,
+		vm.ctx.PeerScores,
 		vm.parser,
 		network.NewLockedTxVerifier(
 			&vm.ctx.Lock,
@@ -637,6 +638,22 @@ func (vm *VM) selectChangeAddr(defaultAddr ids.ShortID, changeAddr string) (ids.
 	return addr, nil
 }
 
+// newPubSubFilterer returns the filterer that the acceptance of [tx] is
+// published with. Once the chain is linearized, [tx] is being accepted in the
+// block after the last accepted block, whose height is used as the cursor.
+func (vm *VM) newPubSubFilterer(tx *txs.Tx) (pubsub.Filterer, error) {
+	if vm.chainManager == nil {
+		return NewPubSubFilterer(tx), nil
+	}
+
+	lastAcceptedID := vm.state.GetLastAccepted()
+	lastAccepted, err := vm.state.GetBlock(lastAcceptedID)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	return newCursorPubSubFilterer(tx, lastAccepted.Height()+1), nil
+}
+
 // lookupAssetID looks for an ID aliased by [asset] and if it fails
 // attempts to parse [asset] into an ID
 func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
@@ -687,7 +704,11 @@ func (vm *VM) onAccept(tx *txs.Tx) error {
 		return fmt.Errorf("error indexing tx: %w", err)
 	}
 
-	vm.pubsub.Publish(NewPubSubFilterer(tx))
+	filterer, err := vm.newPubSubFilterer(tx)
+	if err != nil {
+		return err
+	}
+	vm.pubsub.Publish(filterer)
 	vm.walletService.decided(txID)
 	return nil
 }
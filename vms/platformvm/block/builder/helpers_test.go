@@ -193,6 +193,7 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		res.state,
 		&res.backend,
 		pvalidators.TestManager,
+		nil,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&res.ctx.Lock, res.blkManager)
@@ -201,6 +202,7 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		res.backend.Ctx.NodeID,
 		res.backend.Ctx.SubnetID,
 		res.backend.Ctx.ValidatorState,
+		res.backend.Ctx.PeerScores,
 		txVerifier,
 		res.mempool,
 		res.backend.Config.PartialSyncPrimaryNetwork,
@@ -30,6 +30,9 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+
+	// If non-nil, called with each block after it has been committed.
+	onAccepted func(block.Block)
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -104,6 +107,8 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
+	a.notifyAccepted(b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", "apricot atomic"),
@@ -173,6 +178,8 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	a.notifyAccepted(parentState.statelessBlock, b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -252,6 +259,8 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	a.notifyAccepted(b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -264,6 +273,16 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// notifyAccepted calls [a.onAccepted] with each of [blks], if it's set.
+func (a *acceptor) notifyAccepted(blks ...block.Block) {
+	if a.onAccepted == nil {
+		return
+	}
+	for _, blk := range blks {
+		a.onAccepted(blk)
+	}
+}
+
 func (a *acceptor) commonAccept(b block.Block) error {
 	blkID := b.ID()
 
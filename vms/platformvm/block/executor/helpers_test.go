@@ -214,6 +214,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			res.state,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		addSubnet(res)
 	} else {
@@ -223,6 +224,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f fork) *environment
 			res.mockedState,
 			res.backend,
 			pvalidators.TestManager,
+			nil,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
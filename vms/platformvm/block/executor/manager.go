@@ -52,6 +52,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	onAccepted func(block.Block),
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -73,6 +74,7 @@ func NewManager(
 			metrics:      metrics,
 			validators:   validatorManager,
 			bootstrapped: txExecutorBackend.Bootstrapped,
+			onAccepted:   onAccepted,
 		},
 		rejector: &rejector{
 			backend:         backend,
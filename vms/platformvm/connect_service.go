@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/platform/platformconnect"
+
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
+)
+
+var _ platformconnect.PlatformHandler = (*connectService)(nil)
+
+// connectService serves the read methods of the platform API over gRPC,
+// gRPC-Web and Connect.
+type connectService struct {
+	service *Service
+}
+
+func (s *connectService) GetHeight(
+	ctx context.Context,
+	_ *connect.Request[emptypb.Empty],
+) (*connect.Response[platformpb.GetHeightResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getHeight"),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	height, err := vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&platformpb.GetHeightResponse{
+		Height: height,
+	}), nil
+}
+
+func (s *connectService) GetTimestamp(
+	context.Context,
+	*connect.Request[emptypb.Empty],
+) (*connect.Response[platformpb.GetTimestampResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTimestamp"),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	return connect.NewResponse(&platformpb.GetTimestampResponse{
+		Timestamp: timestamppb.New(vm.state.GetTimestamp()),
+	}), nil
+}
+
+func (s *connectService) GetCurrentSupply(
+	ctx context.Context,
+	req *connect.Request[platformpb.GetCurrentSupplyRequest],
+) (*connect.Response[platformpb.GetCurrentSupplyResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCurrentSupply"),
+	)
+
+	subnetID, err := toOptionalID(req.Msg.SubnetId)
+	if err != nil {
+		return nil, err
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	supply, err := vm.state.GetCurrentSupply(subnetID)
+	if err != nil {
+		return nil, connectError(fmt.Errorf("fetching current supply failed: %w", err))
+	}
+	height, err := vm.GetCurrentHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching current height failed: %w", err)
+	}
+	return connect.NewResponse(&platformpb.GetCurrentSupplyResponse{
+		Supply: supply,
+		Height: height,
+	}), nil
+}
+
+func (s *connectService) GetBlock(
+	_ context.Context,
+	req *connect.Request[platformpb.GetBlockRequest],
+) (*connect.Response[platformpb.GetBlockResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlock"),
+	)
+
+	blockID, err := ids.ToID(req.Msg.BlockId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	block, err := vm.manager.GetStatelessBlock(blockID)
+	if err != nil {
+		return nil, connectError(fmt.Errorf("couldn't get block with id %s: %w", blockID, err))
+	}
+	return connect.NewResponse(&platformpb.GetBlockResponse{
+		Block: block.Bytes(),
+	}), nil
+}
+
+func (s *connectService) GetBlockByHeight(
+	_ context.Context,
+	req *connect.Request[platformpb.GetBlockByHeightRequest],
+) (*connect.Response[platformpb.GetBlockByHeightResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getBlockByHeight"),
+		zap.Uint64("height", req.Msg.Height),
+	)
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	blockID, err := vm.state.GetBlockIDAtHeight(req.Msg.Height)
+	if err != nil {
+		return nil, connectError(fmt.Errorf("couldn't get block at height %d: %w", req.Msg.Height, err))
+	}
+	block, err := vm.manager.GetStatelessBlock(blockID)
+	if err != nil {
+		vm.ctx.Log.Error("couldn't get accepted block",
+			zap.Stringer("blkID", blockID),
+			zap.Error(err),
+		)
+		return nil, fmt.Errorf("couldn't get block with id %s: %w", blockID, err)
+	}
+	return connect.NewResponse(&platformpb.GetBlockByHeightResponse{
+		BlockId: blockID[:],
+		Block:   block.Bytes(),
+	}), nil
+}
+
+func (s *connectService) GetTx(
+	_ context.Context,
+	req *connect.Request[platformpb.GetTxRequest],
+) (*connect.Response[platformpb.GetTxResponse], error) {
+	vm := s.service.vm
+	vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getTx"),
+	)
+
+	txID, err := ids.ToID(req.Msg.TxId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	tx, _, err := vm.state.GetTx(txID)
+	if err != nil {
+		return nil, connectError(fmt.Errorf("couldn't get tx: %w", err))
+	}
+	return connect.NewResponse(&platformpb.GetTxResponse{
+		Tx: tx.Bytes(),
+	}), nil
+}
+
+func (s *connectService) GetTxStatus(
+	_ context.Context,
+	req *connect.Request[platformpb.GetTxStatusRequest],
+) (*connect.Response[platformpb.GetTxStatusResponse], error) {
+	txID, err := ids.ToID(req.Msg.TxId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var reply GetTxStatusResponse
+	err = s.service.GetTxStatus(
+		nil,
+		&GetTxStatusArgs{
+			TxID: txID,
+		},
+		&reply,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&platformpb.GetTxStatusResponse{
+		Status: reply.Status.String(),
+		Reason: reply.Reason,
+	}), nil
+}
+
+// toOptionalID parses [id], which defaults to the empty ID if it isn't
+// provided.
+func toOptionalID(id []byte) (ids.ID, error) {
+	if len(id) == 0 {
+		return ids.Empty, nil
+	}
+	parsed, err := ids.ToID(id)
+	if err != nil {
+		return ids.Empty, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return parsed, nil
+}
+
+// connectError reports [err] as NotFound if it was caused by a missing value.
+func connectError(err error) error {
+	if errors.Is(err, database.ErrNotFound) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/platform/platformconnect"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+
+	platformpb "github.com/ava-labs/avalanchego/proto/pb/platform"
+)
+
+func newConnectClient(t *testing.T, service *Service) platformconnect.PlatformClient {
+	path, handler := platformconnect.NewPlatformHandler(&connectService{service: service})
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return platformconnect.NewPlatformClient(server.Client(), server.URL)
+}
+
+func TestConnectService(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	service, _ := defaultService(t)
+	client := newConnectClient(t, service)
+
+	service.vm.ctx.Lock.Lock()
+	lastAcceptedID := service.vm.manager.LastAccepted()
+	timestamp := service.vm.state.GetTimestamp()
+	service.vm.ctx.Lock.Unlock()
+
+	heightResponse, err := client.GetHeight(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(err)
+	require.Equal(uint64(1), heightResponse.Msg.Height)
+
+	timestampResponse, err := client.GetTimestamp(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(err)
+	require.Equal(timestamp.Unix(), timestampResponse.Msg.Timestamp.AsTime().Unix())
+
+	supplyResponse, err := client.GetCurrentSupply(ctx, connect.NewRequest(&platformpb.GetCurrentSupplyRequest{}))
+	require.NoError(err)
+	require.Positive(supplyResponse.Msg.Supply)
+	require.Equal(uint64(1), supplyResponse.Msg.Height)
+
+	// The first block created [testSubnet1].
+	blockByHeightResponse, err := client.GetBlockByHeight(ctx, connect.NewRequest(&platformpb.GetBlockByHeightRequest{
+		Height: 1,
+	}))
+	require.NoError(err)
+	require.Equal(lastAcceptedID[:], blockByHeightResponse.Msg.BlockId)
+
+	blockResponse, err := client.GetBlock(ctx, connect.NewRequest(&platformpb.GetBlockRequest{
+		BlockId: lastAcceptedID[:],
+	}))
+	require.NoError(err)
+	require.Equal(blockByHeightResponse.Msg.Block, blockResponse.Msg.Block)
+
+	txID := testSubnet1.ID()
+	txResponse, err := client.GetTx(ctx, connect.NewRequest(&platformpb.GetTxRequest{
+		TxId: txID[:],
+	}))
+	require.NoError(err)
+	require.Equal(testSubnet1.Bytes(), txResponse.Msg.Tx)
+
+	txStatusResponse, err := client.GetTxStatus(ctx, connect.NewRequest(&platformpb.GetTxStatusRequest{
+		TxId: txID[:],
+	}))
+	require.NoError(err)
+	require.Equal(status.Committed.String(), txStatusResponse.Msg.Status)
+}
+
+func TestConnectServiceErrors(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	service, _ := defaultService(t)
+	client := newConnectClient(t, service)
+
+	_, err := client.GetTx(ctx, connect.NewRequest(&platformpb.GetTxRequest{
+		TxId: []byte{1, 2, 3},
+	}))
+	require.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	unknownID := ids.GenerateTestID()
+	_, err = client.GetTx(ctx, connect.NewRequest(&platformpb.GetTxRequest{
+		TxId: unknownID[:],
+	}))
+	require.Equal(connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = client.GetBlock(ctx, connect.NewRequest(&platformpb.GetBlockRequest{
+		BlockId: unknownID[:],
+	}))
+	require.Equal(connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = client.GetBlockByHeight(ctx, connect.NewRequest(&platformpb.GetBlockByHeightRequest{
+		Height: 100,
+	}))
+	require.Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
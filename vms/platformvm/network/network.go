@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
@@ -45,6 +46,7 @@ func New(
 	nodeID ids.NodeID,
 	subnetID ids.ID,
 	vdrs validators.State,
+	peerScores tracker.PeerScores,
 	txVerifier TxVerifier,
 	mempool mempool.Mempool,
 	partialSyncPrimaryNetwork bool,
@@ -68,6 +70,7 @@ func New(
 	txGossipClient := p2pNetwork.NewClient(
 		TxGossipHandlerID,
 		p2p.WithValidatorSampling(validators),
+		p2p.WithPeerScores(peerScores),
 	)
 	txGossipMetrics, err := gossip.NewMetrics(registerer, "tx")
 	if err != nil {
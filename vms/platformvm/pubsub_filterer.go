@@ -0,0 +1,359 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// blockEventType is the type of the event published when a block is accepted.
+const blockEventType = "block"
+
+var (
+	_ pubsub.TypedFilterer  = (*blockFilterer)(nil)
+	_ pubsub.CursorFilterer = (*blockFilterer)(nil)
+	_ pubsub.TypedFilterer  = (*txFilterer)(nil)
+	_ pubsub.CursorFilterer = (*txFilterer)(nil)
+	_ pubsub.Replayer       = (*pubsubReplayer)(nil)
+	_ txs.Visitor           = (*txEvent)(nil)
+)
+
+// BlockEvent is the message published when a block is accepted.
+type BlockEvent struct {
+	Type    string      `json:"type"`
+	BlockID ids.ID      `json:"blockID"`
+	Height  json.Uint64 `json:"height"`
+	TxIDs   []ids.ID    `json:"txIDs"`
+}
+
+// TxEvent is the message published when a transaction is accepted.
+type TxEvent struct {
+	// Type is the name of the transaction type, e.g. "AddValidatorTx".
+	Type    string      `json:"type"`
+	TxID    ids.ID      `json:"txID"`
+	BlockID ids.ID      `json:"blockID"`
+	Height  json.Uint64 `json:"height"`
+}
+
+// blockFilterer passes connections that are passed by any of the block's
+// transactions.
+type blockFilterer struct {
+	blk block.Block
+	txs []*txFilterer
+}
+
+func newBlockFilterer(blk block.Block, chainState state.State) *blockFilterer {
+	blkTxs := blk.Txs()
+	f := &blockFilterer{
+		blk: blk,
+		txs: make([]*txFilterer, len(blkTxs)),
+	}
+	for i, tx := range blkTxs {
+		f.txs[i] = &txFilterer{
+			blk:   blk,
+			tx:    tx,
+			state: chainState,
+		}
+	}
+	return f
+}
+
+func (f *blockFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	resp := make([]bool, len(filters))
+	txIDs := make([]ids.ID, len(f.txs))
+	for i, tx := range f.txs {
+		txIDs[i] = tx.tx.ID()
+		txResp, _ := tx.Filter(filters)
+		for j, shouldNotify := range txResp {
+			resp[j] = resp[j] || shouldNotify
+		}
+	}
+	return resp, BlockEvent{
+		Type:    blockEventType,
+		BlockID: f.blk.ID(),
+		Height:  json.Uint64(f.blk.Height()),
+		TxIDs:   txIDs,
+	}
+}
+
+func (*blockFilterer) Type() string {
+	return blockEventType
+}
+
+func (f *blockFilterer) Cursor() uint64 {
+	return f.blk.Height()
+}
+
+// txFilterer passes connections that added any address that the transaction
+// sends funds or ownership to, or any subnet that the transaction modifies.
+type txFilterer struct {
+	blk   block.Block
+	tx    *txs.Tx
+	state state.State
+
+	// Populated by [getEvent] on first use.
+	event *txEvent
+}
+
+func (f *txFilterer) Filter(filters []pubsub.Filter) ([]bool, interface{}) {
+	event := f.getEvent()
+	resp := make([]bool, len(filters))
+	for i, c := range filters {
+		for _, subnetID := range event.subnetIDs {
+			if resp[i] {
+				break
+			}
+			resp[i] = c.Check(subnetID[:])
+		}
+		for _, addr := range event.addresses {
+			if resp[i] {
+				break
+			}
+			resp[i] = c.Check(addr)
+		}
+	}
+	return resp, TxEvent{
+		Type:    event.typ,
+		TxID:    f.tx.ID(),
+		BlockID: f.blk.ID(),
+		Height:  json.Uint64(f.blk.Height()),
+	}
+}
+
+func (f *txFilterer) Type() string {
+	return f.getEvent().typ
+}
+
+func (f *txFilterer) Cursor() uint64 {
+	return f.blk.Height()
+}
+
+func (f *txFilterer) getEvent() *txEvent {
+	if f.event != nil {
+		return f.event
+	}
+
+	f.event = &txEvent{
+		state: f.state,
+		txID:  f.tx.ID(),
+	}
+	for _, utxo := range f.tx.UTXOs() {
+		f.event.addOutput(utxo.Out)
+	}
+	// The visitor only fails if the transaction references state that
+	// couldn't be read. In that case, the event still has the transaction's
+	// type and outputs.
+	_ = f.tx.Unsigned.Visit(f.event)
+	return f.event
+}
+
+// pubsubReplayer replays the blocks and transactions accepted by the VM. The
+// cursor of an event is the height of its block.
+type pubsubReplayer struct {
+	vm *VM
+}
+
+func (r *pubsubReplayer) Replay(start uint64, limit uint64) ([]pubsub.Filterer, uint64, error) {
+	r.vm.ctx.Lock.Lock()
+	defer r.vm.ctx.Lock.Unlock()
+
+	// The genesis block is never published.
+	start = max(start, 1)
+
+	lastAcceptedID := r.vm.state.GetLastAccepted()
+	lastAccepted, err := r.vm.state.GetStatelessBlock(lastAcceptedID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("couldn't get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	end := max(min(lastAccepted.Height()+1, start+limit), start)
+
+	var filterers []pubsub.Filterer
+	for height := start; height < end; height++ {
+		blkID, err := r.vm.state.GetBlockIDAtHeight(height)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get block ID at height %d: %w", height, err)
+		}
+		blk, err := r.vm.state.GetStatelessBlock(blkID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get block %s: %w", blkID, err)
+		}
+
+		blkFilterer := newBlockFilterer(blk, r.vm.state)
+		for _, txFilterer := range blkFilterer.txs {
+			// The event reads from the state, so it must be built while the
+			// lock is held.
+			txFilterer.getEvent()
+			filterers = append(filterers, txFilterer)
+		}
+		filterers = append(filterers, blkFilterer)
+	}
+	return filterers, end, nil
+}
+
+// txEvent collects the type of a transaction, the subnets it modifies, and
+// the addresses it sends funds or ownership to.
+type txEvent struct {
+	state state.State
+	txID  ids.ID
+
+	typ       string
+	subnetIDs []ids.ID
+	addresses [][]byte
+}
+
+func (e *txEvent) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	e.typ = "AddValidatorTx"
+	e.subnetIDs = append(e.subnetIDs, constants.PrimaryNetworkID)
+	e.addTransferableOutputs(tx.StakeOuts)
+	e.addOwner(tx.RewardsOwner)
+	return nil
+}
+
+func (e *txEvent) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	e.typ = "AddSubnetValidatorTx"
+	e.subnetIDs = append(e.subnetIDs, tx.SubnetValidator.Subnet)
+	return nil
+}
+
+func (e *txEvent) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	e.typ = "AddDelegatorTx"
+	e.subnetIDs = append(e.subnetIDs, constants.PrimaryNetworkID)
+	e.addTransferableOutputs(tx.StakeOuts)
+	e.addOwner(tx.DelegationRewardsOwner)
+	return nil
+}
+
+func (e *txEvent) CreateChainTx(tx *txs.CreateChainTx) error {
+	e.typ = "CreateChainTx"
+	e.subnetIDs = append(e.subnetIDs, tx.SubnetID)
+	return nil
+}
+
+func (e *txEvent) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	e.typ = "CreateSubnetTx"
+	// The ID of a subnet is the ID of the transaction that created it.
+	e.subnetIDs = append(e.subnetIDs, e.txID)
+	e.addOwner(tx.Owner)
+	return nil
+}
+
+func (e *txEvent) ImportTx(*txs.ImportTx) error {
+	e.typ = "ImportTx"
+	return nil
+}
+
+func (e *txEvent) ExportTx(tx *txs.ExportTx) error {
+	e.typ = "ExportTx"
+	e.addTransferableOutputs(tx.ExportedOutputs)
+	return nil
+}
+
+func (e *txEvent) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	e.typ = "AdvanceTimeTx"
+	return nil
+}
+
+func (e *txEvent) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
+	// The type is set after visiting the staker transaction so that it isn't
+	// overwritten.
+	defer func() {
+		e.typ = "RewardValidatorTx"
+	}()
+
+	// The reward UTXOs are sent to the reward owners of the staker.
+	rewardUTXOs, err := e.state.GetRewardUTXOs(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward UTXOs of %s: %w", tx.TxID, err)
+	}
+	for _, utxo := range rewardUTXOs {
+		e.addOutput(utxo.Out)
+	}
+
+	// The staker is removed from its subnet and its stake is returned.
+	stakerTx, _, err := e.state.GetTx(tx.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get staker tx %s: %w", tx.TxID, err)
+	}
+	stakerEvent := &txEvent{
+		state: e.state,
+		txID:  tx.TxID,
+	}
+	if err := stakerTx.Unsigned.Visit(stakerEvent); err != nil {
+		return err
+	}
+	e.subnetIDs = append(e.subnetIDs, stakerEvent.subnetIDs...)
+	e.addresses = append(e.addresses, stakerEvent.addresses...)
+	return nil
+}
+
+func (e *txEvent) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	e.typ = "RemoveSubnetValidatorTx"
+	e.subnetIDs = append(e.subnetIDs, tx.Subnet)
+	return nil
+}
+
+func (e *txEvent) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	e.typ = "TransformSubnetTx"
+	e.subnetIDs = append(e.subnetIDs, tx.Subnet)
+	return nil
+}
+
+func (e *txEvent) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	e.typ = "AddPermissionlessValidatorTx"
+	e.subnetIDs = append(e.subnetIDs, tx.Subnet)
+	e.addTransferableOutputs(tx.StakeOuts)
+	e.addOwner(tx.ValidatorRewardsOwner)
+	e.addOwner(tx.DelegatorRewardsOwner)
+	return nil
+}
+
+func (e *txEvent) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	e.typ = "AddPermissionlessDelegatorTx"
+	e.subnetIDs = append(e.subnetIDs, tx.Subnet)
+	e.addTransferableOutputs(tx.StakeOuts)
+	e.addOwner(tx.DelegationRewardsOwner)
+	return nil
+}
+
+func (e *txEvent) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	e.typ = "TransferSubnetOwnershipTx"
+	e.subnetIDs = append(e.subnetIDs, tx.Subnet)
+	e.addOwner(tx.Owner)
+	return nil
+}
+
+func (e *txEvent) BaseTx(*txs.BaseTx) error {
+	e.typ = "BaseTx"
+	return nil
+}
+
+func (e *txEvent) addTransferableOutputs(outs []*avax.TransferableOutput) {
+	for _, out := range outs {
+		e.addOutput(out.Out)
+	}
+}
+
+func (e *txEvent) addOutput(out interface{}) {
+	if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		out = lockedOut.TransferableOut
+	}
+	if addressable, ok := out.(avax.Addressable); ok {
+		e.addresses = append(e.addresses, addressable.Addresses()...)
+	}
+}
+
+func (e *txEvent) addOwner(owner fx.Owner) {
+	e.addOutput(owner)
+}
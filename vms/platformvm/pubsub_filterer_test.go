@@ -0,0 +1,153 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/pubsub"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+type mockFilter struct {
+	addr []byte
+}
+
+func (f *mockFilter) Check(addr []byte) bool {
+	return bytes.Equal(addr, f.addr)
+}
+
+func TestPubSubFilterer(t *testing.T) {
+	require := require.New(t)
+
+	addrID := ids.ShortID{1}
+	subnetID := ids.ID{2}
+	otherID := ids.ID{3}
+
+	createSubnetTx, err := txs.NewSigned(&txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{
+				{
+					Out: &secp256k1fx.TransferOutput{
+						OutputOwners: secp256k1fx.OutputOwners{
+							Addrs: []ids.ShortID{addrID},
+						},
+					},
+				},
+			},
+		}},
+		Owner: &secp256k1fx.OutputOwners{},
+	}, txs.Codec, nil)
+	require.NoError(err)
+
+	createChainTx, err := txs.NewSigned(&txs.CreateChainTx{
+		SubnetID:   subnetID,
+		SubnetAuth: &secp256k1fx.Input{},
+	}, txs.Codec, nil)
+	require.NoError(err)
+
+	blk, err := block.NewBanffStandardBlock(
+		time.Unix(0, 0),
+		ids.GenerateTestID(),
+		1,
+		[]*txs.Tx{createSubnetTx, createChainTx},
+	)
+	require.NoError(err)
+
+	filters := []pubsub.Filter{
+		&mockFilter{addr: addrID[:]},
+		&mockFilter{addr: subnetID[:]},
+		&mockFilter{addr: otherID[:]},
+	}
+
+	blkFilterer := newBlockFilterer(blk, nil)
+	require.Len(blkFilterer.txs, 2)
+
+	tests := []struct {
+		name             string
+		filterer         pubsub.TypedFilterer
+		expectedType     string
+		expectedToNotify []bool
+		expectedMsg      interface{}
+	}{
+		{
+			name:             "create subnet tx",
+			filterer:         blkFilterer.txs[0],
+			expectedType:     "CreateSubnetTx",
+			expectedToNotify: []bool{true, false, false},
+			expectedMsg: TxEvent{
+				Type:    "CreateSubnetTx",
+				TxID:    createSubnetTx.ID(),
+				BlockID: blk.ID(),
+				Height:  1,
+			},
+		},
+		{
+			name:             "create chain tx",
+			filterer:         blkFilterer.txs[1],
+			expectedType:     "CreateChainTx",
+			expectedToNotify: []bool{false, true, false},
+			expectedMsg: TxEvent{
+				Type:    "CreateChainTx",
+				TxID:    createChainTx.ID(),
+				BlockID: blk.ID(),
+				Height:  1,
+			},
+		},
+		{
+			name:             "block",
+			filterer:         blkFilterer,
+			expectedType:     blockEventType,
+			expectedToNotify: []bool{true, true, false},
+			expectedMsg: BlockEvent{
+				Type:    blockEventType,
+				BlockID: blk.ID(),
+				Height:  1,
+				TxIDs:   []ids.ID{createSubnetTx.ID(), createChainTx.ID()},
+			},
+		},
+	}
+	for _, test := range tests {
+		toNotify, msg := test.filterer.Filter(filters)
+		require.Equal(test.expectedToNotify, toNotify, test.name)
+		require.Equal(test.expectedMsg, msg, test.name)
+		require.Equal(test.expectedType, test.filterer.Type(), test.name)
+	}
+}
+
+func TestPubSubReplayer(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+
+	// The genesis block isn't replayed, so the first block is the one that
+	// created [testSubnet1].
+	replayer := &pubsubReplayer{vm: vm}
+	filterers, next, err := replayer.Replay(0, 10)
+	require.NoError(err)
+	require.Equal(uint64(2), next)
+	require.Len(filterers, 2)
+
+	txFilterer := filterers[0].(*txFilterer)
+	require.Equal(testSubnet1.ID(), txFilterer.tx.ID())
+	require.Equal(uint64(1), txFilterer.Cursor())
+	require.Equal("CreateSubnetTx", txFilterer.Type())
+
+	blkFilterer := filterers[1].(*blockFilterer)
+	require.Equal(vm.manager.LastAccepted(), blkFilterer.blk.ID())
+	require.Equal(uint64(1), blkFilterer.Cursor())
+
+	// Every accepted block has been replayed.
+	filterers, next, err = replayer.Replay(next, 10)
+	require.NoError(err)
+	require.Equal(uint64(2), next)
+	require.Empty(filterers)
+}
@@ -16,10 +16,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/proto/pb/platform/platformconnect"
+	"github.com/ava-labs/avalanchego/pubsub"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -57,6 +60,7 @@ var (
 	_ secp256k1fx.VM             = (*VM)(nil)
 	_ validators.State           = (*VM)(nil)
 	_ validators.SubnetConnector = (*VM)(nil)
+	_ chains.SubnetTracker       = (*VM)(nil)
 )
 
 type VM struct {
@@ -85,9 +89,16 @@ type VM struct {
 	// Bootstrapped remembers if this chain has finished bootstrapping or not
 	bootstrapped utils.Atomic[bool]
 
+	// Loggers of the validator changes of the tracked subnets, which are
+	// deregistered when their subnet is untracked.
+	subnetValidatorLoggers map[ids.ID]validators.SetCallbackListener
+
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
 
+	// Publishes accepted blocks and transactions to websocket connections
+	pubsub *pubsub.Server
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -127,6 +138,7 @@ func (vm *VM) Initialize(
 	}
 
 	vm.ctx = chainCtx
+	vm.subnetValidatorLoggers = make(map[ids.ID]validators.SetCallbackListener)
 	vm.db = db
 
 	// Note: this codec is never used to serialize anything
@@ -185,12 +197,14 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
+	vm.pubsub = pubsub.NewReplayable(chainCtx.Log, &pubsubReplayer{vm: vm})
 	vm.manager = blockexecutor.NewManager(
 		mempool,
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		vm.publishBlock,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
@@ -202,6 +216,7 @@ func (vm *VM) Initialize(
 			&chainCtx.Lock,
 			validatorManager,
 		),
+		chainCtx.PeerScores,
 		txVerifier,
 		mempool,
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
@@ -232,6 +247,7 @@ func (vm *VM) Initialize(
 			err,
 		)
 	}
+	vm.Chains.SetSubnetTracker(vm)
 
 	lastAcceptedID := vm.state.GetLastAccepted()
 	chainCtx.Log.Info("initializing last accepted",
@@ -335,6 +351,68 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 	return nil
 }
 
+// TrackSubnet starts tracking the uptimes of the validators of [subnetID] and
+// creates the chains of [subnetID].
+func (vm *VM) TrackSubnet(subnetID ids.ID) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.TrackedSubnets.Contains(subnetID) {
+		return nil
+	}
+	vm.TrackedSubnets.Add(subnetID)
+
+	if vm.bootstrapped.Get() {
+		vdrIDs := vm.Validators.GetValidatorIDs(subnetID)
+		if err := vm.uptimeManager.StartTracking(vdrIDs, subnetID); err != nil {
+			return err
+		}
+
+		vm.registerValidatorLogger(subnetID)
+
+		if err := vm.state.Commit(); err != nil {
+			return err
+		}
+	}
+	return vm.createSubnet(subnetID)
+}
+
+// UntrackSubnet stops tracking the uptimes of the validators of [subnetID] and
+// stops creating the chains of [subnetID].
+func (vm *VM) UntrackSubnet(subnetID ids.ID) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if !vm.TrackedSubnets.Contains(subnetID) {
+		return nil
+	}
+	vm.TrackedSubnets.Remove(subnetID)
+
+	if !vm.bootstrapped.Get() {
+		return nil
+	}
+
+	if vl, ok := vm.subnetValidatorLoggers[subnetID]; ok {
+		vm.Validators.DeregisterCallbackListener(subnetID, vl)
+		delete(vm.subnetValidatorLoggers, subnetID)
+	}
+
+	vdrIDs := vm.Validators.GetValidatorIDs(subnetID)
+	if err := vm.uptimeManager.StopTracking(vdrIDs, subnetID); err != nil {
+		return err
+	}
+	return vm.state.Commit()
+}
+
+// registerValidatorLogger logs the validator changes of [subnetID].
+//
+// Assumes [vm.ctx.Lock] is held.
+func (vm *VM) registerValidatorLogger(subnetID ids.ID) {
+	vl := validators.NewLogger(vm.ctx.Log, subnetID, vm.ctx.NodeID)
+	vm.Validators.RegisterCallbackListener(subnetID, vl)
+	vm.subnetValidatorLoggers[subnetID] = vl
+}
+
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
 	vm.bootstrapped.Set(false)
@@ -366,8 +444,7 @@ func (vm *VM) onNormalOperationsStarted() error {
 			return err
 		}
 
-		vl := validators.NewLogger(vm.ctx.Log, subnetID, vm.ctx.NodeID)
-		vm.Validators.RegisterCallbackListener(subnetID, vl)
+		vm.registerValidatorLogger(subnetID)
 	}
 
 	if err := vm.state.Commit(); err != nil {
@@ -470,10 +547,27 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 			Size: stakerAttributesCacheSize,
 		},
 	}
-	err := server.RegisterService(service, "platform")
+	if err := server.RegisterService(service, "platform"); err != nil {
+		return nil, err
+	}
+	connectPath, connectHandler := platformconnect.NewPlatformHandler(&connectService{
+		service: service,
+	})
 	return map[string]http.Handler{
-		"": server,
-	}, err
+		"":          server,
+		"/events":   vm.pubsub,
+		connectPath: connectHandler,
+	}, nil
+}
+
+// publishBlock publishes the accepted block [blk] and each of its
+// transactions.
+func (vm *VM) publishBlock(blk block.Block) {
+	blkFilterer := newBlockFilterer(blk, vm.state)
+	for _, txFilterer := range blkFilterer.txs {
+		vm.pubsub.Publish(txFilterer)
+	}
+	vm.pubsub.Publish(blkFilterer)
 }
 
 func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
@@ -1343,6 +1343,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 		},
 		benchlist,
+		timetracker.NewNoPeerScorer(),
 		"",
 		prometheus.NewRegistry(),
 	)
@@ -1354,7 +1355,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", constants.DefaultNetworkCompressionType, 10*time.Second)
+	mc, err := message.NewCreator(logging.NoLog{}, metrics, "dummyNamespace", message.CompressionConfig{Type: constants.DefaultNetworkCompressionType}, 10*time.Second)
 	require.NoError(err)
 
 	require.NoError(chainRouter.Initialize(
@@ -2331,3 +2332,74 @@ func TestPruneMempool(t *testing.T) {
 	_, ok = vm.Builder.Get(baseTxID)
 	require.True(ok)
 }
+
+type queueingChainManager struct {
+	chains.Manager
+	queued []chains.ChainParameters
+}
+
+func (m *queueingChainManager) QueueChainCreation(chainParams chains.ChainParameters) {
+	m.queued = append(m.queued, chainParams)
+}
+
+func TestTrackSubnet(t *testing.T) {
+	require := require.New(t)
+	vm, _, _ := defaultVM(t, latestFork)
+
+	chainManager := &queueingChainManager{
+		Manager: chains.TestManager,
+	}
+	vm.ctx.Lock.Lock()
+	vm.Chains = chainManager
+
+	subnetID := testSubnet1.ID()
+	tx, err := vm.txBuilder.NewCreateChainTx(
+		subnetID,
+		nil,
+		ids.ID{'t', 'e', 's', 't', 'v', 'm'},
+		nil,
+		"name",
+		[]*secp256k1.PrivateKey{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		ids.ShortEmpty, // change addr
+		nil,
+	)
+	require.NoError(err)
+
+	vm.ctx.Lock.Unlock()
+	require.NoError(vm.issueTxFromRPC(tx))
+	vm.ctx.Lock.Lock()
+
+	blk, err := vm.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	// The chain isn't created because the subnet isn't tracked
+	require.Empty(chainManager.queued)
+	vm.ctx.Lock.Unlock()
+
+	require.NoError(vm.TrackSubnet(subnetID))
+
+	vm.ctx.Lock.Lock()
+	require.True(vm.TrackedSubnets.Contains(subnetID))
+	require.Contains(vm.subnetValidatorLoggers, subnetID)
+	require.Len(chainManager.queued, 1)
+	require.Equal(tx.ID(), chainManager.queued[0].ID)
+	require.Equal(subnetID, chainManager.queued[0].SubnetID)
+	vm.ctx.Lock.Unlock()
+
+	// Tracking a subnet that is already tracked is a noop
+	require.NoError(vm.TrackSubnet(subnetID))
+
+	vm.ctx.Lock.Lock()
+	require.Len(chainManager.queued, 1)
+	vm.ctx.Lock.Unlock()
+
+	require.NoError(vm.UntrackSubnet(subnetID))
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	require.False(vm.TrackedSubnets.Contains(subnetID))
+	require.NotContains(vm.subnetValidatorLoggers, subnetID)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var (
+	_ block.ChainVM         = ConfigReloaderVMMock{}
+	_ common.ConfigReloader = ConfigReloaderVMMock{}
+
+	errInvalidConfig = errors.New("invalid config")
+
+	validConfigBytes = []byte("valid config")
+)
+
+type ConfigReloaderVMMock struct {
+	*block.MockChainVM
+}
+
+func (ConfigReloaderVMMock) ReloadConfig(_ context.Context, configBytes []byte) error {
+	if !bytes.Equal(configBytes, validConfigBytes) {
+		return errInvalidConfig
+	}
+	return nil
+}
+
+func configReloaderTestPlugin(t *testing.T, _ bool) block.ChainVM {
+	// test key is "configReloaderTestKey"
+	ctrl := gomock.NewController(t)
+	return ConfigReloaderVMMock{
+		MockChainVM: block.NewMockChainVM(ctrl),
+	}
+}
+
+func configReloaderNotImplementedTestPlugin(t *testing.T, _ bool) block.ChainVM {
+	// test key is "configReloaderNotImplementedTestKey"
+	ctrl := gomock.NewController(t)
+	return block.NewMockChainVM(ctrl)
+}
+
+func TestReloadConfig(t *testing.T) {
+	require := require.New(t)
+	testKey := configReloaderTestKey
+
+	// Create and start the plugin
+	vm, stopper := buildClientHelper(require, testKey)
+	defer stopper.Stop(context.Background())
+
+	require.NoError(vm.ReloadConfig(context.Background(), validConfigBytes))
+
+	// test a non-special error.
+	// TODO: retrieve exact error
+	err := vm.ReloadConfig(context.Background(), []byte("invalid config"))
+	require.Error(err) //nolint:forbidigo // currently returns grpc errors
+}
+
+func TestReloadConfigNotImplemented(t *testing.T) {
+	require := require.New(t)
+	testKey := configReloaderNotImplementedTestKey
+
+	// Create and start the plugin
+	vm, stopper := buildClientHelper(require, testKey)
+	defer stopper.Stop(context.Background())
+
+	err := vm.ReloadConfig(context.Background(), validConfigBytes)
+	require.ErrorIs(err, common.ErrConfigReloaderNotImplemented)
+}
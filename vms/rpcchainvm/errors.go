@@ -5,6 +5,7 @@ package rpcchainvm
 
 import (
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 
 	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
@@ -12,16 +13,18 @@ import (
 
 var (
 	errEnumToError = map[vmpb.Error]error{
-		vmpb.Error_ERROR_CLOSED:                     database.ErrClosed,
-		vmpb.Error_ERROR_NOT_FOUND:                  database.ErrNotFound,
-		vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE:    block.ErrIndexIncomplete,
-		vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED: block.ErrStateSyncableVMNotImplemented,
+		vmpb.Error_ERROR_CLOSED:                        database.ErrClosed,
+		vmpb.Error_ERROR_NOT_FOUND:                     database.ErrNotFound,
+		vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE:       block.ErrIndexIncomplete,
+		vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED:    block.ErrStateSyncableVMNotImplemented,
+		vmpb.Error_ERROR_CONFIG_RELOAD_NOT_IMPLEMENTED: common.ErrConfigReloaderNotImplemented,
 	}
 	errorToErrEnum = map[error]vmpb.Error{
 		database.ErrClosed:                     vmpb.Error_ERROR_CLOSED,
 		database.ErrNotFound:                   vmpb.Error_ERROR_NOT_FOUND,
 		block.ErrIndexIncomplete:               vmpb.Error_ERROR_HEIGHT_INDEX_INCOMPLETE,
 		block.ErrStateSyncableVMNotImplemented: vmpb.Error_ERROR_STATE_SYNC_NOT_IMPLEMENTED,
+		common.ErrConfigReloaderNotImplemented: vmpb.Error_ERROR_CONFIG_RELOAD_NOT_IMPLEMENTED,
 	}
 )
 
@@ -74,6 +74,7 @@ var (
 	_ block.BuildBlockWithContextChainVM = (*VMClient)(nil)
 	_ block.BatchedChainVM               = (*VMClient)(nil)
 	_ block.StateSyncableVM              = (*VMClient)(nil)
+	_ common.ConfigReloader              = (*VMClient)(nil)
 	_ prometheus.Gatherer                = (*VMClient)(nil)
 
 	_ snowman.Block           = (*blockClient)(nil)
@@ -783,6 +784,16 @@ func (vm *VMClient) GetStateSummary(ctx context.Context, summaryHeight uint64) (
 	}, err
 }
 
+func (vm *VMClient) ReloadConfig(ctx context.Context, configBytes []byte) error {
+	resp, err := vm.client.ReloadConfig(ctx, &vmpb.ReloadConfigRequest{
+		ConfigBytes: configBytes,
+	})
+	if err != nil {
+		return err
+	}
+	return errEnumToError[resp.Err]
+}
+
 func (vm *VMClient) newBlockFromBuildBlock(resp *vmpb.BuildBlockResponse) (*blockClient, error) {
 	id, err := ids.ToID(resp.Id)
 	if err != nil {
@@ -69,6 +69,8 @@ type VMServer struct {
 	bVM block.BuildBlockWithContextChainVM
 	// If nil, the underlying VM doesn't implement the interface.
 	ssVM block.StateSyncableVM
+	// If nil, the underlying VM doesn't implement the interface.
+	crVM common.ConfigReloader
 
 	allowShutdown *utils.Atomic[bool]
 
@@ -87,10 +89,12 @@ type VMServer struct {
 func NewServer(vm block.ChainVM, allowShutdown *utils.Atomic[bool]) *VMServer {
 	bVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	crVM, _ := vm.(common.ConfigReloader)
 	return &VMServer{
 		vm:            vm,
 		bVM:           bVM,
 		ssVM:          ssVM,
+		crVM:          crVM,
 		allowShutdown: allowShutdown,
 	}
 }
@@ -847,3 +851,14 @@ func (vm *VMServer) StateSummaryAccept(
 		Err:  errorToErrEnum[err],
 	}, errorToRPCError(err)
 }
+
+func (vm *VMServer) ReloadConfig(ctx context.Context, req *vmpb.ReloadConfigRequest) (*vmpb.ReloadConfigResponse, error) {
+	err := common.ErrConfigReloaderNotImplemented
+	if vm.crVM != nil {
+		err = vm.crVM.ReloadConfig(ctx, req.ConfigBytes)
+	}
+
+	return &vmpb.ReloadConfigResponse{
+		Err: errorToErrEnum[err],
+	}, errorToRPCError(err)
+}
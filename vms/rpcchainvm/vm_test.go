@@ -36,6 +36,8 @@ const (
 	lastAcceptedBlockPostStateSummaryAcceptTestKey = "lastAcceptedBlockPostStateSummaryAcceptTest"
 	contextTestKey                                 = "contextTest"
 	batchedParseBlockCachingTestKey                = "batchedParseBlockCachingTest"
+	configReloaderTestKey                          = "configReloaderTest"
+	configReloaderNotImplementedTestKey            = "configReloaderNotImplementedTest"
 )
 
 var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
@@ -48,6 +50,8 @@ var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
 	lastAcceptedBlockPostStateSummaryAcceptTestKey: lastAcceptedBlockPostStateSummaryAcceptTestPlugin,
 	contextTestKey:                                 contextEnabledTestPlugin,
 	batchedParseBlockCachingTestKey:                batchedParseBlockCachingTestPlugin,
+	configReloaderTestKey:                          configReloaderTestPlugin,
+	configReloaderNotImplementedTestKey:            configReloaderNotImplementedTestPlugin,
 }
 
 // helperProcess helps with creating the subnet binary for testing.
@@ -36,6 +36,14 @@ func (c *batch) Write() error {
 		return err
 	}
 
+	// The timestamp is only needed to prune by [RetentionPolicy.KeepAfter].
+	if c.db.recordTimestamps.Load() {
+		timestamp := c.db.clock.Unix()
+		if err := database.PutUInt64(batch, newTimestampKey(c.height), timestamp); err != nil {
+			return err
+		}
+	}
+
 	return batch.Write()
 }
 
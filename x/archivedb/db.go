@@ -5,16 +5,25 @@ package archivedb
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var (
 	ErrNotImplemented = errors.New("feature not implemented")
 	ErrInvalidValue   = errors.New("invalid data value")
+	ErrHeightPruned   = errors.New("height has been pruned")
+
+	errInvalidPrunedValue = errors.New("invalid pruned value")
 
 	_ database.Compacter = (*Database)(nil)
 	_ health.Checker     = (*Database)(nil)
@@ -57,8 +66,39 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// Old heights can be removed with a [Pruner]. Reading a removed height returns
+// ErrHeightPruned.
 type Database struct {
 	db database.Database
+
+	// Used to record the time that each height was written at.
+	clock mockable.Clock
+	// If true, the time that each height was written at is recorded. Set by
+	// [NewPruner] if pruning by [RetentionPolicy.KeepAfter].
+	recordTimestamps atomic.Bool
+
+	// Protects [pruned] and [prunedLoaded].
+	prunedLock sync.RWMutex
+	// The heights that have been pruned. Lazily loaded from [db].
+	pruned       prunedHeights
+	prunedLoaded bool
+}
+
+// prunedHeights describes the heights that may no longer be read.
+//
+// Every height >= [below] is available. A height < [below] is available only
+// if [every] is non-zero and the height is a multiple of [every].
+type prunedHeights struct {
+	below uint64
+	every uint64
+}
+
+func (p prunedHeights) isPruned(height uint64) bool {
+	if height >= p.below {
+		return false
+	}
+	return p.every == 0 || height%p.every != 0
 }
 
 func New(db database.Database) *Database {
@@ -92,6 +132,71 @@ func (db *Database) NewBatch(height uint64) *batch {
 	}
 }
 
+// OldestHeight returns the oldest height such that every height after it can
+// still be read.
+//
+// Heights before the returned height may also be readable if they were
+// retained by [RetentionPolicy.KeepEvery].
+func (db *Database) OldestHeight() (uint64, error) {
+	pruned, err := db.getPruned()
+	return pruned.below, err
+}
+
+// isPruned returns true if [height] may no longer be read.
+func (db *Database) isPruned(height uint64) (bool, error) {
+	pruned, err := db.getPruned()
+	return pruned.isPruned(height), err
+}
+
+func (db *Database) getPruned() (prunedHeights, error) {
+	db.prunedLock.RLock()
+	pruned, loaded := db.pruned, db.prunedLoaded
+	db.prunedLock.RUnlock()
+	if loaded {
+		return pruned, nil
+	}
+
+	db.prunedLock.Lock()
+	defer db.prunedLock.Unlock()
+
+	if db.prunedLoaded {
+		return db.pruned, nil
+	}
+
+	prunedBytes, err := db.db.Get(prunedKey)
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return prunedHeights{}, err
+	case len(prunedBytes) != 2*wrappers.LongLen:
+		return prunedHeights{}, fmt.Errorf("%w: length %d", errInvalidPrunedValue, len(prunedBytes))
+	default:
+		db.pruned = prunedHeights{
+			below: binary.BigEndian.Uint64(prunedBytes),
+			every: binary.BigEndian.Uint64(prunedBytes[wrappers.LongLen:]),
+		}
+	}
+	db.prunedLoaded = true
+	return db.pruned, nil
+}
+
+// setPruned marks the heights described by [pruned] as no longer readable.
+// This must be called before any of those heights are deleted.
+func (db *Database) setPruned(pruned prunedHeights) error {
+	db.prunedLock.Lock()
+	defer db.prunedLock.Unlock()
+
+	prunedBytes := make([]byte, 2*wrappers.LongLen)
+	binary.BigEndian.PutUint64(prunedBytes, pruned.below)
+	binary.BigEndian.PutUint64(prunedBytes[wrappers.LongLen:], pruned.every)
+	if err := db.db.Put(prunedKey, prunedBytes); err != nil {
+		return err
+	}
+	db.pruned = pruned
+	db.prunedLoaded = true
+	return nil
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
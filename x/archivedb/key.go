@@ -4,6 +4,7 @@
 package archivedb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 
@@ -15,6 +16,15 @@ var (
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
 	heightKey = newDBKeyFromMetadata([]byte{})
+	prunedKey = newDBKeyFromMetadata([]byte("pruned"))
+
+	// The timestamp of each height is stored under
+	// newDBKeyFromMetadata(timestampMetadataPrefix + big endian height), so
+	// that iterating over [timestampPrefix] yields heights in increasing
+	// order.
+	timestampMetadataPrefix = []byte{'t'}
+	timestampKeyLen         = len(newTimestampKey(0))
+	timestampPrefix         = newTimestampKey(0)[:timestampKeyLen-wrappers.LongLen]
 )
 
 // The requirements of a database key are:
@@ -93,3 +103,22 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// newTimestampKey returns the metadata key that the timestamp of [height] is
+// stored under.
+func newTimestampKey(height uint64) []byte {
+	key := make([]byte, len(timestampMetadataPrefix)+wrappers.LongLen)
+	copy(key, timestampMetadataPrefix)
+	binary.BigEndian.PutUint64(key[len(timestampMetadataPrefix):], height)
+	return newDBKeyFromMetadata(key)
+}
+
+// parseTimestampKey returns the height of a key created by [newTimestampKey].
+// Because user keys may share [timestampPrefix], false is returned if
+// [dbKey] isn't a timestamp key.
+func parseTimestampKey(dbKey []byte) (uint64, bool) {
+	if len(dbKey) != timestampKeyLen || !bytes.HasPrefix(dbKey, timestampPrefix) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(dbKey[len(timestampPrefix):]), true
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const metricsNamespace = "archivedb"
+
+var (
+	errNoRetentionRules = errors.New("retention policy must specify at least one rule")
+	errInvalidBatchSize = errors.New("batch size must be positive")
+	errInvalidInterval  = errors.New("interval must be positive")
+	errInvalidTimestamp = errors.New("invalid timestamp value")
+)
+
+// RetentionPolicy defines the heights that are kept when the database is
+// pruned. A height is retained if it matches any of the specified rules. The
+// last written height is always retained.
+type RetentionPolicy struct {
+	// If non-zero, the last [KeepLast] heights are retained.
+	KeepLast uint64
+	// If non-zero, every height that is a multiple of [KeepEvery] is
+	// retained.
+	KeepEvery uint64
+	// If non-zero, every height written after [KeepAfter] is retained.
+	// Heights written before a pruner with [KeepAfter] was created have no
+	// recorded time and are considered to be older than [KeepAfter].
+	KeepAfter time.Time
+}
+
+type PrunerConfig struct {
+	Policy RetentionPolicy
+	// The frequency that [Pruner.Dispatch] prunes the database at.
+	Interval time.Duration
+	// The number of bytes of deletions to accumulate before writing them to
+	// the database.
+	BatchSize int
+	Log       logging.Logger
+	// If [Reg] is nil, metrics are collected locally but not exported through
+	// Prometheus.
+	Reg prometheus.Registerer
+}
+
+func (c *PrunerConfig) Verify() error {
+	switch {
+	case c.Policy.KeepLast == 0 && c.Policy.KeepEvery == 0 && c.Policy.KeepAfter.IsZero():
+		return errNoRetentionRules
+	case c.BatchSize <= 0:
+		return errInvalidBatchSize
+	case c.Interval <= 0:
+		return errInvalidInterval
+	default:
+		return nil
+	}
+}
+
+// Pruner deletes the versions of keys that are no longer needed to read any
+// height retained by its [RetentionPolicy].
+//
+// Deletions are written in batches and don't block readers. Reading a height
+// that has been pruned returns [ErrHeightPruned].
+type Pruner struct {
+	db      *Database
+	config  PrunerConfig
+	metrics *prunerMetrics
+
+	// Held while pruning to prevent concurrent passes.
+	lock sync.Mutex
+	// The heights pruned by the last completed pass.
+	lastPruned prunedHeights
+}
+
+type prunerMetrics struct {
+	prunedBytes   prometheus.Counter
+	prunedEntries prometheus.Counter
+	oldestHeight  prometheus.Gauge
+}
+
+func newPrunerMetrics(reg prometheus.Registerer) (*prunerMetrics, error) {
+	m := &prunerMetrics{
+		prunedBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pruned_bytes",
+			Help:      "cumulative amount of key and value bytes deleted by pruning",
+		}),
+		prunedEntries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pruned_entries",
+			Help:      "cumulative number of entries deleted by pruning",
+		}),
+		oldestHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "oldest_height",
+			Help:      "oldest height such that every later height is available",
+		}),
+	}
+	if reg == nil {
+		return m, nil
+	}
+	err := utils.Err(
+		reg.Register(m.prunedBytes),
+		reg.Register(m.prunedEntries),
+		reg.Register(m.oldestHeight),
+	)
+	return m, err
+}
+
+// NewPruner returns a pruner for [db].
+//
+// If the policy specifies [RetentionPolicy.KeepAfter], [db] starts recording
+// the time that each height is written at. The pruner should be created before
+// any heights are written, as heights without a recorded time are considered
+// to be older than [RetentionPolicy.KeepAfter].
+func NewPruner(db *Database, config PrunerConfig) (*Pruner, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	metrics, err := newPrunerMetrics(config.Reg)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Policy.KeepAfter.IsZero() {
+		db.recordTimestamps.Store(true)
+	}
+	return &Pruner{
+		db:      db,
+		config:  config,
+		metrics: metrics,
+	}, nil
+}
+
+// Dispatch prunes the database every [PrunerConfig.Interval] until [ctx] is
+// cancelled.
+func (p *Pruner) Dispatch(ctx context.Context) {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+				p.config.Log.Warn("failed to prune archivedb",
+					zap.Error(err),
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Prune deletes every version of every key that isn't needed to read a height
+// retained by the policy.
+//
+// The pruned heights are recorded before anything is deleted, so readers never
+// observe a partially pruned height. If pruning is interrupted, the next call
+// finishes deleting the superseded versions.
+func (p *Pruner) Prune(ctx context.Context) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lastHeight, err := p.db.Height()
+	if err == database.ErrNotFound {
+		// Nothing has been written yet.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	below, err := p.pruneBelow(lastHeight)
+	if err != nil {
+		return err
+	}
+
+	previous, err := p.db.getPruned()
+	if err != nil {
+		return err
+	}
+	pruned := prunedHeights{
+		// Heights can't be un-pruned if the policy was relaxed.
+		below: max(below, previous.below),
+		every: p.config.Policy.KeepEvery,
+	}
+	if previous.below != 0 && previous.every != pruned.every {
+		// Heights before [previous.below] are only available if they were
+		// retained by both the previous and the current policy.
+		pruned.every = lcm(previous.every, pruned.every)
+	}
+	if pruned == p.lastPruned {
+		// A previous pass already pruned these heights.
+		return nil
+	}
+
+	if pruned != previous {
+		if err := p.db.setPruned(pruned); err != nil {
+			return err
+		}
+	}
+	if err := p.deleteSuperseded(ctx, pruned, lastHeight); err != nil {
+		return err
+	}
+	if err := p.deleteTimestamps(ctx, pruned.below); err != nil {
+		return err
+	}
+
+	p.lastPruned = pruned
+	p.metrics.oldestHeight.Set(float64(pruned.below))
+	return nil
+}
+
+// pruneBelow returns the height that every height that isn't retained by
+// [RetentionPolicy.KeepEvery] is pruned below.
+func (p *Pruner) pruneBelow(lastHeight uint64) (uint64, error) {
+	below := lastHeight
+	if keepLast := p.config.Policy.KeepLast; keepLast != 0 {
+		if keepLast > lastHeight {
+			below = 0
+		} else {
+			below = min(below, lastHeight-keepLast+1)
+		}
+	}
+
+	keepAfter := p.config.Policy.KeepAfter
+	if keepAfter.IsZero() {
+		return below, nil
+	}
+
+	it := p.db.db.NewIteratorWithPrefix(timestampPrefix)
+	defer it.Release()
+
+	threshold := uint64(keepAfter.Unix())
+	for it.Next() {
+		height, ok := parseTimestampKey(it.Key())
+		if !ok {
+			// This is a user key.
+			continue
+		}
+		if height >= below {
+			break
+		}
+
+		timestamp, err := database.ParseUInt64(it.Value())
+		if err != nil {
+			return 0, errInvalidTimestamp
+		}
+		if timestamp > threshold {
+			return height, nil
+		}
+	}
+	return below, it.Error()
+}
+
+// deleteSuperseded deletes the versions of keys that aren't needed to read
+// any height that isn't pruned.
+//
+// Versions written after [lastHeight] are never deleted.
+func (p *Pruner) deleteSuperseded(ctx context.Context, pruned prunedHeights, lastHeight uint64) error {
+	it := p.db.db.NewIterator()
+	defer it.Release()
+
+	deleter := p.newDeleter()
+
+	var (
+		// The database key prefix of the user key being iterated over.
+		prefix []byte
+		// The height of the newer version of the user key, if there is one.
+		newerHeight uint64
+		hasNewer    bool
+		// The kept deletions of the user key that aren't followed by an
+		// older kept version. They are deleted if no older version is kept.
+		tombstones [][]byte
+	)
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		dbKey := it.Key()
+		_, height, err := parseDBKeyFromUser(dbKey)
+		if err == ErrIncorrectKeyLength {
+			// This is a metadata key.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		dbKeyPrefix := dbKey[:len(dbKey)-wrappers.LongLen]
+		if !bytes.Equal(prefix, dbKeyPrefix) {
+			for _, tombstone := range tombstones {
+				if err := deleter.delete(tombstone, nil); err != nil {
+					return err
+				}
+			}
+			prefix = slices.Clone(dbKeyPrefix)
+			hasNewer = false
+			tombstones = tombstones[:0]
+		}
+
+		needed := !hasNewer || height > lastHeight || pruned.anyAvailable(height, newerHeight)
+		newerHeight = height
+		hasNewer = true
+
+		if !needed {
+			if err := deleter.delete(dbKey, it.Value()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, exists := parseDBValue(it.Value()); exists {
+			tombstones = tombstones[:0]
+		} else {
+			tombstones = append(tombstones, slices.Clone(dbKey))
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, tombstone := range tombstones {
+		if err := deleter.delete(tombstone, nil); err != nil {
+			return err
+		}
+	}
+	return deleter.flush()
+}
+
+// deleteTimestamps deletes the timestamps of heights before [below]. They are
+// no longer needed to find the heights retained by
+// [RetentionPolicy.KeepAfter].
+func (p *Pruner) deleteTimestamps(ctx context.Context, below uint64) error {
+	it := p.db.db.NewIteratorWithPrefix(timestampPrefix)
+	defer it.Release()
+
+	deleter := p.newDeleter()
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		height, ok := parseTimestampKey(it.Key())
+		if !ok {
+			// This is a user key.
+			continue
+		}
+		if height >= below {
+			break
+		}
+		if err := deleter.delete(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return deleter.flush()
+}
+
+// deleter writes deletions to the database in batches of at least
+// [PrunerConfig.BatchSize] bytes.
+type deleter struct {
+	pruner *Pruner
+	batch  database.Batch
+
+	// The number of entries and bytes deleted by [batch].
+	entries int
+	bytes   int
+}
+
+func (p *Pruner) newDeleter() *deleter {
+	return &deleter{
+		pruner: p,
+		batch:  p.db.db.NewBatch(),
+	}
+}
+
+func (d *deleter) delete(key, value []byte) error {
+	if err := d.batch.Delete(key); err != nil {
+		return err
+	}
+	d.entries++
+	d.bytes += len(key) + len(value)
+	if d.batch.Size() < d.pruner.config.BatchSize {
+		return nil
+	}
+	return d.flush()
+}
+
+func (d *deleter) flush() error {
+	if d.entries == 0 {
+		return nil
+	}
+	if err := d.batch.Write(); err != nil {
+		return err
+	}
+	d.pruner.metrics.prunedEntries.Add(float64(d.entries))
+	d.pruner.metrics.prunedBytes.Add(float64(d.bytes))
+
+	d.batch.Reset()
+	d.entries = 0
+	d.bytes = 0
+	return nil
+}
+
+// anyAvailable returns true if any height in [start, end) isn't pruned.
+//
+// Assumes [start] < [end].
+func (p prunedHeights) anyAvailable(start, end uint64) bool {
+	if end > p.below {
+		return true
+	}
+	if p.every == 0 {
+		return false
+	}
+	// The distance from [start] to the next multiple of [every].
+	remainder := start % p.every
+	if remainder == 0 {
+		return true
+	}
+	return p.every-remainder < end-start
+}
+
+// lcm returns the least common multiple of [a] and [b], or 0 if either is 0.
+func lcm(a, b uint64) uint64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
@@ -0,0 +1,392 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// writeHeights writes heights [1, numHeights] to [db]. At every height, key
+// "key" is set to the height and key "even" is set if the height is even and
+// deleted otherwise.
+func writeHeights(t *testing.T, db *Database, numHeights uint64) {
+	for height := uint64(1); height <= numHeights; height++ {
+		batch := db.NewBatch(height)
+		require.NoError(t, batch.Put([]byte("key"), database.PackUInt64(height)))
+		if height%2 == 0 {
+			require.NoError(t, batch.Put([]byte("even"), database.PackUInt64(height)))
+		} else {
+			require.NoError(t, batch.Delete([]byte("even")))
+		}
+		require.NoError(t, batch.Write())
+	}
+}
+
+// requireAvailable verifies that [height] can be read with the values written
+// by [writeHeights].
+func requireAvailable(t *testing.T, db *Database, height uint64) {
+	reader := db.Open(height)
+
+	value, err := reader.Get([]byte("key"))
+	require.NoError(t, err, "height %d", height)
+	require.Equal(t, database.PackUInt64(height), value)
+
+	value, err = reader.Get([]byte("even"))
+	if height%2 == 0 {
+		require.NoError(t, err, "height %d", height)
+		require.Equal(t, database.PackUInt64(height), value)
+	} else {
+		require.ErrorIs(t, err, database.ErrNotFound)
+	}
+}
+
+func countEntries(t *testing.T, db database.Iteratee) int {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var count int
+	for it.Next() {
+		if _, _, err := parseDBKeyFromUser(it.Key()); err == nil {
+			count++
+		}
+	}
+	require.NoError(t, it.Error())
+	return count
+}
+
+func newTestPruner(t *testing.T, db *Database, policy RetentionPolicy) (*Pruner, *prometheus.Registry) {
+	reg := prometheus.NewRegistry()
+	pruner, err := NewPruner(db, PrunerConfig{
+		Policy:    policy,
+		Interval:  time.Millisecond,
+		BatchSize: 64,
+		Log:       logging.NoLog{},
+		Reg:       reg,
+	})
+	require.NoError(t, err)
+	return pruner, reg
+}
+
+func TestPrunerRetentionPolicies(t *testing.T) {
+	tests := []struct {
+		policy         RetentionPolicy
+		expectedOldest uint64
+		// Heights that must be readable after pruning.
+		available []uint64
+		// Heights that must be pruned.
+		pruned []uint64
+	}{
+		{
+			policy:         RetentionPolicy{KeepLast: 5},
+			expectedOldest: 16,
+			available:      []uint64{16, 17, 18, 19, 20},
+			pruned:         []uint64{1, 10, 15},
+		},
+		{
+			policy:         RetentionPolicy{KeepLast: 100},
+			expectedOldest: 0,
+			available:      []uint64{1, 10, 20},
+		},
+		{
+			policy:         RetentionPolicy{KeepEvery: 4},
+			expectedOldest: 20,
+			available:      []uint64{4, 8, 12, 16, 20},
+			pruned:         []uint64{1, 2, 3, 5, 19},
+		},
+		{
+			policy:         RetentionPolicy{KeepLast: 3, KeepEvery: 5},
+			expectedOldest: 18,
+			available:      []uint64{5, 10, 15, 18, 19, 20},
+			pruned:         []uint64{1, 9, 16, 17},
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%+v", test.policy), func(t *testing.T) {
+			require := require.New(t)
+
+			db := New(memdb.New())
+			writeHeights(t, db, 20)
+
+			pruner, _ := newTestPruner(t, db, test.policy)
+			require.NoError(pruner.Prune(context.Background()))
+
+			oldest, err := db.OldestHeight()
+			require.NoError(err)
+			require.Equal(test.expectedOldest, oldest)
+
+			for _, height := range test.available {
+				requireAvailable(t, db, height)
+			}
+			for _, height := range test.pruned {
+				_, err := db.Open(height).Get([]byte("key"))
+				require.ErrorIs(err, ErrHeightPruned, "height %d", height)
+			}
+		})
+	}
+}
+
+func TestPrunerKeepAfter(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	start := time.Unix(1_000_000, 0)
+	pruner, _ := newTestPruner(t, db, RetentionPolicy{
+		KeepAfter: start.Add(7 * time.Minute),
+	})
+	for height := uint64(1); height <= 10; height++ {
+		db.clock.Set(start.Add(time.Duration(height) * time.Minute))
+		batch := db.NewBatch(height)
+		require.NoError(batch.Put([]byte("key"), database.PackUInt64(height)))
+		require.NoError(batch.Write())
+	}
+	require.NoError(pruner.Prune(context.Background()))
+
+	oldest, err := db.OldestHeight()
+	require.NoError(err)
+	require.Equal(uint64(8), oldest)
+
+	_, err = db.Open(7).Get([]byte("key"))
+	require.ErrorIs(err, ErrHeightPruned)
+	for height := uint64(8); height <= 10; height++ {
+		value, err := db.Open(height).Get([]byte("key"))
+		require.NoError(err)
+		require.Equal(database.PackUInt64(height), value)
+	}
+
+	// Only the timestamps of the available heights are kept.
+	it := db.db.NewIteratorWithPrefix(timestampPrefix)
+	defer it.Release()
+	var heights []uint64
+	for it.Next() {
+		height, ok := parseTimestampKey(it.Key())
+		require.True(ok)
+		heights = append(heights, height)
+	}
+	require.NoError(it.Error())
+	require.Equal([]uint64{8, 9, 10}, heights)
+}
+
+func TestPrunerDeletesSupersededVersions(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	writeHeights(t, db, 20)
+	require.Equal(40, countEntries(t, db.db))
+
+	pruner, reg := newTestPruner(t, db, RetentionPolicy{KeepLast: 5})
+	require.NoError(pruner.Prune(context.Background()))
+
+	// Only the versions at heights [16, 20] are needed.
+	require.Equal(10, countEntries(t, db.db))
+	// 30 versions were deleted.
+	require.Equal(float64(30), testutil.ToFloat64(pruner.metrics.prunedEntries))
+	require.Positive(testutil.ToFloat64(pruner.metrics.prunedBytes))
+	require.Equal(float64(16), testutil.ToFloat64(pruner.metrics.oldestHeight))
+
+	metrics, err := reg.Gather()
+	require.NoError(err)
+	require.Len(metrics, 3)
+
+	// Pruning again without any new heights is a no-op.
+	require.NoError(pruner.Prune(context.Background()))
+	require.Equal(float64(30), testutil.ToFloat64(pruner.metrics.prunedEntries))
+}
+
+func TestTimestampsOnlyRecordedWhenPruningByTime(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	_, _ = newTestPruner(t, db, RetentionPolicy{KeepLast: 5})
+	writeHeights(t, db, 1)
+
+	it := db.db.NewIteratorWithPrefix(timestampPrefix)
+	defer it.Release()
+	for it.Next() {
+		_, ok := parseTimestampKey(it.Key())
+		require.False(ok)
+	}
+	require.NoError(it.Error())
+}
+
+func TestPrunerDeletesTombstones(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Delete([]byte("key")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("other"), []byte("value")))
+	require.NoError(batch.Write())
+
+	pruner, _ := newTestPruner(t, db, RetentionPolicy{KeepLast: 2})
+	require.NoError(pruner.Prune(context.Background()))
+
+	// Both versions of "key" are deleted as there's no remaining version for
+	// the deletion to shadow.
+	require.Equal(1, countEntries(t, db.db))
+
+	_, err := db.Open(2).Get([]byte("key"))
+	require.ErrorIs(err, database.ErrNotFound)
+	value, err := db.Open(3).Get([]byte("other"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestPrunerPolicyChange(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	writeHeights(t, db, 20)
+
+	pruner, _ := newTestPruner(t, db, RetentionPolicy{KeepLast: 5, KeepEvery: 2})
+	require.NoError(pruner.Prune(context.Background()))
+
+	// Relaxing the policy can't restore pruned heights.
+	pruner, _ = newTestPruner(t, db, RetentionPolicy{KeepLast: 10, KeepEvery: 3})
+	require.NoError(pruner.Prune(context.Background()))
+
+	oldest, err := db.OldestHeight()
+	require.NoError(err)
+	require.Equal(uint64(16), oldest)
+
+	for _, height := range []uint64{6, 12, 16, 17} {
+		requireAvailable(t, db, height)
+	}
+	for _, height := range []uint64{2, 3, 9, 14, 15} {
+		_, err := db.Open(height).Get([]byte("key"))
+		require.ErrorIs(err, ErrHeightPruned, "height %d", height)
+	}
+}
+
+func TestPrunerPersisted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+	writeHeights(t, db, 10)
+
+	pruner, _ := newTestPruner(t, db, RetentionPolicy{KeepLast: 2})
+	require.NoError(pruner.Prune(context.Background()))
+
+	db = New(baseDB)
+	oldest, err := db.OldestHeight()
+	require.NoError(err)
+	require.Equal(uint64(9), oldest)
+
+	_, err = db.Open(8).Get([]byte("key"))
+	require.ErrorIs(err, ErrHeightPruned)
+	requireAvailable(t, db, 9)
+}
+
+func TestPrunerDispatch(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+	writeHeights(t, db, 10)
+
+	pruner, _ := newTestPruner(t, db, RetentionPolicy{KeepLast: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pruner.Dispatch(ctx)
+	}()
+
+	require.Eventually(func() bool {
+		oldest, err := db.OldestHeight()
+		require.NoError(err)
+		return oldest == 10
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestPrunerConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      PrunerConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: PrunerConfig{
+				Policy:    RetentionPolicy{KeepEvery: 1},
+				Interval:  time.Second,
+				BatchSize: 1,
+			},
+		},
+		{
+			name: "no retention rules",
+			config: PrunerConfig{
+				Interval:  time.Second,
+				BatchSize: 1,
+			},
+			expectedErr: errNoRetentionRules,
+		},
+		{
+			name: "invalid batch size",
+			config: PrunerConfig{
+				Policy:   RetentionPolicy{KeepLast: 1},
+				Interval: time.Second,
+			},
+			expectedErr: errInvalidBatchSize,
+		},
+		{
+			name: "invalid interval",
+			config: PrunerConfig{
+				Policy:    RetentionPolicy{KeepAfter: time.Unix(1, 0)},
+				BatchSize: 1,
+			},
+			expectedErr: errInvalidInterval,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestPrunedHeightsAnyAvailable(t *testing.T) {
+	pruned := prunedHeights{
+		below: 20,
+		every: 5,
+	}
+	tests := []struct {
+		start, end uint64
+		expected   bool
+	}{
+		{start: 1, end: 5, expected: false},
+		{start: 1, end: 6, expected: true},
+		{start: 5, end: 6, expected: true},
+		{start: 6, end: 10, expected: false},
+		{start: 16, end: 20, expected: false},
+		{start: 16, end: 21, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("[%d, %d)", test.start, test.end), func(t *testing.T) {
+			require.Equal(t, test.expected, pruned.anyAvailable(test.start, test.end))
+		})
+	}
+}
@@ -34,7 +34,20 @@ func (r *Reader) Get(key []byte) ([]byte, error) {
 // GetEntry retrieves the value of the provided key, the height it was last
 // modified at, and a boolean to indicate if the last modification was an
 // insertion. If the key has never been modified, ErrNotFound will be returned.
+// If the height of the reader has been pruned, ErrHeightPruned will be
+// returned.
+//
+// Note: A deletion is reported as ErrNotFound, rather than as a removal, once
+// every prior version of the key has been pruned.
 func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	pruned, err := r.db.isPruned(r.height)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	if pruned {
+		return nil, 0, false, ErrHeightPruned
+	}
+
 	it := r.db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, r.height))
 	defer it.Release()
 
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 )
 
@@ -29,6 +30,9 @@ const (
 	minByteSliceLen      = minVarIntLen
 	minDBNodeLen         = minMaybeByteSliceLen + minVarIntLen
 	minChildLen          = minVarIntLen + minKeyLen + ids.IDLen + boolLen
+	minNodeChangeLen     = minKeyLen + 2*boolLen
+	minValueChangeLen    = minKeyLen + 2*minMaybeByteSliceLen
+	minChangeSummaryLen  = ids.IDLen + 2*boolLen + 2*minVarIntLen
 
 	estimatedKeyLen   = 64
 	estimatedValueLen = 64
@@ -66,12 +70,16 @@ type encoder interface {
 	// Assumes [n] is non-nil.
 	encodeHashValues(n *node) []byte
 	encodeKey(key Key) []byte
+
+	// Assumes [changes] is non-nil.
+	encodeChangeSummary(changes *changeSummary) []byte
 }
 
 type decoder interface {
 	// Assumes [n] is non-nil.
 	decodeDBNode(bytes []byte, n *dbNode) error
 	decodeKey(bytes []byte) (Key, error)
+	decodeChangeSummary(bytes []byte) (*changeSummary, error)
 }
 
 func newCodec() encoderDecoder {
@@ -424,3 +432,154 @@ func (c *codecImpl) decodeKeyFromReader(src *bytes.Reader) (Key, error) {
 	result.value = string(buffer)
 	return result, nil
 }
+
+func (c *codecImpl) encodeChangeSummary(changes *changeSummary) []byte {
+	estimatedLen := minChangeSummaryLen +
+		len(changes.nodes)*(estimatedKeyLen+2*estimatedValueLen) +
+		len(changes.values)*(estimatedKeyLen+2*estimatedValueLen)
+	buf := bytes.NewBuffer(make([]byte, 0, estimatedLen))
+
+	_, _ = buf.Write(changes.rootID[:])
+	c.encodeNode(buf, changes.rootChange.before.Value())
+	c.encodeNode(buf, changes.rootChange.after.Value())
+
+	// Note we insert changes in order of increasing key
+	// for determinism.
+	nodeKeys := maps.Keys(changes.nodes)
+	utils.Sort(nodeKeys)
+	c.encodeUint(buf, uint64(len(nodeKeys)))
+	for _, key := range nodeKeys {
+		nodeChange := changes.nodes[key]
+		c.encodeKeyToBuffer(buf, key)
+		c.encodeNode(buf, nodeChange.before)
+		c.encodeNode(buf, nodeChange.after)
+	}
+
+	valueKeys := maps.Keys(changes.values)
+	utils.Sort(valueKeys)
+	c.encodeUint(buf, uint64(len(valueKeys)))
+	for _, key := range valueKeys {
+		valueChange := changes.values[key]
+		c.encodeKeyToBuffer(buf, key)
+		c.encodeMaybeByteSlice(buf, valueChange.before)
+		c.encodeMaybeByteSlice(buf, valueChange.after)
+	}
+	return buf.Bytes()
+}
+
+func (c *codecImpl) decodeChangeSummary(b []byte) (*changeSummary, error) {
+	if minChangeSummaryLen > len(b) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	src := bytes.NewReader(b)
+
+	rootID, err := c.decodeID(src)
+	if err != nil {
+		return nil, err
+	}
+	rootBefore, err := c.decodeNode(src)
+	if err != nil {
+		return nil, err
+	}
+	rootAfter, err := c.decodeNode(src)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return nil, err
+	case numNodes > uint64(src.Len()/minNodeChangeLen):
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	changes := newChangeSummary(int(numNodes))
+	changes.rootID = rootID
+	if rootBefore != nil {
+		changes.rootChange.before = maybe.Some(rootBefore)
+	}
+	if rootAfter != nil {
+		changes.rootChange.after = maybe.Some(rootAfter)
+	}
+
+	for i := uint64(0); i < numNodes; i++ {
+		key, err := c.decodeKeyFromReader(src)
+		if err != nil {
+			return nil, err
+		}
+		before, err := c.decodeNode(src)
+		if err != nil {
+			return nil, err
+		}
+		after, err := c.decodeNode(src)
+		if err != nil {
+			return nil, err
+		}
+		changes.nodes[key] = &change[*node]{
+			before: before,
+			after:  after,
+		}
+	}
+
+	numValues, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return nil, err
+	case numValues > uint64(src.Len()/minValueChangeLen):
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	for i := uint64(0); i < numValues; i++ {
+		key, err := c.decodeKeyFromReader(src)
+		if err != nil {
+			return nil, err
+		}
+		before, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return nil, err
+		}
+		after, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return nil, err
+		}
+		changes.values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+
+	if src.Len() != 0 {
+		return nil, errExtraSpace
+	}
+	return changes, nil
+}
+
+// encodeNode writes [n], which may be nil, to [dst].
+func (c *codecImpl) encodeNode(dst *bytes.Buffer, n *node) {
+	hasNode := n != nil
+	c.encodeBool(dst, hasNode)
+	if hasNode {
+		c.encodeKeyToBuffer(dst, n.key)
+		c.encodeByteSlice(dst, n.bytes())
+	}
+}
+
+// decodeNode reads a node written by [encodeNode] from [src].
+// Returns nil if no node was written.
+func (c *codecImpl) decodeNode(src *bytes.Reader) (*node, error) {
+	if hasNode, err := c.decodeBool(src); err != nil || !hasNode {
+		return nil, err
+	}
+
+	key, err := c.decodeKeyFromReader(src)
+	if err != nil {
+		return nil, err
+	}
+	nodeBytes, err := c.decodeByteSlice(src)
+	if err != nil {
+		return nil, err
+	}
+	return parseNode(key, nodeBytes)
+}
@@ -181,6 +181,59 @@ func TestCodecDecodeDBNode_TooShort(t *testing.T) {
 	require.ErrorIs(err, io.ErrUnexpectedEOF)
 }
 
+func TestCodecChangeSummary(t *testing.T) {
+	require := require.New(t)
+
+	rootBefore := newNode(ToKey([]byte{1}))
+	rootBefore.setValue(maybe.Some([]byte{2}))
+	rootAfter := newNode(ToKey([]byte{1}))
+	rootAfter.addChild(newNode(ToKey([]byte{1, 2})), 4)
+
+	changedNode := newNode(ToKey([]byte{3}))
+	changedNode.setValue(maybe.Some([]byte{4}))
+
+	changes := &changeSummary{
+		rootID: ids.GenerateTestID(),
+		rootChange: change[maybe.Maybe[*node]]{
+			before: maybe.Some(rootBefore),
+			after:  maybe.Some(rootAfter),
+		},
+		nodes: map[Key]*change[*node]{
+			rootAfter.key: {
+				before: rootBefore,
+				after:  rootAfter,
+			},
+			changedNode.key: {
+				after: changedNode,
+			},
+		},
+		values: map[Key]*change[maybe.Maybe[[]byte]]{
+			ToKey([]byte{1}): {
+				before: maybe.Some([]byte{2}),
+			},
+			ToKey([]byte{3}): {
+				after: maybe.Some([]byte{4}),
+			},
+		},
+	}
+
+	changesBytes := codec.encodeChangeSummary(changes)
+	gotChanges, err := codec.decodeChangeSummary(changesBytes)
+	require.NoError(err)
+	require.Equal(changes, gotChanges)
+
+	// Encoding should be deterministic.
+	require.Equal(changesBytes, codec.encodeChangeSummary(gotChanges))
+}
+
+func TestCodecDecodeChangeSummary_TooShort(t *testing.T) {
+	require := require.New(t)
+
+	tooShortBytes := make([]byte, minChangeSummaryLen-1)
+	_, err := codec.decodeChangeSummary(tooShortBytes)
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
 // Ensure that encodeHashValues is deterministic
 func FuzzEncodeHashValues(f *testing.F) {
 	codec1 := newCodec()
@@ -8,9 +8,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"runtime"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
@@ -35,6 +37,7 @@ const (
 	clearBatchSize                       = units.MiB
 	rebuildIntermediateDeletionWriteSize = units.MiB
 	valueNodePrefixLen                   = 1
+	historyPrefixLen                     = 1
 	cacheEntryOverHead                   = 8
 )
 
@@ -46,6 +49,7 @@ var (
 	metadataPrefix         = []byte{0}
 	valueNodePrefix        = []byte{1}
 	intermediateNodePrefix = []byte{2}
+	historyPrefix          = []byte{3}
 
 	cleanShutdownKey        = []byte(string(metadataPrefix) + "cleanShutdown")
 	rootDBKey               = []byte(string(metadataPrefix) + "root")
@@ -119,6 +123,26 @@ type RangeProofer interface {
 	CommitRangeProof(ctx context.Context, start, end maybe.Maybe[[]byte], proof *RangeProof) error
 }
 
+// KeyRangeProof is a range proof of the key/value pairs in [Start, End].
+type KeyRangeProof struct {
+	// Start is the smallest possible key in the range [Proof] covers.
+	Start maybe.Maybe[[]byte]
+	// End is the largest possible key in the range [Proof] covers.
+	End   maybe.Maybe[[]byte]
+	Proof *RangeProof
+}
+
+type RangeProofBatchCommitter interface {
+	// CommitRangeProofs atomically commits the key/value pairs within each of
+	// the [proofs] to the db.
+	// The ranges covered by the [proofs] must not overlap.
+	//
+	// Committing multiple proofs at once is more efficient than committing
+	// them individually because the trie nodes shared by the proofs are only
+	// hashed and written once.
+	CommitRangeProofs(ctx context.Context, proofs []KeyRangeProof) error
+}
+
 type Clearer interface {
 	// Deletes all key/value pairs from the database
 	// and clears the change history.
@@ -138,6 +162,35 @@ type Prefetcher interface {
 	PrefetchPaths(keys [][]byte) error
 }
 
+type Snapshotter interface {
+	// ExportSnapshot writes the key/value pairs in the trie when its root was
+	// [rootID] to [w], in chunks of at most [chunkSize] key/value pairs.
+	// Each chunk includes a range proof so that the snapshot can be verified
+	// when it's imported.
+	// Returns [ErrInsufficientHistory] if [rootID] isn't in the history when a
+	// chunk is generated.
+	ExportSnapshot(ctx context.Context, rootID ids.ID, chunkSize int, w io.Writer) error
+
+	// ImportSnapshot commits the key/value pairs of a snapshot written by
+	// ExportSnapshot, read from [r], and returns the root ID of the snapshot.
+	// Returns [ErrDatabaseNotEmpty] if the database contains any key/value pairs.
+	// Returns [ErrSnapshotRootMismatch] if the resulting root ID doesn't
+	// match the root ID of the snapshot.
+	ImportSnapshot(ctx context.Context, r io.Reader) (ids.ID, error)
+}
+
+type HistoricalViewer interface {
+	// ViewAtRoot returns a read-only view of the trie when its root was
+	// [rootID].
+	// Unlike views returned by NewView, the returned view remains usable after
+	// changes are committed to the database for as long as [rootID] is in the
+	// change history.
+	// Returns [ErrInsufficientHistory] if [rootID] isn't in the change history.
+	// Reads from the returned view return [ErrInsufficientHistory] once
+	// [rootID] is no longer in the change history.
+	ViewAtRoot(ctx context.Context, rootID ids.ID) (ReadOnlyView, error)
+}
+
 type MerkleDB interface {
 	database.Database
 	Clearer
@@ -146,7 +199,10 @@ type MerkleDB interface {
 	ProofGetter
 	ChangeProofer
 	RangeProofer
+	RangeProofBatchCommitter
 	Prefetcher
+	Snapshotter
+	HistoricalViewer
 }
 
 type Config struct {
@@ -162,6 +218,12 @@ type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// If non-zero, changes recorded more than [HistoryMaxAge] ago are removed
+	// from the history even if there are fewer than [HistoryLength] changes.
+	HistoryMaxAge time.Duration
+	// If true, the change history is also written to disk so that change
+	// proofs can still be served for roots from before a restart.
+	PersistHistory bool
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
@@ -257,6 +319,11 @@ func newDatabase(
 		},
 	}
 
+	var historyDB *historyDB
+	if config.PersistHistory {
+		historyDB = newHistoryDB(db)
+	}
+
 	trieDB := &merkleDB{
 		metrics: metrics,
 		baseDB:  db,
@@ -272,7 +339,7 @@ func newDatabase(
 			bufferPool,
 			metrics,
 			int(config.ValueNodeCacheSize)),
-		history:              newTrieHistory(int(config.HistoryLength)),
+		history:              newTrieHistory(int(config.HistoryLength), config.HistoryMaxAge, historyDB),
 		debugTracer:          getTracerIfEnabled(config.TraceLevel, DebugTrace, config.Tracer),
 		infoTracer:           getTracerIfEnabled(config.TraceLevel, InfoTrace, config.Tracer),
 		childViews:           make([]*view, 0, defaultPreallocationSize),
@@ -284,15 +351,24 @@ func newDatabase(
 		return nil, err
 	}
 
-	// add current root to history (has no changes)
-	trieDB.history.record(&changeSummary{
-		rootID: trieDB.rootID,
-		rootChange: change[maybe.Maybe[*node]]{
-			after: trieDB.root,
-		},
-		values: map[Key]*change[maybe.Maybe[[]byte]]{},
-		nodes:  map[Key]*change[*node]{},
-	})
+	restored, err := trieDB.history.restore(trieDB.rootID)
+	if err != nil {
+		return nil, err
+	}
+	if !restored {
+		// add current root to history (has no changes)
+		err := trieDB.history.record(&changeSummary{
+			rootID: trieDB.rootID,
+			rootChange: change[maybe.Maybe[*node]]{
+				after: trieDB.root,
+			},
+			values: map[Key]*change[maybe.Maybe[[]byte]]{},
+			nodes:  map[Key]*change[*node]{},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	shutdownType, err := trieDB.baseDB.Get(cleanShutdownKey)
 	switch err {
@@ -320,6 +396,11 @@ func (db *merkleDB) rebuild(ctx context.Context, cacheSize int) error {
 	db.root = maybe.Nothing[*node]()
 	db.rootID = ids.Empty
 
+	// The history may not be consistent with the trie, so discard it.
+	if err := db.history.reset(); err != nil {
+		return err
+	}
+
 	// Delete intermediate nodes.
 	if err := database.ClearPrefix(db.baseDB, intermediateNodePrefix, rebuildIntermediateDeletionWriteSize); err != nil {
 		return err
@@ -393,6 +474,16 @@ func (db *merkleDB) CommitChangeProof(ctx context.Context, proof *ChangeProof) e
 }
 
 func (db *merkleDB) CommitRangeProof(ctx context.Context, start, end maybe.Maybe[[]byte], proof *RangeProof) error {
+	return db.CommitRangeProofs(ctx, []KeyRangeProof{
+		{
+			Start: start,
+			End:   end,
+			Proof: proof,
+		},
+	})
+}
+
+func (db *merkleDB) CommitRangeProofs(ctx context.Context, proofs []KeyRangeProof) error {
 	db.commitLock.Lock()
 	defer db.commitLock.Unlock()
 
@@ -400,6 +491,28 @@ func (db *merkleDB) CommitRangeProof(ctx context.Context, start, end maybe.Maybe
 		return database.ErrClosed
 	}
 
+	var ops []database.BatchOp
+	for _, proof := range proofs {
+		proofOps, err := db.getRangeProofOps(proof.Start, proof.End, proof.Proof)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, proofOps...)
+	}
+
+	// Don't need to lock [view] because nobody else has a reference to it.
+	view, err := newView(db, db, ViewChanges{BatchOps: ops})
+	if err != nil {
+		return err
+	}
+
+	return view.commitToDB(ctx)
+}
+
+// getRangeProofOps returns the operations that make the key/value pairs in
+// [start, end] match [proof].
+// Assumes [db.commitLock] is held.
+func (db *merkleDB) getRangeProofOps(start, end maybe.Maybe[[]byte], proof *RangeProof) ([]database.BatchOp, error) {
 	ops := make([]database.BatchOp, len(proof.KeyValues))
 	keys := set.NewSet[string](len(proof.KeyValues))
 	for i, kv := range proof.KeyValues {
@@ -416,7 +529,7 @@ func (db *merkleDB) CommitRangeProof(ctx context.Context, start, end maybe.Maybe
 	}
 	keysToDelete, err := db.getKeysNotInSet(start, largestKey, keys)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	for _, keyToDelete := range keysToDelete {
 		ops = append(ops, database.BatchOp{
@@ -424,14 +537,7 @@ func (db *merkleDB) CommitRangeProof(ctx context.Context, start, end maybe.Maybe
 			Delete: true,
 		})
 	}
-
-	// Don't need to lock [view] because nobody else has a reference to it.
-	view, err := newView(db, db, ViewChanges{BatchOps: ops})
-	if err != nil {
-		return err
-	}
-
-	return view.commitToDB(ctx)
+	return ops, nil
 }
 
 func (db *merkleDB) Compact(start []byte, limit []byte) error {
@@ -792,6 +898,21 @@ func (db *merkleDB) NewView(
 	return view, nil
 }
 
+func (db *merkleDB) ViewAtRoot(ctx context.Context, rootID ids.ID) (ReadOnlyView, error) {
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.ViewAtRoot")
+	defer span.End()
+
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	historicalView := newHistoricalView(db, rootID)
+	// Make sure there's enough history to build the view.
+	if _, err := historicalView.getView(); err != nil {
+		return nil, err
+	}
+	return historicalView, nil
+}
+
 func (db *merkleDB) Has(k []byte) (bool, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -940,6 +1061,9 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 	}
 
 	currentValueNodeBatch := db.valueNodeDB.NewBatch()
+	// The value nodes, the history, and the root are written in a single batch
+	// so that they are consistent on disk.
+	batch := db.baseDB.NewBatch()
 	_, nodesSpan := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.writeNodes")
 	for key, nodeChange := range changes.nodes {
 		shouldAddIntermediate := nodeChange.after != nil && !nodeChange.after.hasValue()
@@ -968,25 +1092,36 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 	}
 	nodesSpan.End()
 
-	_, commitSpan := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.valueNodeDBCommit")
-	err := currentValueNodeBatch.Write()
-	commitSpan.End()
-	if err != nil {
+	if err := currentValueNodeBatch.writeTo(batch); err != nil {
 		return err
 	}
 
-	db.history.record(changes)
+	recordHistory, err := db.history.recordTo(batch, changes)
+	if err != nil {
+		return err
+	}
 
 	// Update root in database.
-	db.root = changes.rootChange.after
-	db.rootID = changes.rootID
+	if root := changes.rootChange.after; root.IsNothing() {
+		err = batch.Delete(rootDBKey)
+	} else {
+		err = batch.Put(rootDBKey, codec.encodeKey(root.Value().key))
+	}
+	if err != nil {
+		return err
+	}
 
-	if db.root.IsNothing() {
-		return db.baseDB.Delete(rootDBKey)
+	_, commitSpan := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.valueNodeDBCommit")
+	err = batch.Write()
+	commitSpan.End()
+	if err != nil {
+		return err
 	}
 
-	rootKey := codec.encodeKey(db.root.Value().key)
-	return db.baseDB.Put(rootDBKey, rootKey)
+	recordHistory()
+	db.root = changes.rootChange.after
+	db.rootID = changes.rootID
+	return nil
 }
 
 // moveChildViewsToDB removes any child views from the trieToCommit and moves them to the db
@@ -1223,6 +1358,46 @@ func (db *merkleDB) getTrieAtRootForRange(
 	return newViewWithChanges(db, changeHistory)
 }
 
+// Returns a view of the trie as it was when it had root [rootID].
+// The view is invalidated when changes are committed to the db.
+// Assumes [db.commitLock] is read locked.
+// Assumes [db.lock] isn't held.
+func (db *merkleDB) getViewAtRoot(rootID ids.ID) (*view, error) {
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	var changes *changeSummary
+	if rootID == db.getMerkleRoot() {
+		changes = &changeSummary{
+			rootChange: change[maybe.Maybe[*node]]{
+				after: db.root,
+			},
+			nodes:  map[Key]*change[*node]{},
+			values: map[Key]*change[maybe.Maybe[[]byte]]{},
+		}
+	} else {
+		var err error
+		changes, err = db.history.getChangesToGetToRoot(rootID, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]())
+		if err != nil {
+			return nil, err
+		}
+	}
+	changes.rootID = rootID
+
+	view, err := newViewWithChanges(db, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track the view so that it's invalidated when the db changes.
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.childViews = append(db.childViews, view)
+	return view, nil
+}
+
 // Returns all keys in range [start, end] that aren't in [keySet].
 // If [start] is Nothing, then the range has no lower bound.
 // If [end] is Nothing, then the range has no upper bound.
@@ -1292,6 +1467,9 @@ func (db *merkleDB) Clear() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
+	// mark all children as no longer valid because the db is changing
+	db.invalidateChildrenExcept(nil)
+
 	// Clear nodes from disk and caches
 	if err := db.valueNodeDB.Clear(); err != nil {
 		return err
@@ -1305,13 +1483,14 @@ func (db *merkleDB) Clear() error {
 	db.rootID = ids.Empty
 
 	// Clear history
-	db.history = newTrieHistory(db.history.maxHistoryLen)
-	db.history.record(&changeSummary{
+	if err := db.history.reset(); err != nil {
+		return err
+	}
+	return db.history.record(&changeSummary{
 		rootID: db.rootID,
 		values: map[Key]*change[maybe.Maybe[[]byte]]{},
 		nodes:  map[Key]*change[*node]{},
 	})
-	return nil
 }
 
 func (db *merkleDB) getTokenSize() int {
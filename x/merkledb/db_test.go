@@ -387,6 +387,74 @@ func Test_MerkleDB_CommitRangeProof_EmptyTrie(t *testing.T) {
 	require.Equal(db1Root, db2Root)
 }
 
+func Test_MerkleDB_CommitRangeProofs(t *testing.T) {
+	require := require.New(t)
+
+	// Populate [db1] with 4 key-value pairs.
+	db1, err := getBasicDB()
+	require.NoError(err)
+	batch := db1.NewBatch()
+	require.NoError(batch.Put([]byte("key1"), []byte("1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("2")))
+	require.NoError(batch.Put([]byte("key3"), []byte("3")))
+	require.NoError(batch.Put([]byte("key4"), []byte("4")))
+	require.NoError(batch.Write())
+
+	// Get proofs for the ranges [Nothing, key2] and [key3, Nothing].
+	proof1, err := db1.GetRangeProof(
+		context.Background(),
+		maybe.Nothing[[]byte](),
+		maybe.Some([]byte("key2")),
+		10,
+	)
+	require.NoError(err)
+	proof2, err := db1.GetRangeProof(
+		context.Background(),
+		maybe.Some([]byte("key3")),
+		maybe.Nothing[[]byte](),
+		10,
+	)
+	require.NoError(err)
+
+	// Populate [db2] with values that should be replaced or deleted by the
+	// proofs.
+	db2, err := getBasicDB()
+	require.NoError(err)
+	batch = db2.NewBatch()
+	require.NoError(batch.Put([]byte("key1"), []byte("5")))
+	require.NoError(batch.Put([]byte("key25"), []byte("6")))
+	require.NoError(batch.Put([]byte("key35"), []byte("7")))
+	require.NoError(batch.Write())
+
+	require.NoError(db2.CommitRangeProofs(context.Background(), []KeyRangeProof{
+		{
+			Start: maybe.Nothing[[]byte](),
+			End:   maybe.Some([]byte("key2")),
+			Proof: proof1,
+		},
+		{
+			Start: maybe.Some([]byte("key3")),
+			End:   maybe.Nothing[[]byte](),
+			Proof: proof2,
+		},
+	}))
+
+	// [db2] should have the same key-value pairs as [db1].
+	// Note that "key35" was in the range covered by [proof2], so it's
+	// deleted. "key25" isn't covered by either proof, so it isn't deleted.
+	_, err = db2.Get([]byte("key25"))
+	require.NoError(err)
+	require.NoError(db2.Delete([]byte("key25")))
+
+	db2Root, err := db2.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db1Root, err := db1.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	require.Equal(db1Root, db2Root)
+}
+
 func Test_MerkleDB_CommitRangeProof_TrieWithInitialValues(t *testing.T) {
 	require := require.New(t)
 
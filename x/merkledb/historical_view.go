@@ -0,0 +1,221 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+var (
+	_ ReadOnlyView      = (*historicalView)(nil)
+	_ database.Iterator = (*historicalViewIterator)(nil)
+)
+
+// historicalView is a read-only view of the trie at [rootID].
+//
+// It's backed by a view built from the change history. When changes are
+// committed to the db, that view is invalidated and is rebuilt from the
+// history on the next read. Once [rootID] is no longer in the history,
+// reads return [ErrInsufficientHistory].
+type historicalView struct {
+	db     *merkleDB
+	rootID ids.ID
+
+	// Must be held when reading/writing [view].
+	lock sync.Mutex
+	view *view
+}
+
+func newHistoricalView(db *merkleDB, rootID ids.ID) *historicalView {
+	return &historicalView{
+		db:     db,
+		rootID: rootID,
+	}
+}
+
+// Returns a valid view of the trie at [hv.rootID].
+// Assumes [hv.db.commitLock] is read locked.
+func (hv *historicalView) getView() (*view, error) {
+	hv.lock.Lock()
+	defer hv.lock.Unlock()
+
+	if hv.view != nil && !hv.view.isInvalid() {
+		return hv.view, nil
+	}
+
+	view, err := hv.db.getViewAtRoot(hv.rootID)
+	if err != nil {
+		return nil, err
+	}
+	hv.view = view
+	return view, nil
+}
+
+func (hv *historicalView) GetMerkleRoot(context.Context) (ids.ID, error) {
+	return hv.rootID, nil
+}
+
+func (hv *historicalView) GetValue(ctx context.Context, key []byte) ([]byte, error) {
+	hv.db.commitLock.RLock()
+	defer hv.db.commitLock.RUnlock()
+
+	view, err := hv.getView()
+	if err != nil {
+		return nil, err
+	}
+	return view.GetValue(ctx, key)
+}
+
+func (hv *historicalView) GetValues(ctx context.Context, keys [][]byte) ([][]byte, []error) {
+	hv.db.commitLock.RLock()
+	defer hv.db.commitLock.RUnlock()
+
+	view, err := hv.getView()
+	if err != nil {
+		values := make([][]byte, len(keys))
+		getErrors := make([]error, len(keys))
+		for i := range getErrors {
+			getErrors[i] = err
+		}
+		return values, getErrors
+	}
+	return view.GetValues(ctx, keys)
+}
+
+func (hv *historicalView) GetProof(ctx context.Context, key []byte) (*Proof, error) {
+	hv.db.commitLock.RLock()
+	defer hv.db.commitLock.RUnlock()
+
+	view, err := hv.getView()
+	if err != nil {
+		return nil, err
+	}
+	return view.GetProof(ctx, key)
+}
+
+func (hv *historicalView) GetRangeProof(
+	ctx context.Context,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*RangeProof, error) {
+	hv.db.commitLock.RLock()
+	defer hv.db.commitLock.RUnlock()
+
+	view, err := hv.getView()
+	if err != nil {
+		return nil, err
+	}
+	return view.GetRangeProof(ctx, start, end, maxLength)
+}
+
+func (hv *historicalView) NewIterator() database.Iterator {
+	return hv.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (hv *historicalView) NewIteratorWithStart(start []byte) database.Iterator {
+	return hv.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (hv *historicalView) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return hv.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (hv *historicalView) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &historicalViewIterator{
+		view:   hv,
+		start:  slices.Clone(start),
+		prefix: slices.Clone(prefix),
+	}
+}
+
+// historicalViewIterator iterates over the key/value pairs of a
+// [historicalView].
+// If changes are committed to the db during iteration, iteration resumes
+// after the last returned key on a rebuilt view.
+type historicalViewIterator struct {
+	view *historicalView
+
+	// The iterator over the current view of [view].
+	// Nil if it hasn't been created yet or the view it was created on
+	// was invalidated.
+	iter database.Iterator
+
+	// The next key/value pair returned will be >= [start].
+	start  []byte
+	prefix []byte
+
+	key, value []byte
+	err        error
+}
+
+func (it *historicalViewIterator) Next() bool {
+	it.key = nil
+	it.value = nil
+	if it.err != nil {
+		return false
+	}
+
+	// Prevent changes from being committed while we iterate.
+	it.view.db.commitLock.RLock()
+	defer it.view.db.commitLock.RUnlock()
+
+	for {
+		if it.iter == nil {
+			view, err := it.view.getView()
+			if err != nil {
+				it.err = err
+				return false
+			}
+			it.iter = view.NewIteratorWithStartAndPrefix(it.start, it.prefix)
+		}
+
+		if it.iter.Next() {
+			it.key = slices.Clone(it.iter.Key())
+			it.value = slices.Clone(it.iter.Value())
+			// The smallest key that's greater than [it.key].
+			it.start = append(slices.Clone(it.key), 0)
+			return true
+		}
+
+		err := it.iter.Error()
+		if !errors.Is(err, ErrInvalid) {
+			it.err = err
+			return false
+		}
+
+		// Changes were committed to the db since [it.iter] was created.
+		// Resume iteration on a rebuilt view.
+		it.iter.Release()
+		it.iter = nil
+	}
+}
+
+func (it *historicalViewIterator) Error() error {
+	return it.err
+}
+
+func (it *historicalViewIterator) Key() []byte {
+	return it.key
+}
+
+func (it *historicalViewIterator) Value() []byte {
+	return it.value
+}
+
+func (it *historicalViewIterator) Release() {
+	it.key = nil
+	it.value = nil
+	if it.iter != nil {
+		it.iter.Release()
+		it.iter = nil
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+)
+
+func TestViewAtRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	oldRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key1"), []byte("newValue1")))
+	require.NoError(db.Delete([]byte("key2")))
+	require.NoError(db.Put([]byte("key3"), []byte("value3")))
+
+	view, err := db.ViewAtRoot(context.Background(), oldRoot)
+	require.NoError(err)
+
+	root, err := view.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(oldRoot, root)
+
+	value, err := view.GetValue(context.Background(), []byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1"), value)
+
+	values, errs := view.GetValues(context.Background(), [][]byte{[]byte("key2"), []byte("key3")})
+	require.Equal([]byte("value2"), values[0])
+	require.NoError(errs[0])
+	require.Nil(values[1])
+	require.ErrorIs(errs[1], database.ErrNotFound)
+
+	proof, err := view.GetProof(context.Background(), []byte("key2"))
+	require.NoError(err)
+	require.Equal(maybe.Some([]byte("value2")), proof.Value)
+	require.NoError(proof.Verify(context.Background(), oldRoot, db.tokenSize))
+
+	rangeProof, err := view.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.NoError(rangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), oldRoot, db.tokenSize))
+
+	require.Equal(
+		[]KeyValue{
+			{Key: []byte("key1"), Value: []byte("value1")},
+			{Key: []byte("key2"), Value: []byte("value2")},
+		},
+		iterateAll(t, view.NewIterator()),
+	)
+
+	// The view should remain usable after further changes are committed.
+	require.NoError(db.Put([]byte("key2"), []byte("newValue2")))
+
+	value, err = view.GetValue(context.Background(), []byte("key2"))
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+
+	proof, err = view.GetProof(context.Background(), []byte("key1"))
+	require.NoError(err)
+	require.NoError(proof.Verify(context.Background(), oldRoot, db.tokenSize))
+}
+
+func TestViewAtRootCurrentRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	view, err := db.ViewAtRoot(context.Background(), root)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key"), []byte("newValue")))
+
+	value, err := view.GetValue(context.Background(), []byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestViewAtRootInsufficientHistory(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.HistoryLength = 2
+	db, err := New(context.Background(), memdb.New(), config)
+	require.NoError(err)
+
+	_, err = db.ViewAtRoot(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	view, err := db.ViewAtRoot(context.Background(), root)
+	require.NoError(err)
+
+	// Evict [root] from the history.
+	require.NoError(db.Put([]byte("key"), []byte("value1")))
+	require.NoError(db.Put([]byte("key"), []byte("value2")))
+
+	_, err = view.GetValue(context.Background(), []byte("key"))
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	it := view.NewIterator()
+	defer it.Release()
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrInsufficientHistory)
+}
+
+func TestViewAtRootIteratorResumesAfterCommit(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	view, err := db.ViewAtRoot(context.Background(), root)
+	require.NoError(err)
+
+	it := view.NewIteratorWithStart([]byte{1})
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal([]byte{1}, it.Key())
+	require.Equal([]byte{1}, it.Value())
+
+	// Modify the db in the middle of iteration.
+	require.NoError(db.Delete([]byte{2}))
+	require.NoError(db.Put([]byte{3}, []byte{10}))
+
+	for i := 2; i < 5; i++ {
+		require.True(it.Next())
+		require.Equal([]byte{byte(i)}, it.Key())
+		require.Equal([]byte{byte(i)}, it.Value())
+	}
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
+func TestViewAtRootClosed(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	view, err := db.ViewAtRoot(context.Background(), root)
+	require.NoError(err)
+
+	require.NoError(db.Close())
+
+	_, err = view.GetValue(context.Background(), []byte("key"))
+	require.ErrorIs(err, database.ErrClosed)
+}
+
+func iterateAll(t *testing.T, it database.Iterator) []KeyValue {
+	defer it.Release()
+
+	var keyValues []KeyValue
+	for it.Next() {
+		keyValues = append(keyValues, KeyValue{
+			Key:   it.Key(),
+			Value: it.Value(),
+		})
+	}
+	require.NoError(t, it.Error())
+	return keyValues
+}
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/maybe"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
 var (
@@ -28,6 +31,11 @@ type trieHistory struct {
 	// Maximum number of previous roots/changes to store in [history].
 	maxHistoryLen int
 
+	// Changes recorded more than [maxHistoryAge] ago are removed from
+	// [history] when a new change is recorded.
+	// If 0, changes are only removed to respect [maxHistoryLen].
+	maxHistoryAge time.Duration
+
 	// Contains the history.
 	// Sorted by increasing order of insertion.
 	// Contains at most [maxHistoryLen] values.
@@ -35,6 +43,12 @@ type trieHistory struct {
 
 	// Each change is tagged with this monotonic increasing number.
 	nextInsertNumber uint64
+
+	// If non-nil, every change in [history] is also written to [historyDB]
+	// so that the history can be restored after a restart.
+	historyDB *historyDB
+
+	clock mockable.Clock
 }
 
 // Tracks the beginning and ending state of a value.
@@ -50,6 +64,8 @@ type changeSummaryAndInsertNumber struct {
 	// Another changeSummaryAndInsertNumber with a greater
 	// [insertNumber] means that change was after this one.
 	insertNumber uint64
+	// The time at which this change was recorded.
+	recordedAt time.Time
 }
 
 // Tracks all the node and value changes that resulted in the rootID.
@@ -71,11 +87,13 @@ func newChangeSummary(estimatedSize int) *changeSummary {
 	}
 }
 
-func newTrieHistory(maxHistoryLookback int) *trieHistory {
+func newTrieHistory(maxHistoryLookback int, maxHistoryAge time.Duration, historyDB *historyDB) *trieHistory {
 	return &trieHistory{
 		maxHistoryLen: maxHistoryLookback,
+		maxHistoryAge: maxHistoryAge,
 		history:       buffer.NewUnboundedDeque[*changeSummaryAndInsertNumber](maxHistoryLookback),
 		lastChanges:   make(map[ids.ID]*changeSummaryAndInsertNumber),
+		historyDB:     historyDB,
 	}
 }
 
@@ -287,33 +305,174 @@ func (th *trieHistory) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]
 }
 
 // record the provided set of changes in the history
-func (th *trieHistory) record(changes *changeSummary) {
+func (th *trieHistory) record(changes *changeSummary) error {
+	if th.historyDB == nil {
+		apply, err := th.recordTo(nil, changes)
+		if err != nil {
+			return err
+		}
+		apply()
+		return nil
+	}
+
+	batch := th.historyDB.baseDB.NewBatch()
+	apply, err := th.recordTo(batch, changes)
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// recordTo writes the changes to the persisted history that are needed to
+// record [changes] to [batch], which must be a batch of [th.historyDB]'s
+// database. [batch] is only used if the history is persisted.
+//
+// The returned function records [changes] in memory. It must be called once
+// [batch] was written, so that the history in memory matches the history on
+// disk.
+func (th *trieHistory) recordTo(batch database.KeyValueWriterDeleter, changes *changeSummary) (func(), error) {
 	// we aren't recording history so noop
 	if th.maxHistoryLen == 0 {
-		return
+		return func() {}, nil
 	}
 
-	if th.history.Len() == th.maxHistoryLen {
-		// This change causes us to go over our lookback limit.
-		// Remove the oldest set of changes.
-		oldestEntry, _ := th.history.PopLeft()
+	var (
+		now        = th.clock.UnixTime()
+		numRemoved int
+	)
+	for numRemoved < th.history.Len() {
+		oldestEntry, _ := th.history.Index(numRemoved)
+		if th.history.Len()-numRemoved < th.maxHistoryLen && !th.isExpired(oldestEntry, now) {
+			break
+		}
 
-		latestChange := th.lastChanges[oldestEntry.rootID]
-		if latestChange == oldestEntry {
-			// The removed change was the most recent resulting in this root ID.
-			delete(th.lastChanges, oldestEntry.rootID)
+		// This change causes us to go over our lookback limit or the
+		// oldest change is too old to keep.
+		// Remove the oldest set of changes.
+		if th.historyDB != nil {
+			if err := th.historyDB.Delete(batch, oldestEntry.insertNumber); err != nil {
+				return nil, err
+			}
 		}
+		numRemoved++
 	}
 
 	changesAndIndex := &changeSummaryAndInsertNumber{
 		changeSummary: changes,
 		insertNumber:  th.nextInsertNumber,
+		recordedAt:    now,
+	}
+	if th.historyDB != nil {
+		if err := th.historyDB.Put(batch, changesAndIndex); err != nil {
+			return nil, err
+		}
+	}
+	return func() {
+		for i := 0; i < numRemoved; i++ {
+			th.removeOldest()
+		}
+		th.nextInsertNumber++
+		th.add(changesAndIndex)
+	}, nil
+}
+
+// restore loads the changes written to [th.historyDB] into the history.
+// The stored changes are only kept if the most recent of them resulted in
+// [currentRootID]. Otherwise, they don't lead to the current state of the
+// trie and are removed from disk.
+// Returns true iff the stored changes were loaded.
+// Assumes the history is empty.
+func (th *trieHistory) restore(currentRootID ids.ID) (bool, error) {
+	if th.historyDB == nil {
+		return false, nil
 	}
-	th.nextInsertNumber++
 
+	storedChanges, err := th.historyDB.GetAll()
+	if err != nil {
+		return false, err
+	}
+
+	if th.maxHistoryLen == 0 ||
+		len(storedChanges) == 0 ||
+		storedChanges[len(storedChanges)-1].rootID != currentRootID ||
+		!isContiguous(storedChanges) {
+		return false, th.historyDB.Clear()
+	}
+
+	for _, changes := range storedChanges {
+		th.add(changes)
+	}
+	th.nextInsertNumber = storedChanges[len(storedChanges)-1].insertNumber + 1
+
+	// The limits may have changed since the changes were written, so remove
+	// any changes that no longer fit in the history. The most recent change
+	// is always kept so that the current root remains in the history.
+	var (
+		now   = th.clock.UnixTime()
+		batch = th.historyDB.baseDB.NewBatch()
+	)
+	for th.history.Len() > 1 {
+		oldestEntry, _ := th.history.PeekLeft()
+		if th.history.Len() <= th.maxHistoryLen && !th.isExpired(oldestEntry, now) {
+			break
+		}
+		if err := th.historyDB.Delete(batch, oldestEntry.insertNumber); err != nil {
+			return false, err
+		}
+		th.removeOldest()
+	}
+	return true, batch.Write()
+}
+
+// reset removes all changes from the history.
+func (th *trieHistory) reset() error {
+	th.lastChanges = make(map[ids.ID]*changeSummaryAndInsertNumber)
+	th.history = buffer.NewUnboundedDeque[*changeSummaryAndInsertNumber](th.maxHistoryLen)
+	th.nextInsertNumber = 0
+
+	if th.historyDB == nil {
+		return nil
+	}
+	return th.historyDB.Clear()
+}
+
+// add appends [changes] to the history.
+func (th *trieHistory) add(changes *changeSummaryAndInsertNumber) {
 	// Add [changes] to the sorted change list.
-	_ = th.history.PushRight(changesAndIndex)
+	_ = th.history.PushRight(changes)
 
 	// Mark that this is the most recent change resulting in [changes.rootID].
-	th.lastChanges[changes.rootID] = changesAndIndex
+	th.lastChanges[changes.rootID] = changes
+}
+
+// removeOldest removes the oldest change from the history in memory.
+// Assumes the history is non-empty.
+func (th *trieHistory) removeOldest() {
+	oldestEntry, _ := th.history.PopLeft()
+
+	latestChange := th.lastChanges[oldestEntry.rootID]
+	if latestChange == oldestEntry {
+		// The removed change was the most recent resulting in this root ID.
+		delete(th.lastChanges, oldestEntry.rootID)
+	}
+}
+
+// Returns true iff [changes] was recorded more than [th.maxHistoryAge]
+// before [now].
+func (th *trieHistory) isExpired(changes *changeSummaryAndInsertNumber, now time.Time) bool {
+	return th.maxHistoryAge > 0 && now.Sub(changes.recordedAt) > th.maxHistoryAge
+}
+
+// Returns true iff the insert numbers of [changes] are consecutive.
+func isContiguous(changes []*changeSummaryAndInsertNumber) bool {
+	for i := 1; i < len(changes); i++ {
+		if changes[i].insertNumber != changes[i-1].insertNumber+1 {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	historyKeyLen      = historyPrefixLen + wrappers.LongLen
+	historyValueHeader = wrappers.LongLen
+)
+
+var (
+	errInvalidHistoryKey   = errors.New("invalid history key")
+	errInvalidHistoryValue = errors.New("invalid history value")
+)
+
+// Holds the changes recorded in the trie history so that they can
+// be restored after a restart.
+type historyDB struct {
+	// The underlying storage.
+	// Keys written to [baseDB] are prefixed with [historyPrefix] and
+	// followed by the big-endian insert number of the change, so
+	// iterating over [historyPrefix] yields changes in the order
+	// they were recorded.
+	baseDB database.Database
+}
+
+func newHistoryDB(db database.Database) *historyDB {
+	return &historyDB{
+		baseDB: db,
+	}
+}
+
+// Put writes [changes] to [w], which must be [db.baseDB] or one of its
+// batches.
+func (*historyDB) Put(w database.KeyValueWriter, changes *changeSummaryAndInsertNumber) error {
+	changesBytes := codec.encodeChangeSummary(changes.changeSummary)
+	value := make([]byte, historyValueHeader, historyValueHeader+len(changesBytes))
+	copy(value, database.PackUInt64(uint64(changes.recordedAt.Unix())))
+	value = append(value, changesBytes...)
+	return w.Put(historyKey(changes.insertNumber), value)
+}
+
+// Delete removes the change with the given [insertNumber] from [w], which must
+// be [db.baseDB] or one of its batches.
+func (*historyDB) Delete(w database.KeyValueDeleter, insertNumber uint64) error {
+	return w.Delete(historyKey(insertNumber))
+}
+
+// GetAll returns every change on disk sorted by increasing insert number.
+func (db *historyDB) GetAll() ([]*changeSummaryAndInsertNumber, error) {
+	it := db.baseDB.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+
+	var changes []*changeSummaryAndInsertNumber
+	for it.Next() {
+		key := it.Key()
+		if len(key) != historyKeyLen {
+			return nil, errInvalidHistoryKey
+		}
+		insertNumber, err := database.ParseUInt64(key[historyPrefixLen:])
+		if err != nil {
+			return nil, err
+		}
+
+		value := it.Value()
+		if len(value) < historyValueHeader {
+			return nil, errInvalidHistoryValue
+		}
+		recordedAt, err := database.ParseUInt64(value[:historyValueHeader])
+		if err != nil {
+			return nil, err
+		}
+		changeSummary, err := codec.decodeChangeSummary(value[historyValueHeader:])
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, &changeSummaryAndInsertNumber{
+			changeSummary: changeSummary,
+			insertNumber:  insertNumber,
+			recordedAt:    time.Unix(int64(recordedAt), 0),
+		})
+	}
+	return changes, it.Error()
+}
+
+// Clear removes every change from disk.
+func (db *historyDB) Clear() error {
+	return database.AtomicClearPrefix(db.baseDB, db.baseDB, historyPrefix)
+}
+
+func historyKey(insertNumber uint64) []byte {
+	key := make([]byte, 0, historyKeyLen)
+	key = append(key, historyPrefix...)
+	return append(key, database.PackUInt64(insertNumber)...)
+}
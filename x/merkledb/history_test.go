@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/maybe"
@@ -573,13 +574,13 @@ func TestHistoryRecord(t *testing.T) {
 	require := require.New(t)
 
 	maxHistoryLen := 3
-	th := newTrieHistory(maxHistoryLen)
+	th := newTrieHistory(maxHistoryLen, 0, nil)
 
 	changes := []*changeSummary{}
 	for i := 0; i < maxHistoryLen; i++ { // Fill the history
 		changes = append(changes, &changeSummary{rootID: ids.GenerateTestID()})
 
-		th.record(changes[i])
+		require.NoError(th.record(changes[i]))
 		require.Equal(uint64(i+1), th.nextInsertNumber)
 		require.Equal(i+1, th.history.Len())
 		require.Len(th.lastChanges, i+1)
@@ -594,7 +595,7 @@ func TestHistoryRecord(t *testing.T) {
 
 	// Add a new change
 	change3 := &changeSummary{rootID: ids.GenerateTestID()}
-	th.record(change3)
+	require.NoError(th.record(change3))
 	// history is [changes[1], changes[2], change3]
 	require.Equal(uint64(maxHistoryLen+1), th.nextInsertNumber)
 	require.Equal(maxHistoryLen, th.history.Len())
@@ -614,11 +615,11 @@ func TestHistoryRecord(t *testing.T) {
 
 	// Add another change which was the same root ID as changes[2]
 	change4 := &changeSummary{rootID: changes[2].rootID}
-	th.record(change4)
+	require.NoError(th.record(change4))
 	// history is [changes[2], change3, change4]
 
 	change5 := &changeSummary{rootID: ids.GenerateTestID()}
-	th.record(change5)
+	require.NoError(th.record(change5))
 	// history is [change3, change4, change5]
 
 	// Make sure that even though changes[2] was evicted, we still remember
@@ -646,7 +647,7 @@ func TestHistoryRecord(t *testing.T) {
 
 func TestHistoryGetChangesToRoot(t *testing.T) {
 	maxHistoryLen := 3
-	history := newTrieHistory(maxHistoryLen)
+	history := newTrieHistory(maxHistoryLen, 0, nil)
 
 	changes := []*changeSummary{}
 	for i := 0; i < maxHistoryLen; i++ { // Fill the history
@@ -668,7 +669,7 @@ func TestHistoryGetChangesToRoot(t *testing.T) {
 				},
 			},
 		})
-		history.record(changes[i])
+		require.NoError(t, history.record(changes[i]))
 	}
 
 	type test struct {
@@ -775,3 +776,236 @@ func TestHistoryGetChangesToRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoryPersisted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.PersistHistory = true
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	startRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put([]byte("key2"), []byte("value2")))
+	require.NoError(batch.Delete([]byte("key1")))
+	require.NoError(batch.Write())
+	endRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	expectedProof, err := db.GetChangeProof(context.Background(), startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	config = newDefaultConfig()
+	config.PersistHistory = true
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.Equal(3, db.history.history.Len())
+	require.Equal(uint64(3), db.history.nextInsertNumber)
+
+	// The change proof generated before the restart should still be
+	// available.
+	proof, err := db.GetChangeProof(context.Background(), startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Equal(expectedProof, proof)
+
+	// The proof should be verifiable against a database at [startRoot].
+	otherDB, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(otherDB.Put([]byte("key1"), []byte("value1")))
+	require.NoError(otherDB.VerifyChangeProof(context.Background(), proof, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), endRoot))
+
+	rangeProof, err := db.GetRangeProofAtRoot(context.Background(), startRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.NoError(rangeProof.Verify(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), startRoot, db.tokenSize))
+
+	// New changes should continue to be persisted.
+	require.NoError(db.Put([]byte("key3"), []byte("value3")))
+	require.Equal(4, countStoredHistory(t, baseDB))
+}
+
+func TestHistoryNotPersisted(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := newDB(context.Background(), baseDB, newDefaultConfig())
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	startRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	endRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(db.Close())
+	require.Zero(countStoredHistory(t, baseDB))
+
+	db, err = newDB(context.Background(), baseDB, newDefaultConfig())
+	require.NoError(err)
+
+	_, err = db.GetChangeProof(context.Background(), startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
+func TestHistoryPersistedPrunedOnRestart(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.PersistHistory = true
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	require.NoError(db.Close())
+	require.Equal(6, countStoredHistory(t, baseDB))
+
+	config = newDefaultConfig()
+	config.PersistHistory = true
+	config.HistoryLength = 2
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.Equal(2, db.history.history.Len())
+	require.Equal(2, countStoredHistory(t, baseDB))
+
+	oldestChange, ok := db.history.history.PeekLeft()
+	require.True(ok)
+	require.Equal(uint64(4), oldestChange.insertNumber)
+}
+
+func TestHistoryPersistedDiscardedOnRootMismatch(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.PersistHistory = true
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Close())
+	require.Equal(2, countStoredHistory(t, baseDB))
+
+	// Modify the trie without persisting the history.
+	db, err = newDB(context.Background(), baseDB, newDefaultConfig())
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	// The stored history no longer leads to the current root,
+	// so it should be discarded.
+	config = newDefaultConfig()
+	config.PersistHistory = true
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.Equal(1, db.history.history.Len())
+	require.Equal(1, countStoredHistory(t, baseDB))
+	require.Contains(db.history.lastChanges, root)
+}
+
+func TestHistoryMaxAge(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.PersistHistory = true
+	config.HistoryMaxAge = time.Hour
+	baseDB := memdb.New()
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	now := time.Now()
+	db.history.clock.Set(now)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	oldRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db.history.clock.Set(now.Add(30 * time.Minute))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+	require.Contains(db.history.lastChanges, oldRoot)
+
+	// Both the initial change and the change resulting in [oldRoot]
+	// are now older than [config.HistoryMaxAge].
+	db.history.clock.Set(now.Add(90 * time.Minute))
+	require.NoError(db.Put([]byte("key3"), []byte("value3")))
+	require.NotContains(db.history.lastChanges, oldRoot)
+	require.Equal(2, db.history.history.Len())
+	require.Equal(2, countStoredHistory(t, baseDB))
+}
+
+// failingBatchDB is a database whose batches fail to be written once
+// [failWrites] is set.
+type failingBatchDB struct {
+	database.Database
+	failWrites bool
+}
+
+func (db *failingBatchDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+	db *failingBatchDB
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.failWrites {
+		return errTest
+	}
+	return b.Batch.Write()
+}
+
+func TestHistoryPersistedAtomically(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := &failingBatchDB{
+		Database: memdb.New(),
+	}
+	config := newDefaultConfig()
+	config.PersistHistory = true
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(2, db.history.history.Len())
+	require.Equal(2, countStoredHistory(t, baseDB))
+
+	// If the commit fails, neither the trie nor the history is changed.
+	baseDB.failWrites = true
+	err = db.Put([]byte("key2"), []byte("value2"))
+	require.ErrorIs(err, errTest)
+
+	require.Equal(root, db.rootID)
+	require.Equal(2, db.history.history.Len())
+	require.Equal(uint64(2), db.history.nextInsertNumber)
+	require.Equal(2, countStoredHistory(t, baseDB))
+
+	rootKey, err := baseDB.Get(rootDBKey)
+	require.NoError(err)
+	require.Equal(codec.encodeKey(db.root.Value().key), rootKey)
+}
+
+func countStoredHistory(t *testing.T, db database.Database) int {
+	it := db.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Error())
+	return count
+}
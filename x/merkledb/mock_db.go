@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,Prefetcher
+//	mockgen -source=x/merkledb/db.go -destination=x/merkledb/mock_db.go -package=merkledb -exclude_interfaces=ChangeProofer,RangeProofer,Clearer,Prefetcher,Snapshotter,HistoricalViewer,RangeProofBatchCommitter
 //
 
 // Package merkledb is a generated GoMock package.
@@ -11,6 +11,7 @@ package merkledb
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	database "github.com/ava-labs/avalanchego/database"
@@ -98,6 +99,20 @@ func (mr *MockMerkleDBMockRecorder) CommitRangeProof(ctx, start, end, proof any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitRangeProof", reflect.TypeOf((*MockMerkleDB)(nil).CommitRangeProof), ctx, start, end, proof)
 }
 
+// CommitRangeProofs mocks base method.
+func (m *MockMerkleDB) CommitRangeProofs(ctx context.Context, proofs []KeyRangeProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitRangeProofs", ctx, proofs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitRangeProofs indicates an expected call of CommitRangeProofs.
+func (mr *MockMerkleDBMockRecorder) CommitRangeProofs(ctx, proofs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitRangeProofs", reflect.TypeOf((*MockMerkleDB)(nil).CommitRangeProofs), ctx, proofs)
+}
+
 // Compact mocks base method.
 func (m *MockMerkleDB) Compact(start, limit []byte) error {
 	m.ctrl.T.Helper()
@@ -126,6 +141,20 @@ func (mr *MockMerkleDBMockRecorder) Delete(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMerkleDB)(nil).Delete), key)
 }
 
+// ExportSnapshot mocks base method.
+func (m *MockMerkleDB) ExportSnapshot(ctx context.Context, rootID ids.ID, chunkSize int, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSnapshot", ctx, rootID, chunkSize, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSnapshot indicates an expected call of ExportSnapshot.
+func (mr *MockMerkleDBMockRecorder) ExportSnapshot(ctx, rootID, chunkSize, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSnapshot", reflect.TypeOf((*MockMerkleDB)(nil).ExportSnapshot), ctx, rootID, chunkSize, w)
+}
+
 // Get mocks base method.
 func (m *MockMerkleDB) Get(key []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -276,6 +305,21 @@ func (mr *MockMerkleDBMockRecorder) HealthCheck(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockMerkleDB)(nil).HealthCheck), arg0)
 }
 
+// ImportSnapshot mocks base method.
+func (m *MockMerkleDB) ImportSnapshot(ctx context.Context, r io.Reader) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSnapshot", ctx, r)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSnapshot indicates an expected call of ImportSnapshot.
+func (mr *MockMerkleDBMockRecorder) ImportSnapshot(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSnapshot", reflect.TypeOf((*MockMerkleDB)(nil).ImportSnapshot), ctx, r)
+}
+
 // NewBatch mocks base method.
 func (m *MockMerkleDB) NewBatch() database.Batch {
 	m.ctrl.T.Helper()
@@ -417,6 +461,21 @@ func (mr *MockMerkleDBMockRecorder) VerifyChangeProof(ctx, proof, start, end, ex
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChangeProof", reflect.TypeOf((*MockMerkleDB)(nil).VerifyChangeProof), ctx, proof, start, end, expectedEndRootID)
 }
 
+// ViewAtRoot mocks base method.
+func (m *MockMerkleDB) ViewAtRoot(ctx context.Context, rootID ids.ID) (ReadOnlyView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewAtRoot", ctx, rootID)
+	ret0, _ := ret[0].(ReadOnlyView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ViewAtRoot indicates an expected call of ViewAtRoot.
+func (mr *MockMerkleDBMockRecorder) ViewAtRoot(ctx, rootID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAtRoot", reflect.TypeOf((*MockMerkleDB)(nil).ViewAtRoot), ctx, rootID)
+}
+
 // getEditableNode mocks base method.
 func (m *MockMerkleDB) getEditableNode(key Key, hasValue bool) (*node, error) {
 	m.ctrl.T.Helper()
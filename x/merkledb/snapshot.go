@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/maybe"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+const (
+	snapshotVersion uint16 = 0
+
+	// version + branch factor + root ID
+	snapshotHeaderLen = wrappers.ShortLen + wrappers.ShortLen + ids.IDLen
+
+	// The maximum size of a single encoded chunk that will be read when
+	// importing a snapshot.
+	maxSnapshotChunkSize = 256 * units.MiB
+)
+
+var (
+	ErrUnknownSnapshotVersion = errors.New("unknown snapshot version")
+	ErrSnapshotBranchFactor   = errors.New("snapshot branch factor doesn't match the database")
+	ErrSnapshotChunkTooLarge  = errors.New("snapshot chunk too large")
+	ErrSnapshotRootMismatch   = errors.New("imported root doesn't match the snapshot root")
+	ErrDatabaseNotEmpty       = errors.New("database isn't empty")
+)
+
+// A snapshot is written as:
+//
+//   - A header containing the snapshot version, the branch factor of the
+//     trie and the root ID of the trie.
+//   - A sequence of chunks. Each chunk is a uint32 length followed by a
+//     protobuf encoded range proof. The key/value pairs of consecutive chunks
+//     are in increasing order and the range proof of each chunk is verified
+//     against the root ID in the header when the snapshot is imported.
+//   - A zero length, which marks the end of the snapshot.
+func (db *merkleDB) ExportSnapshot(ctx context.Context, rootID ids.ID, chunkSize int, w io.Writer) error {
+	ctx, span := db.infoTracer.Start(ctx, "MerkleDB.ExportSnapshot")
+	defer span.End()
+
+	if chunkSize <= 0 {
+		return fmt.Errorf("%w but was %d", ErrInvalidMaxLength, chunkSize)
+	}
+
+	header := make([]byte, snapshotHeaderLen)
+	binary.BigEndian.PutUint16(header, snapshotVersion)
+	binary.BigEndian.PutUint16(header[wrappers.ShortLen:], uint16(db.branchFactor()))
+	copy(header[2*wrappers.ShortLen:], rootID[:])
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	// An empty trie has no key/value pairs to write.
+	if rootID == ids.Empty {
+		return writeSnapshotChunk(w, nil)
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, maybe.Nothing[[]byte](), chunkSize)
+		if err != nil {
+			return err
+		}
+
+		proofBytes, err := proto.Marshal(proof.ToProto())
+		if err != nil {
+			return err
+		}
+		if err := writeSnapshotChunk(w, proofBytes); err != nil {
+			return err
+		}
+
+		if len(proof.KeyValues) < chunkSize {
+			// There are no key/value pairs after this chunk.
+			return writeSnapshotChunk(w, nil)
+		}
+
+		// The next chunk starts at the smallest key that is greater than
+		// the last key in this chunk.
+		lastKey := proof.KeyValues[len(proof.KeyValues)-1].Key
+		start = maybe.Some(append(slices.Clone(lastKey), 0))
+	}
+}
+
+// ImportSnapshot reads a snapshot written by [ExportSnapshot] from [r] and
+// commits its key/value pairs.
+// If an error is returned, the database may contain a subset of the
+// snapshot's key/value pairs.
+func (db *merkleDB) ImportSnapshot(ctx context.Context, r io.Reader) (ids.ID, error) {
+	ctx, span := db.infoTracer.Start(ctx, "MerkleDB.ImportSnapshot")
+	defer span.End()
+
+	currentRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if currentRootID != ids.Empty {
+		return ids.Empty, ErrDatabaseNotEmpty
+	}
+
+	header := make([]byte, snapshotHeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return ids.Empty, err
+	}
+	if version := binary.BigEndian.Uint16(header); version != snapshotVersion {
+		return ids.Empty, fmt.Errorf("%w: %d", ErrUnknownSnapshotVersion, version)
+	}
+	if branchFactor := BranchFactor(binary.BigEndian.Uint16(header[wrappers.ShortLen:])); branchFactor != db.branchFactor() {
+		return ids.Empty, fmt.Errorf("%w: snapshot has %d, database has %d", ErrSnapshotBranchFactor, branchFactor, db.branchFactor())
+	}
+	rootID, err := ids.ToID(header[2*wrappers.ShortLen:])
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		chunk, err := readSnapshotChunk(r)
+		if err != nil {
+			return ids.Empty, err
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		var pbProof pb.RangeProof
+		if err := proto.Unmarshal(chunk, &pbProof); err != nil {
+			return ids.Empty, err
+		}
+		var proof RangeProof
+		if err := proof.UnmarshalProto(&pbProof); err != nil {
+			return ids.Empty, err
+		}
+
+		if err := proof.Verify(ctx, start, maybe.Nothing[[]byte](), rootID, db.tokenSize); err != nil {
+			return ids.Empty, err
+		}
+		if err := db.CommitRangeProof(ctx, start, maybe.Nothing[[]byte](), &proof); err != nil {
+			return ids.Empty, err
+		}
+
+		if len(proof.KeyValues) == 0 {
+			continue
+		}
+		lastKey := proof.KeyValues[len(proof.KeyValues)-1].Key
+		start = maybe.Some(append(slices.Clone(lastKey), 0))
+	}
+
+	importedRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if importedRootID != rootID {
+		return ids.Empty, fmt.Errorf("%w: imported %s, expected %s", ErrSnapshotRootMismatch, importedRootID, rootID)
+	}
+	return rootID, nil
+}
+
+func (db *merkleDB) branchFactor() BranchFactor {
+	return tokenSizeToBranchFactor[db.tokenSize]
+}
+
+// writeSnapshotChunk writes the length of [chunk] followed by [chunk] to [w].
+func writeSnapshotChunk(w io.Writer, chunk []byte) error {
+	chunkLen := make([]byte, wrappers.IntLen)
+	binary.BigEndian.PutUint32(chunkLen, uint32(len(chunk)))
+	if _, err := w.Write(chunkLen); err != nil {
+		return err
+	}
+	_, err := w.Write(chunk)
+	return err
+}
+
+// readSnapshotChunk reads a chunk written by [writeSnapshotChunk] from [r].
+func readSnapshotChunk(r io.Reader) ([]byte, error) {
+	chunkLenBytes := make([]byte, wrappers.IntLen)
+	if _, err := io.ReadFull(r, chunkLenBytes); err != nil {
+		return nil, err
+	}
+	chunkLen := binary.BigEndian.Uint32(chunkLenBytes)
+	if chunkLen > maxSnapshotChunkSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrSnapshotChunkTooLarge, chunkLen, maxSnapshotChunkSize)
+	}
+
+	chunk := make([]byte, chunkLen)
+	if _, err := io.ReadFull(r, chunk); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return chunk, nil
+}
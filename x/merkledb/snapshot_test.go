@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+
+	pb "github.com/ava-labs/avalanchego/proto/pb/sync"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	for _, chunkSize := range []int{1, 7, 100, 1000} {
+		require := require.New(t)
+
+		db, err := getBasicDB()
+		require.NoError(err)
+		require.NoError(putRandomKeyValues(db, r, 200))
+
+		rootID, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+
+		var snapshot bytes.Buffer
+		require.NoError(db.ExportSnapshot(context.Background(), rootID, chunkSize, &snapshot))
+
+		importedDB, err := getBasicDB()
+		require.NoError(err)
+		importedRootID, err := importedDB.ImportSnapshot(context.Background(), &snapshot)
+		require.NoError(err)
+		require.Equal(rootID, importedRootID)
+
+		gotRootID, err := importedDB.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		require.Equal(rootID, gotRootID)
+
+		it := db.NewIterator()
+		for it.Next() {
+			value, err := importedDB.Get(it.Key())
+			require.NoError(err)
+			require.Equal(it.Value(), value)
+		}
+		require.NoError(it.Error())
+		it.Release()
+	}
+}
+
+func TestSnapshotExportHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+
+	oldRootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte("key3"), []byte("value3")))
+	require.NoError(db.Delete([]byte("key1")))
+
+	var snapshot bytes.Buffer
+	require.NoError(db.ExportSnapshot(context.Background(), oldRootID, 1, &snapshot))
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	importedRootID, err := importedDB.ImportSnapshot(context.Background(), &snapshot)
+	require.NoError(err)
+	require.Equal(oldRootID, importedRootID)
+
+	value, err := importedDB.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1"), value)
+
+	_, err = importedDB.Get([]byte("key3"))
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestSnapshotEmpty(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	var snapshot bytes.Buffer
+	require.NoError(db.ExportSnapshot(context.Background(), ids.Empty, 10, &snapshot))
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	importedRootID, err := importedDB.ImportSnapshot(context.Background(), &snapshot)
+	require.NoError(err)
+	require.Equal(ids.Empty, importedRootID)
+}
+
+func TestSnapshotExportErrors(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	err = db.ExportSnapshot(context.Background(), rootID, 0, io.Discard)
+	require.ErrorIs(err, ErrInvalidMaxLength)
+
+	err = db.ExportSnapshot(context.Background(), ids.GenerateTestID(), 10, io.Discard)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
+func TestSnapshotImportErrors(t *testing.T) {
+	db, err := getBasicDB()
+	require.NoError(t, err)
+	require.NoError(t, db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(t, db.Put([]byte("key2"), []byte("value2")))
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	var snapshot bytes.Buffer
+	require.NoError(t, db.ExportSnapshot(context.Background(), rootID, 10, &snapshot))
+	snapshotBytes := snapshot.Bytes()
+
+	// Modify a value in the first chunk.
+	chunk, err := readSnapshotChunk(bytes.NewReader(snapshotBytes[snapshotHeaderLen:]))
+	require.NoError(t, err)
+
+	var pbProof pb.RangeProof
+	require.NoError(t, proto.Unmarshal(chunk, &pbProof))
+	pbProof.KeyValues[0].Value = []byte("modified")
+	chunk, err = proto.Marshal(&pbProof)
+	require.NoError(t, err)
+
+	var modifiedSnapshot bytes.Buffer
+	_, _ = modifiedSnapshot.Write(snapshotBytes[:snapshotHeaderLen])
+	require.NoError(t, writeSnapshotChunk(&modifiedSnapshot, chunk))
+	require.NoError(t, writeSnapshotChunk(&modifiedSnapshot, nil))
+
+	unknownVersionSnapshot := bytes.Clone(snapshotBytes)
+	unknownVersionSnapshot[1]++
+
+	branchFactor2DB, err := getBasicDBWithBranchFactor(BranchFactor2)
+	require.NoError(t, err)
+
+	type test struct {
+		name        string
+		db          MerkleDB
+		snapshot    []byte
+		expectedErr error
+	}
+
+	tests := []test{
+		{
+			name:        "database not empty",
+			db:          db,
+			snapshot:    snapshotBytes,
+			expectedErr: ErrDatabaseNotEmpty,
+		},
+		{
+			name:        "unknown version",
+			snapshot:    unknownVersionSnapshot,
+			expectedErr: ErrUnknownSnapshotVersion,
+		},
+		{
+			name:        "branch factor mismatch",
+			db:          branchFactor2DB,
+			snapshot:    snapshotBytes,
+			expectedErr: ErrSnapshotBranchFactor,
+		},
+		{
+			name:        "truncated",
+			snapshot:    snapshotBytes[:len(snapshotBytes)-5],
+			expectedErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:        "invalid proof",
+			snapshot:    modifiedSnapshot.Bytes(),
+			expectedErr: ErrInvalidProof,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			importedDB := tt.db
+			if importedDB == nil {
+				importedDB, err = getBasicDB()
+				require.NoError(err)
+			}
+
+			_, err := importedDB.ImportSnapshot(context.Background(), bytes.NewReader(tt.snapshot))
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestSnapshotImportMissingChunk(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	var snapshot bytes.Buffer
+	require.NoError(db.ExportSnapshot(context.Background(), rootID, 5, &snapshot))
+
+	// Drop the first chunk.
+	reader := bytes.NewReader(snapshot.Bytes())
+	header := make([]byte, snapshotHeaderLen)
+	_, err = io.ReadFull(reader, header)
+	require.NoError(err)
+	_, err = readSnapshotChunk(reader)
+	require.NoError(err)
+
+	remaining, err := io.ReadAll(reader)
+	require.NoError(err)
+
+	importedDB, err := New(context.Background(), memdb.New(), newDefaultConfig())
+	require.NoError(err)
+	_, err = importedDB.ImportSnapshot(context.Background(), io.MultiReader(bytes.NewReader(header), bytes.NewReader(remaining)))
+	require.ErrorIs(err, ErrProofNodeHasUnincludedValue)
+}
+
+func putRandomKeyValues(db database.KeyValueWriter, r *rand.Rand, numKeys int) error {
+	for i := 0; i < numKeys; i++ {
+		key := make([]byte, r.Intn(32)+1)
+		_, _ = r.Read(key)
+		value := make([]byte, r.Intn(32)+1)
+		_, _ = r.Read(value)
+		if err := db.Put(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -84,6 +84,28 @@ type Trie interface {
 	) (View, error)
 }
 
+// ReadOnlyView is a view of the trie that can't be modified.
+type ReadOnlyView interface {
+	MerkleRootGetter
+	ProofGetter
+	database.Iteratee
+
+	// GetValue gets the value associated with the specified key
+	// database.ErrNotFound if the key is not present
+	GetValue(ctx context.Context, key []byte) ([]byte, error)
+
+	// GetValues gets the values associated with the specified keys
+	// database.ErrNotFound if the key is not present
+	GetValues(ctx context.Context, keys [][]byte) ([][]byte, []error)
+
+	// GetRangeProof returns a proof of up to [maxLength] key-value pairs with
+	// keys in range [start, end].
+	// If [start] is Nothing, there's no lower bound on the range.
+	// If [end] is Nothing, there's no upper bound on the range.
+	// Returns ErrEmptyProof if the trie is empty.
+	GetRangeProof(ctx context.Context, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte], maxLength int) (*RangeProof, error)
+}
+
 type View interface {
 	Trie
 
@@ -110,6 +110,15 @@ func (b *valueNodeBatch) Delete(key Key) {
 // Write flushes any accumulated data to the underlying database.
 func (b *valueNodeBatch) Write() error {
 	dbBatch := b.db.baseDB.NewBatch()
+	if err := b.writeTo(dbBatch); err != nil {
+		return err
+	}
+	return dbBatch.Write()
+}
+
+// writeTo adds the accumulated data to [dbBatch], which must be a batch of
+// the underlying database.
+func (b *valueNodeBatch) writeTo(dbBatch database.KeyValueWriterDeleter) error {
 	for key, n := range b.ops {
 		b.db.metrics.DatabaseNodeWrite()
 		b.db.nodeCache.Put(key, n)
@@ -124,8 +133,7 @@ func (b *valueNodeBatch) Write() error {
 
 		b.db.bufferPool.Put(prefixedKey)
 	}
-
-	return dbBatch.Write()
+	return nil
 }
 
 type iterator struct {
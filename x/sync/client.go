@@ -8,10 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"runtime"
 	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -72,6 +74,10 @@ type client struct {
 	log              logging.Logger
 	metrics          SyncMetrics
 	tokenSize        int
+
+	// verificationSema controls the number of responses that are parsed and
+	// verified at any given time.
+	verificationSema *semaphore.Weighted
 }
 
 type ClientConfig struct {
@@ -80,18 +86,31 @@ type ClientConfig struct {
 	Log              logging.Logger
 	Metrics          SyncMetrics
 	BranchFactor     merkledb.BranchFactor
+
+	// VerificationConcurrency is the number of responses that can be verified
+	// in parallel. Verifying a proof is CPU bound, so this is independent of
+	// the number of outstanding requests.
+	//
+	// If 0 is specified, [runtime.NumCPU] will be used.
+	VerificationConcurrency uint
 }
 
 func NewClient(config *ClientConfig) (Client, error) {
 	if err := config.BranchFactor.Valid(); err != nil {
 		return nil, err
 	}
+
+	verificationConcurrency := uint(runtime.NumCPU())
+	if config.VerificationConcurrency != 0 {
+		verificationConcurrency = config.VerificationConcurrency
+	}
 	return &client{
-		networkClient:  config.NetworkClient,
-		stateSyncNodes: config.StateSyncNodeIDs,
-		log:            config.Log,
-		metrics:        config.Metrics,
-		tokenSize:      merkledb.BranchFactorToTokenSize[config.BranchFactor],
+		networkClient:    config.NetworkClient,
+		stateSyncNodes:   config.StateSyncNodeIDs,
+		log:              config.Log,
+		metrics:          config.Metrics,
+		tokenSize:        merkledb.BranchFactorToTokenSize[config.BranchFactor],
+		verificationSema: semaphore.NewWeighted(int64(verificationConcurrency)),
 	}, nil
 }
 
@@ -304,7 +323,7 @@ func getAndParse[T any](
 	for attempt := 1; ; attempt++ {
 		nodeID, responseBytes, err := client.get(ctx, request)
 		if err == nil {
-			if response, err = parseFn(ctx, responseBytes); err == nil {
+			if response, err = verify(ctx, client, responseBytes, parseFn); err == nil {
 				return response, nil
 			}
 		}
@@ -344,6 +363,22 @@ func getAndParse[T any](
 	}
 }
 
+// verify parses and verifies [responseBytes] with [parseFn] once one of the
+// client's verification slots is available.
+func verify[T any](
+	ctx context.Context,
+	client *client,
+	responseBytes []byte,
+	parseFn func(context.Context, []byte) (*T, error),
+) (*T, error) {
+	if err := client.verificationSema.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	defer client.verificationSema.Release(1)
+
+	return parseFn(ctx, responseBytes)
+}
+
 // get sends [request] to an arbitrary peer and blocks
 // until the node receives a response, failure notification
 // or [ctx] is canceled.
@@ -11,6 +11,7 @@ import (
 	"slices"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
@@ -36,6 +37,8 @@ var (
 	ErrNoLogProvided              = errors.New("log is a required field of the sync config")
 	ErrZeroWorkLimit              = errors.New("simultaneous work limit must be greater than 0")
 	ErrFinishedWithUnexpectedRoot = errors.New("finished syncing with an unexpected root")
+	ErrUnknownSyncTarget          = errors.New("unknown sync target")
+	ErrRemoveOnlySyncTarget       = errors.New("cannot remove the only sync target")
 )
 
 type priority byte
@@ -68,10 +71,41 @@ func newWorkItem(localRootID ids.ID, start maybe.Maybe[[]byte], end maybe.Maybe[
 	}
 }
 
+// syncTarget is a root that the manager may sync to.
+type syncTarget struct {
+	// The peers that are able to serve proofs for this root.
+	peers set.Set[ids.NodeID]
+	// Used to break ties between targets that can be served by the same
+	// number of peers. Targets that were added later are preferred.
+	index uint64
+}
+
+// pendingCommit is a verified range proof for [work] that hasn't been
+// committed to the database yet.
+type pendingCommit struct {
+	work         *workItem
+	targetRootID ids.ID
+	proof        *merkledb.RangeProof
+}
+
+// inFlightWork is a work item that is currently requesting a proof.
+type inFlightWork struct {
+	// The root the proof is being requested for.
+	targetRootID ids.ID
+	// Cancels the request.
+	cancel context.CancelFunc
+}
+
 type Manager struct {
-	// Must be held when accessing [config.TargetRoot].
+	// Must be held when accessing [config.TargetRoot], [targets], or
+	// [nextTargetIndex].
 	syncTargetLock sync.RWMutex
 	config         ManagerConfig
+	// The candidate roots to sync to. Always contains [config.TargetRoot].
+	targets         map[ids.ID]*syncTarget
+	nextTargetIndex uint64
+
+	metrics *managerMetrics
 
 	workLock sync.Mutex
 	// The number of work items currently being processed.
@@ -88,6 +122,22 @@ type Manager struct {
 	unprocessedWorkCond sync.Cond
 	// [workLock] must be held while accessing [processedWork].
 	processedWork *workHeap
+	// The work items whose proofs are being requested.
+	// [workLock] must be held while accessing [inFlightWork].
+	inFlightWork map[*workItem]inFlightWork
+
+	// The range proofs that have been verified but not yet committed.
+	// [workLock] must be held while accessing [pendingCommits].
+	pendingCommits []*pendingCommit
+	// The number of work items whose range proofs are waiting to be, or are
+	// being, committed.
+	// [workLock] must be held when accessing [committingWorkItems].
+	committingWorkItems int
+	// Signalled when:
+	// - An item is added to [pendingCommits].
+	// - Close() is called.
+	// [workLock] is its inner lock.
+	pendingCommitsCond sync.Cond
 
 	// When this is closed:
 	// - [closed] is true.
@@ -116,6 +166,10 @@ type ManagerConfig struct {
 	Log                   logging.Logger
 	TargetRoot            ids.ID
 	BranchFactor          merkledb.BranchFactor
+	// Namespace and Registerer are used to report the manager's metrics. If
+	// [Registerer] is nil, metrics aren't reported.
+	Namespace  string
+	Registerer prometheus.Registerer
 }
 
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -133,14 +187,26 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		return nil, err
 	}
 
+	metrics, err := newManagerMetrics(config.Namespace, config.Registerer)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &Manager{
-		config:          config,
+		config: config,
+		targets: map[ids.ID]*syncTarget{
+			config.TargetRoot: {},
+		},
+		nextTargetIndex: 1,
+		metrics:         metrics,
 		doneChan:        make(chan struct{}),
 		unprocessedWork: newWorkHeap(),
 		processedWork:   newWorkHeap(),
+		inFlightWork:    make(map[*workItem]inFlightWork),
 		tokenSize:       merkledb.BranchFactorToTokenSize[config.BranchFactor],
 	}
 	m.unprocessedWorkCond.L = &m.workLock
+	m.pendingCommitsCond.L = &m.workLock
 
 	return m, nil
 }
@@ -163,6 +229,7 @@ func (m *Manager) Start(ctx context.Context) error {
 	ctx, m.cancelCtx = context.WithCancel(ctx)
 
 	go m.sync(ctx)
+	go m.commit(ctx)
 	return nil
 }
 
@@ -187,10 +254,14 @@ func (m *Manager) sync(ctx context.Context) {
 			// We're already processing the maximum number of work items.
 			// Wait until one of them finishes.
 			m.unprocessedWorkCond.Wait()
+		case m.committingWorkItems >= m.config.SimultaneousWorkLimit:
+			// The database can't keep up with the verified proofs.
+			// Wait until some of them are committed.
+			m.unprocessedWorkCond.Wait()
 		case m.unprocessedWork.Len() == 0:
-			if m.processingWorkItems == 0 {
+			if m.processingWorkItems == 0 && m.committingWorkItems == 0 {
 				// There's no work to do, and there are no work items being processed
-				// which could cause work to be added, so we're done.
+				// or committed which could cause work to be added, so we're done.
 				return // [m.workLock] released by defer.
 			}
 			// There's no work to do.
@@ -228,6 +299,7 @@ func (m *Manager) close() {
 		m.unprocessedWork.Close()
 		m.unprocessedWorkCond.Signal()
 		m.processedWork.Close()
+		m.pendingCommitsCond.Broadcast()
 
 		// signal all code waiting on the sync to complete
 		close(m.doneChan)
@@ -237,28 +309,80 @@ func (m *Manager) close() {
 // Processes [item] by fetching and applying a change or range proof.
 // Assumes [m.workLock] is not held.
 func (m *Manager) doWork(ctx context.Context, work *workItem) {
+	// [requestCtx] is cancelled if the manager pivots to a different target
+	// while the proof is being requested.
+	requestCtx, cancel := context.WithCancel(ctx)
+	targetRootID := m.startWork(work, cancel)
+
 	defer func() {
+		cancel()
+
 		m.workLock.Lock()
 		defer m.workLock.Unlock()
 
+		delete(m.inFlightWork, work)
 		m.processingWorkItems--
 		m.unprocessedWorkCond.Signal()
 	}()
 
 	if work.localRootID == ids.Empty {
 		// the keys in this range have not been downloaded, so get all key/values
-		m.getAndApplyRangeProof(ctx, work)
+		m.getAndApplyRangeProof(ctx, requestCtx, work, targetRootID)
 	} else {
 		// the keys in this range have already been downloaded, but the root changed, so get all changes
-		m.getAndApplyChangeProof(ctx, work)
+		m.getAndApplyChangeProof(ctx, requestCtx, work, targetRootID)
 	}
 }
 
-// Fetch and apply the change proof given by [work].
+// startWork records that a proof for [work] is being requested and returns the
+// root the proof should be requested for.
+// Assumes [m.syncTargetLock] and [m.workLock] are not held.
+func (m *Manager) startWork(work *workItem, cancel context.CancelFunc) ids.ID {
+	// Hold [syncTargetLock] so that a pivot can't happen between reading the
+	// target and recording the request.
+	m.syncTargetLock.RLock()
+	defer m.syncTargetLock.RUnlock()
+
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	targetRootID := m.config.TargetRoot
+	m.inFlightWork[work] = inFlightWork{
+		targetRootID: targetRootID,
+		cancel:       cancel,
+	}
+	return targetRootID
+}
+
+// handleRequestError handles an error returned by the client when requesting a
+// proof for [work].
+//
+// If the request was cancelled because the manager pivoted to a different
+// target, [work] is retried for the new target. Otherwise, [err] is fatal.
 // Assumes [m.workLock] is not held.
-func (m *Manager) getAndApplyChangeProof(ctx context.Context, work *workItem) {
-	targetRootID := m.getTargetRoot()
+func (m *Manager) handleRequestError(ctx context.Context, requestCtx context.Context, work *workItem, err error) {
+	if ctx.Err() != nil || requestCtx.Err() == nil {
+		m.setError(err)
+		return
+	}
+
+	m.metrics.requestsCancelled.Inc()
+	m.config.Log.Debug("cancelled request after pivot",
+		zap.Stringer("start", work.start),
+		zap.Stringer("end", work.end),
+		zap.Error(err),
+	)
+
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	work.priority = highPriority
+	m.unprocessedWork.Insert(work)
+}
 
+// Fetch and apply the change proof given by [work].
+// Assumes [m.workLock] is not held.
+func (m *Manager) getAndApplyChangeProof(ctx context.Context, requestCtx context.Context, work *workItem, targetRootID ids.ID) {
 	if work.localRootID == targetRootID {
 		// Start root is the same as the end root, so we're done.
 		m.completeWorkItem(ctx, work, work.end, targetRootID, nil)
@@ -278,7 +402,7 @@ func (m *Manager) getAndApplyChangeProof(ctx context.Context, work *workItem) {
 	}
 
 	changeOrRangeProof, err := m.config.Client.GetChangeProof(
-		ctx,
+		requestCtx,
 		&pb.SyncGetChangeProofRequest{
 			StartRootHash: work.localRootID[:],
 			EndRootHash:   targetRootID[:],
@@ -296,7 +420,7 @@ func (m *Manager) getAndApplyChangeProof(ctx context.Context, work *workItem) {
 		m.config.DB,
 	)
 	if err != nil {
-		m.setError(err)
+		m.handleRequestError(ctx, requestCtx, work, err)
 		return
 	}
 
@@ -326,24 +450,18 @@ func (m *Manager) getAndApplyChangeProof(ctx context.Context, work *workItem) {
 
 	// The server responded with a range proof.
 	rangeProof := changeOrRangeProof.RangeProof
-	largestHandledKey := work.end
 	if len(rangeProof.KeyValues) > 0 {
 		// Add all the key-value pairs we got to the database.
-		if err := m.config.DB.CommitRangeProof(ctx, work.start, work.end, rangeProof); err != nil {
-			m.setError(err)
-			return
-		}
-		largestHandledKey = maybe.Some(rangeProof.KeyValues[len(rangeProof.KeyValues)-1].Key)
+		m.enqueueCommit(work, targetRootID, rangeProof)
+		return
 	}
 
-	m.completeWorkItem(ctx, work, largestHandledKey, targetRootID, rangeProof.EndProof)
+	m.completeWorkItem(ctx, work, work.end, targetRootID, rangeProof.EndProof)
 }
 
 // Fetch and apply the range proof given by [work].
 // Assumes [m.workLock] is not held.
-func (m *Manager) getAndApplyRangeProof(ctx context.Context, work *workItem) {
-	targetRootID := m.getTargetRoot()
-
+func (m *Manager) getAndApplyRangeProof(ctx context.Context, requestCtx context.Context, work *workItem, targetRootID ids.ID) {
 	if targetRootID == ids.Empty {
 		if err := m.config.DB.Clear(); err != nil {
 			m.setError(err)
@@ -354,7 +472,7 @@ func (m *Manager) getAndApplyRangeProof(ctx context.Context, work *workItem) {
 		return
 	}
 
-	proof, err := m.config.Client.GetRangeProof(ctx,
+	proof, err := m.config.Client.GetRangeProof(requestCtx,
 		&pb.SyncGetRangeProofRequest{
 			RootHash: targetRootID[:],
 			StartKey: &pb.MaybeBytes{
@@ -370,30 +488,113 @@ func (m *Manager) getAndApplyRangeProof(ctx context.Context, work *workItem) {
 		},
 	)
 	if err != nil {
-		m.setError(err)
+		m.handleRequestError(ctx, requestCtx, work, err)
 		return
 	}
 
+	// Replace all the key-value pairs in the DB from start to end with values from the response.
+	m.enqueueCommit(work, targetRootID, proof)
+}
+
+// enqueueCommit queues the verified [proof] for [work] to be committed by
+// [commit].
+// Assumes [m.workLock] is not held.
+func (m *Manager) enqueueCommit(work *workItem, targetRootID ids.ID, proof *merkledb.RangeProof) {
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	m.pendingCommits = append(m.pendingCommits, &pendingCommit{
+		work:         work,
+		targetRootID: targetRootID,
+		proof:        proof,
+	})
+	m.committingWorkItems++
+	m.pendingCommitsCond.Signal()
+}
+
+// commit writes verified range proofs to the database until the manager is
+// closed.
+//
+// Proofs that are verified while a batch is being committed are committed
+// together in the next batch. This allows verification to happen in parallel
+// while the database applies the changes of multiple proofs at once.
+func (m *Manager) commit(ctx context.Context) {
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	for {
+		// Invariant: [m.workLock] is held here.
+		for len(m.pendingCommits) == 0 && !m.isClosed() {
+			m.pendingCommitsCond.Wait()
+		}
+		if m.isClosed() {
+			return // [m.workLock] released by defer.
+		}
+
+		commits := m.pendingCommits
+		m.pendingCommits = nil
+		m.workLock.Unlock()
+
+		m.commitRangeProofs(ctx, commits)
+
+		m.workLock.Lock()
+		m.committingWorkItems -= len(commits)
+		m.unprocessedWorkCond.Signal()
+	}
+}
+
+// commitRangeProofs writes [commits] to the database, ordered by the start of
+// their ranges, and marks their work items as completed.
+// Assumes [m.workLock] is not held.
+func (m *Manager) commitRangeProofs(ctx context.Context, commits []*pendingCommit) {
+	slices.SortFunc(commits, func(a, b *pendingCommit) int {
+		return bytes.Compare(a.work.start.Value(), b.work.start.Value())
+	})
+
 	select {
 	case <-m.doneChan:
-		// If we're closed, don't apply the proof.
+		// If we're closed, don't apply the proofs.
 		return
 	default:
 	}
 
-	largestHandledKey := work.end
-
-	// Replace all the key-value pairs in the DB from start to end with values from the response.
-	if err := m.config.DB.CommitRangeProof(ctx, work.start, work.end, proof); err != nil {
+	if err := m.writeRangeProofs(ctx, commits); err != nil {
 		m.setError(err)
 		return
 	}
+	m.metrics.commits.Inc()
+	m.metrics.committedProofs.Add(float64(len(commits)))
+
+	for _, commit := range commits {
+		largestHandledKey := commit.work.end
+		if keyValues := commit.proof.KeyValues; len(keyValues) > 0 {
+			largestHandledKey = maybe.Some(keyValues[len(keyValues)-1].Key)
+		}
+		m.completeWorkItem(ctx, commit.work, largestHandledKey, commit.targetRootID, commit.proof.EndProof)
+	}
+}
 
-	if len(proof.KeyValues) > 0 {
-		largestHandledKey = maybe.Some(proof.KeyValues[len(proof.KeyValues)-1].Key)
+// writeRangeProofs commits [commits] to the database. If the database
+// supports it, all of the proofs are committed at once.
+func (m *Manager) writeRangeProofs(ctx context.Context, commits []*pendingCommit) error {
+	if db, ok := m.config.DB.(merkledb.RangeProofBatchCommitter); ok && len(commits) > 1 {
+		proofs := make([]merkledb.KeyRangeProof, len(commits))
+		for i, commit := range commits {
+			proofs[i] = merkledb.KeyRangeProof{
+				Start: commit.work.start,
+				End:   commit.work.end,
+				Proof: commit.proof,
+			}
+		}
+		return db.CommitRangeProofs(ctx, proofs)
 	}
 
-	m.completeWorkItem(ctx, work, largestHandledKey, targetRootID, proof.EndProof)
+	for _, commit := range commits {
+		if err := m.config.DB.CommitRangeProof(ctx, commit.work.start, commit.work.end, commit.proof); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // findNextKey returns the start of the key range that should be fetched next
@@ -604,33 +805,172 @@ func (m *Manager) Wait(ctx context.Context) error {
 	return nil
 }
 
+// UpdateSyncTarget replaces all of the candidate sync targets with
+// [syncTargetRoot] and syncs to it.
 func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 	m.syncTargetLock.Lock()
 	defer m.syncTargetLock.Unlock()
 
-	m.workLock.Lock()
-	defer m.workLock.Unlock()
+	if m.isClosed() {
+		return ErrAlreadyClosed
+	}
 
-	select {
-	case <-m.doneChan:
+	target, ok := m.targets[syncTargetRoot]
+	if !ok {
+		target = m.newSyncTarget()
+	}
+	m.targets = map[ids.ID]*syncTarget{
+		syncTargetRoot: target,
+	}
+	m.pivot(syncTargetRoot)
+	return nil
+}
+
+// AddSyncTarget adds [root] as a candidate sync target that can be served by
+// [nodeIDs].
+//
+// The manager syncs to the candidate that can be served by the most peers.
+// If that changes, the manager pivots to the new target. Ranges that were
+// already synced to a previous target are updated with change proofs rather
+// than being synced again.
+func (m *Manager) AddSyncTarget(root ids.ID, nodeIDs ...ids.NodeID) error {
+	m.syncTargetLock.Lock()
+	defer m.syncTargetLock.Unlock()
+
+	if m.isClosed() {
+		return ErrAlreadyClosed
+	}
+
+	target, ok := m.targets[root]
+	if !ok {
+		target = m.newSyncTarget()
+		m.targets[root] = target
+	}
+	target.peers.Add(nodeIDs...)
+
+	m.pivot(m.bestSyncTarget())
+	return nil
+}
+
+// RemoveSyncTarget marks that [nodeIDs] can no longer serve [root]. If no
+// [nodeIDs] are provided, [root] is no longer a candidate sync target.
+//
+// If the current target is no longer the candidate that can be served by the
+// most peers, the manager pivots to the best candidate.
+func (m *Manager) RemoveSyncTarget(root ids.ID, nodeIDs ...ids.NodeID) error {
+	m.syncTargetLock.Lock()
+	defer m.syncTargetLock.Unlock()
+
+	if m.isClosed() {
 		return ErrAlreadyClosed
+	}
+
+	target, ok := m.targets[root]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownSyncTarget, root)
+	}
+
+	if len(nodeIDs) == 0 {
+		if len(m.targets) == 1 {
+			return fmt.Errorf("%w: %s", ErrRemoveOnlySyncTarget, root)
+		}
+		delete(m.targets, root)
+	} else {
+		target.peers.Remove(nodeIDs...)
+	}
+
+	m.pivot(m.bestSyncTarget())
+	return nil
+}
+
+// newSyncTarget returns a candidate that is preferred over every existing
+// candidate that can be served by the same number of peers.
+// Assumes [m.syncTargetLock] is held.
+func (m *Manager) newSyncTarget() *syncTarget {
+	target := &syncTarget{
+		index: m.nextTargetIndex,
+	}
+	m.nextTargetIndex++
+	return target
+}
+
+// bestSyncTarget returns the candidate that can be served by the most peers.
+// The current target is kept if no candidate can be served by more peers.
+// Assumes [m.syncTargetLock] is held.
+func (m *Manager) bestSyncTarget() ids.ID {
+	var (
+		bestRoot   ids.ID
+		bestTarget *syncTarget
+	)
+	for root, target := range m.targets {
+		if bestTarget == nil || m.isBetterSyncTarget(root, target, bestRoot, bestTarget) {
+			bestRoot = root
+			bestTarget = target
+		}
+	}
+	return bestRoot
+}
+
+// isBetterSyncTarget returns true if [root] should be synced to rather than
+// [otherRoot].
+// Assumes [m.syncTargetLock] is held.
+func (m *Manager) isBetterSyncTarget(root ids.ID, target *syncTarget, otherRoot ids.ID, other *syncTarget) bool {
+	numPeers := target.peers.Len()
+	otherNumPeers := other.peers.Len()
+	switch {
+	case numPeers != otherNumPeers:
+		return numPeers > otherNumPeers
+	case root == m.config.TargetRoot:
+		// Avoid pivoting unless another target can be served by more peers.
+		return true
+	case otherRoot == m.config.TargetRoot:
+		return false
 	default:
+		return target.index > other.index
 	}
+}
 
+// pivot makes [syncTargetRoot] the sync target.
+//
+// All completed ranges are re-queued so that they are updated to the new
+// target with change proofs, and all requests for proofs of a different root
+// are cancelled.
+// Assumes [m.syncTargetLock] is held and [m.workLock] is not held.
+func (m *Manager) pivot(syncTargetRoot ids.ID) {
 	if m.config.TargetRoot == syncTargetRoot {
 		// the target hasn't changed, so there is nothing to do
-		return nil
+		return
+	}
+
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	if m.isClosed() {
+		// Sync finished or failed after the target was updated.
+		return
 	}
 
-	m.config.Log.Debug("updated sync target", zap.Stringer("target", syncTargetRoot))
+	m.config.Log.Debug("updated sync target",
+		zap.Stringer("previous", m.config.TargetRoot),
+		zap.Stringer("target", syncTargetRoot),
+	)
 	m.config.TargetRoot = syncTargetRoot
+	m.metrics.pivots.Inc()
+
+	// Requests for proofs of other roots would be wasted work. They are
+	// re-queued by [handleRequestError] once cancelled.
+	for _, inFlight := range m.inFlightWork {
+		if inFlight.targetRootID != syncTargetRoot {
+			inFlight.cancel()
+		}
+	}
 
 	// move all completed ranges into the work heap with high priority
 	shouldSignal := m.processedWork.Len() > 0
 	for m.processedWork.Len() > 0 {
 		// Note that [m.processedWork].Close() hasn't
 		// been called because we have [m.workLock]
-		// and we checked that [m.closed] is false.
+		// and we checked that [m.doneChan] isn't closed.
 		currentItem := m.processedWork.GetWork()
 		currentItem.priority = highPriority
 		m.unprocessedWork.Insert(currentItem)
@@ -640,7 +980,15 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 		// waiting on [m.unprocessedWorkCond].
 		m.unprocessedWorkCond.Signal()
 	}
-	return nil
+}
+
+func (m *Manager) isClosed() bool {
+	select {
+	case <-m.doneChan:
+		return true
+	default:
+		return false
+	}
 }
 
 func (m *Manager) getTargetRoot() ids.ID {
@@ -708,6 +1056,7 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 	stale := m.config.TargetRoot != rootID
 	if stale {
 		// the root has changed, so reinsert with high priority
+		m.metrics.staleRanges.Inc()
 		m.enqueueWork(newWorkItem(rootID, work.start, largestHandledKey, highPriority))
 	} else {
 		m.workLock.Lock()
@@ -93,3 +93,54 @@ func (m *metrics) RequestMade() {
 func (m *metrics) RequestSucceeded() {
 	m.requestsSucceeded.Inc()
 }
+
+// managerMetrics tracks how often the manager changes its sync target, the
+// work that is repeated as a result, and how proofs are committed.
+type managerMetrics struct {
+	pivots            prometheus.Counter
+	requestsCancelled prometheus.Counter
+	staleRanges       prometheus.Counter
+	commits           prometheus.Counter
+	committedProofs   prometheus.Counter
+}
+
+func newManagerMetrics(namespace string, reg prometheus.Registerer) (*managerMetrics, error) {
+	m := &managerMetrics{
+		pivots: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pivots",
+			Help:      "cumulative amount of times the sync target changed",
+		}),
+		requestsCancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_cancelled",
+			Help:      "cumulative amount of proof requests that were cancelled because the sync target changed",
+		}),
+		staleRanges: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stale_ranges",
+			Help:      "cumulative amount of ranges that were synced to a previous sync target and must be updated with a change proof",
+		}),
+		commits: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "range_proof_commits",
+			Help:      "cumulative amount of batches of range proofs committed to the database",
+		}),
+		committedProofs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "range_proofs_committed",
+			Help:      "cumulative amount of range proofs committed to the database",
+		}),
+	}
+	if reg == nil {
+		return m, nil
+	}
+	err := utils.Err(
+		reg.Register(m.pivots),
+		reg.Register(m.requestsCancelled),
+		reg.Register(m.staleRanges),
+		reg.Register(m.commits),
+		reg.Register(m.committedProofs),
+	)
+	return m, err
+}
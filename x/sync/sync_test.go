@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
@@ -1142,6 +1144,142 @@ func Test_Sync_UpdateSyncTarget(t *testing.T) {
 	require.Equal(1, m.unprocessedWork.Len())
 }
 
+func Test_Sync_SyncTargetSelection(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	initialRoot := ids.GenerateTestID()
+	m, err := NewManager(ManagerConfig{
+		DB:                    merkledb.NewMockMerkleDB(ctrl), // Not used
+		Client:                NewMockClient(ctrl),            // Not used
+		TargetRoot:            initialRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	var (
+		root1 = ids.GenerateTestID()
+		root2 = ids.GenerateTestID()
+		node1 = ids.GenerateTestNodeID()
+		node2 = ids.GenerateTestNodeID()
+	)
+
+	// [root1] can be served by more peers than [initialRoot].
+	require.NoError(m.AddSyncTarget(root1, node1))
+	require.Equal(root1, m.getTargetRoot())
+
+	// Ties are broken in favor of the current target.
+	require.NoError(m.AddSyncTarget(root2, node1))
+	require.Equal(root1, m.getTargetRoot())
+
+	require.NoError(m.AddSyncTarget(root2, node2))
+	require.Equal(root2, m.getTargetRoot())
+
+	require.NoError(m.RemoveSyncTarget(root2, node1, node2))
+	require.Equal(root1, m.getTargetRoot())
+
+	// If no peers can serve any target, the most recently added target is
+	// preferred.
+	require.NoError(m.RemoveSyncTarget(root1))
+	require.Equal(root2, m.getTargetRoot())
+
+	err = m.RemoveSyncTarget(root1)
+	require.ErrorIs(err, ErrUnknownSyncTarget)
+
+	require.NoError(m.RemoveSyncTarget(initialRoot))
+	err = m.RemoveSyncTarget(root2)
+	require.ErrorIs(err, ErrRemoveOnlySyncTarget)
+
+	// UpdateSyncTarget replaces all candidates.
+	require.NoError(m.AddSyncTarget(root1, node1))
+	require.Equal(root1, m.getTargetRoot())
+	require.NoError(m.UpdateSyncTarget(initialRoot))
+	require.Equal(initialRoot, m.getTargetRoot())
+	require.Len(m.targets, 1)
+
+	require.Equal(float64(6), testutil.ToFloat64(m.metrics.pivots))
+}
+
+// Tests that requests for a root that peers can't serve are cancelled when the
+// manager pivots to a root that peers can serve.
+func Test_Sync_PivotCancelsRequests(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+
+	unavailableRoot := ids.GenerateTestID()
+	requestedUnavailableRoot := make(chan struct{}, 1)
+
+	client := NewMockClient(ctrl)
+	client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *pb.SyncGetRangeProofRequest) (*merkledb.RangeProof, error) {
+			root, err := ids.ToID(request.RootHash)
+			require.NoError(err)
+			if root == unavailableRoot {
+				// No peer can serve this root, so the request is retried
+				// until it is cancelled.
+				select {
+				case requestedUnavailableRoot <- struct{}{}:
+				default:
+				}
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return dbToSync.GetRangeProofAtRoot(ctx, root, maybeBytesToMaybe(request.StartKey), maybeBytesToMaybe(request.EndKey), int(request.KeyLimit))
+		},
+	).AnyTimes()
+
+	reg := prometheus.NewRegistry()
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                client,
+		TargetRoot:            unavailableRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+		Namespace:             "sync",
+		Registerer:            reg,
+	})
+	require.NoError(err)
+
+	require.NoError(syncer.Start(context.Background()))
+	<-requestedUnavailableRoot
+
+	require.NoError(syncer.AddSyncTarget(syncRoot, ids.GenerateTestNodeID()))
+
+	require.NoError(syncer.Wait(context.Background()))
+	require.NoError(syncer.Error())
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+
+	require.Equal(float64(1), testutil.ToFloat64(syncer.metrics.pivots))
+	require.Equal(float64(1), testutil.ToFloat64(syncer.metrics.requestsCancelled))
+
+	metrics, err := reg.Gather()
+	require.NoError(err)
+	require.Len(metrics, 5)
+}
+
 func generateTrie(t *testing.T, r *rand.Rand, count int) (merkledb.MerkleDB, error) {
 	db, _, err := generateTrieWithMinKeyLen(t, r, count, 0)
 	return db, err
@@ -1203,3 +1341,74 @@ func generateTrieWithMinKeyLen(t *testing.T, r *rand.Rand, count int, minKeyLen
 	slices.SortFunc(allKeys, bytes.Compare)
 	return db, allKeys, batch.Write()
 }
+
+// rangeProofDB hides the [merkledb.RangeProofBatchCommitter] implementation of
+// the wrapped database.
+type rangeProofDB struct {
+	DB
+}
+
+func Test_Sync_Result_Correct_Root_Committed_Proofs(t *testing.T) {
+	tests := []struct {
+		name  string
+		newDB func(merkledb.MerkleDB) DB
+	}{
+		{
+			name: "batch committer",
+			newDB: func(db merkledb.MerkleDB) DB {
+				return db
+			},
+		},
+		{
+			name: "no batch committer",
+			newDB: func(db merkledb.MerkleDB) DB {
+				return &rangeProofDB{DB: db}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			now := time.Now().UnixNano()
+			t.Logf("seed: %d", now)
+			r := rand.New(rand.NewSource(now)) // #nosec G404
+			dbToSync, err := generateTrie(t, r, 5000)
+			require.NoError(err)
+			syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			db, err := merkledb.New(
+				context.Background(),
+				memdb.New(),
+				newDefaultDBConfig(),
+			)
+			require.NoError(err)
+			syncer, err := NewManager(ManagerConfig{
+				DB:                    test.newDB(db),
+				Client:                newCallthroughSyncClient(ctrl, dbToSync),
+				TargetRoot:            syncRoot,
+				SimultaneousWorkLimit: 16,
+				Log:                   logging.NoLog{},
+				BranchFactor:          merkledb.BranchFactor16,
+			})
+			require.NoError(err)
+			require.NoError(syncer.Start(context.Background()))
+
+			require.NoError(syncer.Wait(context.Background()))
+			require.NoError(syncer.Error())
+
+			newRoot, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(syncRoot, newRoot)
+
+			// Every committed proof was either part of a batch or committed
+			// on its own.
+			numCommits := testutil.ToFloat64(syncer.metrics.commits)
+			numProofs := testutil.ToFloat64(syncer.metrics.committedProofs)
+			require.Positive(numCommits)
+			require.GreaterOrEqual(numProofs, numCommits)
+		})
+	}
+}